@@ -27,6 +27,9 @@ import (
 // schema is a tool to dump the schema for policy-controller resources.
 func main() {
 	registry.Register(&v1alpha1.ClusterImagePolicy{})
+	registry.Register(&v1alpha1.ImagePolicy{})
+	registry.Register(&v1alpha1.PolicyException{})
+	registry.Register(&v1alpha1.Revocation{})
 	registry.Register(&v1alpha1.TrustRoot{})
 	registry.Register(&v1beta1.ClusterImagePolicy{})
 
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync"
 	"time"
 
 	policyduckv1beta1 "github.com/sigstore/policy-controller/pkg/apis/duck/v1beta1"
@@ -29,8 +30,15 @@ import (
 	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
 	"github.com/sigstore/policy-controller/pkg/apis/policy/v1beta1"
 	policycontrollerconfig "github.com/sigstore/policy-controller/pkg/config"
+	"github.com/sigstore/policy-controller/pkg/policyreport"
+	"github.com/sigstore/policy-controller/pkg/reconciler/audit"
 	"github.com/sigstore/policy-controller/pkg/reconciler/clusterimagepolicy"
+	"github.com/sigstore/policy-controller/pkg/reconciler/imagepolicy"
+	"github.com/sigstore/policy-controller/pkg/reconciler/policyexception"
+	"github.com/sigstore/policy-controller/pkg/reconciler/revocation"
 	"github.com/sigstore/policy-controller/pkg/reconciler/trustroot"
+	"github.com/sigstore/policy-controller/pkg/tracing"
+	admissionv1 "k8s.io/api/admission/v1"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
@@ -38,12 +46,21 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	kubeinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
+	"knative.dev/pkg/injection"
+	"knative.dev/pkg/injection/clients/dynamicclient"
 	"knative.dev/pkg/injection/sharedmain"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/metrics"
+	pkgreconciler "knative.dev/pkg/reconciler"
 	"knative.dev/pkg/signals"
 	"knative.dev/pkg/webhook"
 	"knative.dev/pkg/webhook/certificates"
@@ -103,6 +120,36 @@ var (
 	// trustrootResyncPeriod holds the interval which the TrustRoot will resync
 	// This is essential for triggering a reconcile update for potentially stale TUF metadata.
 	trustrootResyncPeriod = flag.Duration("trustroot-resync-period", 24*time.Hour, "The resync period for ClusterImagePolicies. The default is 24h.")
+
+	// resultCacheSize, resultCacheTTL and resultCacheErrorTTL control the
+	// cache of ClusterImagePolicy evaluation results for a given image, so
+	// that for example Deployment => ReplicaSet => Pod admissions do not
+	// need to verify the same image over and over again.
+	resultCacheSize     = flag.Int("policy-result-cache-size", 1000, "The maximum number of policy evaluation results to cache. Set to 0 to disable caching.")
+	resultCacheTTL      = flag.Duration("policy-result-cache-ttl", 5*time.Minute, "How long successful policy evaluation results are cached for. The default is 5m.")
+	resultCacheErrorTTL = flag.Duration("policy-result-cache-error-ttl", 0, "How long failed policy evaluation results are cached for. The default is 0, failures are not cached.")
+
+	// enableAudit turns on the background controller that re-evaluates the
+	// running Pods against the current policies, and auditResyncPeriod how
+	// often it does so even if nothing changed.
+	enableAudit       = flag.Bool("enable-audit", false, "Periodically re-evaluate running Pods against the current policies. The default is false.")
+	auditResyncPeriod = flag.Duration("audit-resync-period", audit.DefaultResyncPeriod, "The interval at which running Pods are re-evaluated. The default is 1h.")
+
+	// policyReports turns on writing the results of admission (and audit, if
+	// enabled) to a wgpolicyk8s.io PolicyReport in each namespace.
+	policyReports           = flag.Bool("policy-reports", false, "Write policy evaluation results to a PolicyReport in each namespace. Requires the wgpolicyk8s.io CRDs to be installed. The default is false.")
+	policyReportFlushPeriod = flag.Duration("policy-report-flush-period", policyreport.DefaultFlushPeriod, "How often the PolicyReports are updated with new results. The default is 10s.")
+	policyReportMaxResults  = flag.Int("policy-report-max-results", policyreport.DefaultMaxResults, "The maximum number of results kept in each PolicyReport, oldest are dropped first. The default is 1000.")
+
+	// admissionEvents turns on recording a Kubernetes Event against the
+	// top-level owner of the resource being admitted when an image fails a
+	// policy.
+	admissionEvents = flag.Bool("admission-events", true, "Record a Kubernetes Event against the top-level owner of the resource for every image that is denied or warned on admission. The default is true.")
+)
+
+var (
+	policyReportWriterOnce   sync.Once
+	policyReportWriterShared *policyreport.Writer
 )
 
 func main() {
@@ -138,6 +185,7 @@ func main() {
 	// Set the policy and trust root resync periods
 	ctx = clusterimagepolicy.ToContext(ctx, *policyResyncPeriod)
 	ctx = pctuf.ToContext(ctx, *trustrootResyncPeriod)
+	ctx = audit.ToContext(ctx, *auditResyncPeriod)
 
 	// This must match the set of resources we configure in
 	// cmd/webhook/main.go in the "types" map.
@@ -147,17 +195,24 @@ func main() {
 	v := version.GetVersionInfo()
 	vJSON, _ := v.JSONString()
 	log.Printf("%v", vJSON)
-	// This calls flag.Parse()
-	sharedmain.MainWithContext(ctx, "policy-controller",
+	ctors := []injection.ControllerConstructor{
 		certificates.NewController,
 		NewValidatingAdmissionController,
 		NewMutatingAdmissionController,
 		trustroot.NewController,
 		clusterimagepolicy.NewController,
+		imagepolicy.NewController,
+		policyexception.NewController,
+		revocation.NewController,
 		NewPolicyValidatingAdmissionController,
 		NewPolicyMutatingAdmissionController,
 		newConversionController,
-	)
+	}
+	if *enableAudit {
+		ctors = append(ctors, newAuditController)
+	}
+	// This calls flag.Parse()
+	sharedmain.MainWithContext(ctx, "policy-controller", ctors...)
 }
 
 var (
@@ -215,14 +270,89 @@ var types = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 var typesCIP = map[schema.GroupVersionKind]resourcesemantics.GenericCRD{
 	// v1alpha1
 	v1alpha1.SchemeGroupVersion.WithKind("ClusterImagePolicy"): &v1alpha1.ClusterImagePolicy{},
+	v1alpha1.SchemeGroupVersion.WithKind("ImagePolicy"):        &v1alpha1.ImagePolicy{},
+	v1alpha1.SchemeGroupVersion.WithKind("PolicyException"):    &v1alpha1.PolicyException{},
+	v1alpha1.SchemeGroupVersion.WithKind("Revocation"):         &v1alpha1.Revocation{},
 	v1alpha1.SchemeGroupVersion.WithKind("TrustRoot"):          &v1alpha1.TrustRoot{},
 	// v1beta1
 	v1beta1.SchemeGroupVersion.WithKind("ClusterImagePolicy"): &v1beta1.ClusterImagePolicy{},
 }
 
+// policyReportWriter returns the PolicyReport writer shared by the
+// admission and audit controllers, or nil if PolicyReports are disabled.
+func policyReportWriter(ctx context.Context) cwebhook.Reporter {
+	if !*policyReports {
+		return nil
+	}
+	policyReportWriterOnce.Do(func() {
+		policyReportWriterShared = policyreport.NewWriter(dynamicclient.Get(ctx), *policyReportMaxResults)
+		go policyReportWriterShared.Run(ctx, *policyReportFlushPeriod)
+	})
+	return policyReportWriterShared
+}
+
+// newEventRecorder returns an EventRecorder for the admission webhook, the
+// same way knative sets one up for each controller.
+func newEventRecorder(ctx context.Context) record.EventRecorder {
+	logger := logging.FromContext(ctx)
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartLogging(logger.Named("event-broadcaster").Infof)
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclient.Get(ctx).CoreV1().Events("")})
+	go func() {
+		<-ctx.Done()
+		broadcaster.Shutdown()
+	}()
+	return broadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: "policy-controller"})
+}
+
+// newEventReporter returns the EventReporter for the admission webhook. The
+// owners of the resources are looked up in informers rather than fetched on
+// admission. These are not registered with injection, since those get
+// started (and cache every ReplicaSet and Job in the cluster) whether
+// admission Events are enabled or not.
+func newEventReporter(ctx context.Context) *cwebhook.EventReporter {
+	factory := kubeinformerfactory.Get(ctx)
+	replicaSetInformer := factory.Apps().V1().ReplicaSets()
+	jobInformer := factory.Batch().V1().Jobs()
+	if err := controller.StartInformers(ctx.Done(), replicaSetInformer.Informer(), jobInformer.Informer()); err != nil {
+		logging.FromContext(ctx).Fatalf("Failed to start the ReplicaSet and Job informers: %v", err)
+	}
+	return cwebhook.NewEventReporter(newEventRecorder(ctx), replicaSetInformer.Lister(), jobInformer.Lister())
+}
+
+func newAuditController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	if reporter := policyReportWriter(ctx); reporter != nil {
+		ctx = cwebhook.WithReporter(ctx, reporter)
+	}
+	return audit.NewController(ctx, cmw)
+}
+
 func NewValidatingAdmissionController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	var resultCache cwebhook.ResultCache = &cwebhook.NoCache{}
+	var onAfterStore []func(name string, value interface{})
+	if *resultCacheSize > 0 {
+		lruCache, err := cwebhook.NewLRUCache(*resultCacheSize, *resultCacheTTL, *resultCacheErrorTTL)
+		if err != nil {
+			logging.FromContext(ctx).Fatalf("Failed to create policy result cache: %v", err)
+		}
+		resultCache = lruCache
+		// Drop the cached results for any ClusterImagePolicy that was
+		// updated or deleted, and all of them when the revocations or the
+		// TrustRoots, which every policy depends on, change.
+		onAfterStore = append(onAfterStore, func(name string, value interface{}) {
+			switch name {
+			case config.ImagePoliciesConfigName:
+				if ipc, ok := value.(*config.ImagePolicyConfig); ok && ipc != nil {
+					lruCache.EvictStale(ipc.Policies)
+				}
+			case config.RevocationsConfigName, config.SigstoreKeysConfigName:
+				lruCache.Purge()
+			}
+		})
+	}
+
 	// Decorate contexts with the current state of the config.
-	store := config.NewStore(logging.FromContext(ctx).Named("config-store"))
+	store := config.NewStore(logging.FromContext(ctx).Named("config-store"), onAfterStore...)
 	store.WatchConfigs(cmw)
 	policyControllerConfigStore := policycontrollerconfig.NewStore(logging.FromContext(ctx).Named("config-policy-controller"))
 	policyControllerConfigStore.WatchConfigs(cmw)
@@ -237,8 +367,39 @@ func NewValidatingAdmissionController(ctx context.Context, cmw configmap.Watcher
 
 	kc := kubeclient.Get(ctx)
 	validator := cwebhook.NewValidator(ctx)
+	// The labels of the namespaces are matched against the
+	// namespaceSelector of the policies. The informer is registered with
+	// injection, so sharedmain starts it and waits for it to sync.
+	namespaceLister := namespaceinformer.Get(ctx).Lister()
+	var reporters cwebhook.Reporters
+	if reporter := policyReportWriter(ctx); reporter != nil {
+		reporters = append(reporters, reporter)
+	}
+	if *admissionEvents {
+		reporters = append(reporters, newEventReporter(ctx))
+	}
+	// The decision log is configured in config-policy-controller, and
+	// does nothing unless enabled there.
+	decisionLogger := cwebhook.NewDecisionLogger()
+	go func() {
+		<-ctx.Done()
+		if err := decisionLogger.Close(); err != nil {
+			logger.Warnf("Failed to close decision log: %v", err)
+		}
+	}()
+
+	// Tracing is configured in config-observability, next to the metrics,
+	// and is a no-op unless enabled there.
+	tracingProvider := tracing.NewProvider()
+	cmw.Watch(metrics.ConfigMapName(), tracingProvider.Observer(ctx))
+	go func() {
+		<-ctx.Done()
+		if err := tracingProvider.Shutdown(context.Background()); err != nil {
+			logger.Warnf("Failed to shut down tracing: %v", err)
+		}
+	}()
 
-	return validation.NewAdmissionController(ctx,
+	impl := validation.NewAdmissionController(ctx,
 		// Name of the resource webhook.
 		*webhookName,
 
@@ -253,6 +414,12 @@ func NewValidatingAdmissionController(ctx context.Context, cmw configmap.Watcher
 			ctx = context.WithValue(ctx, kubeclient.Key{}, kc)
 			ctx = store.ToContext(ctx)
 			ctx = policyControllerConfigStore.ToContext(ctx)
+			ctx = cwebhook.ToContext(ctx, resultCache)
+			if len(reporters) > 0 {
+				ctx = cwebhook.WithReporter(ctx, reporters)
+			}
+			ctx = cwebhook.WithDecisionLogger(ctx, decisionLogger)
+			ctx = cwebhook.WithNamespaceLister(ctx, namespaceLister)
 			ctx = policyduckv1beta1.WithPodScalableValidator(ctx, validator.ValidatePodScalable)
 			ctx = duckv1.WithPodValidator(ctx, validator.ValidatePod)
 			ctx = duckv1.WithPodSpecValidator(ctx, validator.ValidatePodSpecable)
@@ -267,6 +434,33 @@ func NewValidatingAdmissionController(ctx context.Context, cmw configmap.Watcher
 		// Extra validating callbacks to be applied to resources.
 		nil,
 	)
+	if r, ok := impl.Reconciler.(admissionReconciler); ok {
+		impl.Reconciler = &requestUIDAdmissionController{admissionReconciler: r}
+	} else {
+		logger.Warn("Admission request UIDs will not be available in the decision log")
+	}
+	return impl
+}
+
+// admissionReconciler is what the knative validating admission controller
+// implements.
+type admissionReconciler interface {
+	controller.Reconciler
+	pkgreconciler.LeaderAware
+	webhook.AdmissionController
+	webhook.StatelessAdmissionController
+}
+
+// requestUIDAdmissionController attaches the UID of the admission request
+// to the context before delegating to the wrapped admission controller,
+// since knative does not make it available to the validators otherwise.
+type requestUIDAdmissionController struct {
+	admissionReconciler
+}
+
+// Admit implements webhook.AdmissionController.
+func (r *requestUIDAdmissionController) Admit(ctx context.Context, req *admissionv1.AdmissionRequest) *admissionv1.AdmissionResponse {
+	return r.admissionReconciler.Admit(cwebhook.WithRequestUID(ctx, string(req.UID)), req)
 }
 
 func NewMutatingAdmissionController(ctx context.Context, _ configmap.Watcher) *controller.Impl {
@@ -73,6 +73,21 @@ go run $(dirname $0)/../cmd/schema/ dump ClusterImagePolicy \
   | yq eval-all --inplace 'select(fileIndex == 0).spec.versions[1].schema.openAPIV3Schema = select(fileIndex == 1) | select(fileIndex == 0)' \
   $(dirname $0)/../config/300-clusterimagepolicy.yaml -
 
+# Create file for ImagePolicy as well
+go run $(dirname $0)/../cmd/schema/ dump ImagePolicy \
+  | yq eval-all --inplace 'select(fileIndex == 0).spec.versions[0].schema.openAPIV3Schema = select(fileIndex == 1) | select(fileIndex == 0)' \
+  $(dirname $0)/../config/300-imagepolicy.yaml -
+
+# Create file for PolicyException as well
+go run $(dirname $0)/../cmd/schema/ dump PolicyException \
+  | yq eval-all --inplace 'select(fileIndex == 0).spec.versions[0].schema.openAPIV3Schema = select(fileIndex == 1) | select(fileIndex == 0)' \
+  $(dirname $0)/../config/300-policyexception.yaml -
+
+# Create file for Revocation as well
+go run $(dirname $0)/../cmd/schema/ dump Revocation \
+  | yq eval-all --inplace 'select(fileIndex == 0).spec.versions[0].schema.openAPIV3Schema = select(fileIndex == 1) | select(fileIndex == 0)' \
+  $(dirname $0)/../config/300-revocation.yaml -
+
 # Create file for TrustRoot as well
 go run $(dirname $0)/../cmd/schema/ dump TrustRoot \
   | yq eval-all --inplace 'select(fileIndex == 0).spec.versions[0].schema.openAPIV3Schema = select(fileIndex == 1) | select(fileIndex == 0)' \
@@ -19,10 +19,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 
 	"github.com/sigstore/policy-controller/pkg/apis/glob"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
 	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	metalabels "k8s.io/apimachinery/pkg/labels"
@@ -81,9 +85,101 @@ func parseEntry(entry string, out interface{}) error {
 
 // GetMatchingPolicies returns all matching Policies and their Authorities that
 // need to be matched for the given kind, version and labels (if provided) to then match the Image.
+// namespaceLabels are the labels of the namespace of the resource, which are
+// matched against the NamespaceSelector of the Policies, and userInfo is who
+// is making the request, which is matched against their Subjects.
 // Returned map contains the name of the CIP as the key, and a normalized
-// ClusterImagePolicy for it.
-func (p *ImagePolicyConfig) GetMatchingPolicies(image string, kind, apiVersion string, labels map[string]string) (map[string]webhookcip.ClusterImagePolicy, error) {
+// ClusterImagePolicy for it. Policies compiled from namespaced ImagePolicies
+// are not returned, see GetMatchingNamespacedPolicies.
+func (p *ImagePolicyConfig) GetMatchingPolicies(image string, kind, apiVersion string, labels, namespaceLabels map[string]string, userInfo *authenticationv1.UserInfo) (map[string]webhookcip.ClusterImagePolicy, error) {
+	return p.getMatchingPolicies("", image, kind, apiVersion, labels, namespaceLabels, func(subjects []v1alpha1.Subject) bool {
+		return matchesSubjects(subjects, userInfo)
+	})
+}
+
+// GetMatchingNamespacedPolicies is like GetMatchingPolicies but only returns
+// the Policies compiled from ImagePolicies in the given namespace.
+// Returned map contains the ConfigMap key of the ImagePolicy
+// (<namespace>_<name>) as the key.
+func (p *ImagePolicyConfig) GetMatchingNamespacedPolicies(namespace, image string, kind, apiVersion string, labels, namespaceLabels map[string]string, userInfo *authenticationv1.UserInfo) (map[string]webhookcip.ClusterImagePolicy, error) {
+	if namespace == "" {
+		return map[string]webhookcip.ClusterImagePolicy{}, nil
+	}
+	return p.getMatchingPolicies(namespace, image, kind, apiVersion, labels, namespaceLabels, func(subjects []v1alpha1.Subject) bool {
+		return matchesSubjects(subjects, userInfo)
+	})
+}
+
+// GetSubjectPolicies returns the names of the Policies, both the cluster
+// wide ones and the ones compiled from ImagePolicies in the given namespace,
+// that would match the image if it was not for their Subjects. When who is
+// making the request is not known, for example when auditing running Pods,
+// these are never matched.
+func (p *ImagePolicyConfig) GetSubjectPolicies(namespace, image string, kind, apiVersion string, labels, namespaceLabels map[string]string) ([]string, error) {
+	anySubject := func([]v1alpha1.Subject) bool { return true }
+	noSubject := func([]v1alpha1.Subject) bool { return false }
+	namespaces := []string{""}
+	if namespace != "" {
+		namespaces = append(namespaces, namespace)
+	}
+	var ret []string
+	var lastError error
+	for _, ns := range namespaces {
+		withSubjects, err := p.getMatchingPolicies(ns, image, kind, apiVersion, labels, namespaceLabels, anySubject)
+		if err != nil {
+			lastError = err
+		}
+		withoutSubjects, err := p.getMatchingPolicies(ns, image, kind, apiVersion, labels, namespaceLabels, noSubject)
+		if err != nil {
+			lastError = err
+		}
+		for k := range withSubjects {
+			if _, ok := withoutSubjects[k]; !ok {
+				ret = append(ret, k)
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret, lastError
+}
+
+// HasNamespaceSelector returns true if any of the Policies that could match
+// the image, the cluster wide ones and the ones compiled from ImagePolicies in
+// the given namespace, has match criteria with a NamespaceSelector. Only then
+// are the labels of the namespace needed to get the matching Policies.
+func (p *ImagePolicyConfig) HasNamespaceSelector(namespace, image string) bool {
+	if p == nil {
+		return false
+	}
+	for _, v := range p.Policies {
+		if v.Namespace != "" && v.Namespace != namespace {
+			continue
+		}
+		hasSelector := false
+		for _, matchResource := range v.Match {
+			if matchResource.NamespaceSelector != nil {
+				hasSelector = true
+				break
+			}
+		}
+		if !hasSelector {
+			continue
+		}
+		for _, pattern := range v.Images {
+			// Images that fail to match are reported when getting the
+			// matching Policies, so just assume the labels are needed.
+			if matched, err := matchImagePattern(pattern, image); err != nil || matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getMatchingPolicies returns the Policies in the namespace (or the cluster
+// wide ones if empty) that match. matchSubjects decides whether the Subjects
+// of a match criteria are satisfied.
+func (p *ImagePolicyConfig) getMatchingPolicies(namespace, image string, kind, apiVersion string, labels, namespaceLabels map[string]string, matchSubjects func([]v1alpha1.Subject) bool) (map[string]webhookcip.ClusterImagePolicy, error) {
 	if p == nil {
 		return nil, errors.New("config is nil")
 	}
@@ -102,19 +198,13 @@ func (p *ImagePolicyConfig) GetMatchingPolicies(image string, kind, apiVersion s
 	// way to go from image to Authorities, but just seeing if this is even
 	// workable so fine for now.
 	for k, v := range p.Policies {
+		if v.Namespace != namespace {
+			continue
+		}
 		if len(v.Match) > 0 {
 			foundMatch := false
 			for _, matchResource := range v.Match {
-				if matchResource.Resource != gvr.Resource {
-					// Resource doesn't match.
-					continue
-				}
-				if matchResource.Version != gvr.Version && matchResource.Version != "*" {
-					// Version doesn't match exactly or wildcard.
-					continue
-				}
-				if matchResource.Group != gvr.Group {
-					// Group doesn't match.
+				if !matchesResourceType(matchResource, gvr) {
 					continue
 				}
 
@@ -127,6 +217,18 @@ func (p *ImagePolicyConfig) GetMatchingPolicies(image string, kind, apiVersion s
 						continue
 					}
 				}
+				if matchResource.NamespaceSelector != nil {
+					selector, err := metav1.LabelSelectorAsSelector(matchResource.NamespaceSelector)
+					if err != nil {
+						return nil, errors.New("policy with wrong match namespace selector")
+					}
+					if !selector.Matches(metalabels.Set(namespaceLabels)) {
+						continue
+					}
+				}
+				if len(matchResource.Subjects) > 0 && !matchSubjects(matchResource.Subjects) {
+					continue
+				}
 				// We found a set of match criteria that this resource satisfies
 				foundMatch = true
 				break
@@ -138,14 +240,83 @@ func (p *ImagePolicyConfig) GetMatchingPolicies(image string, kind, apiVersion s
 		}
 
 		for _, pattern := range v.Images {
-			if pattern.Glob != "" {
-				if matched, err := glob.Match(pattern.Glob, image); err != nil {
-					lastError = err
-				} else if matched {
-					ret[k] = v
-				}
+			if matched, err := matchImagePattern(pattern, image); err != nil {
+				lastError = err
+			} else if matched {
+				ret[k] = v
 			}
 		}
 	}
 	return ret, lastError
 }
+
+// matchesResourceType returns true if the group, version and resource of the
+// matchResource match gvr. A NamespaceSelector or Subjects without any group,
+// version and resource apply to all resource types.
+func matchesResourceType(matchResource v1alpha1.MatchResource, gvr schema.GroupVersionResource) bool {
+	if (matchResource.NamespaceSelector != nil || len(matchResource.Subjects) > 0) && matchResource.GroupVersionResource == (metav1.GroupVersionResource{}) {
+		return true
+	}
+	if matchResource.Resource != gvr.Resource {
+		// Resource doesn't match.
+		return false
+	}
+	if matchResource.Version != gvr.Version && matchResource.Version != "*" {
+		// Version doesn't match exactly or wildcard.
+		return false
+	}
+	// Group has to match.
+	return matchResource.Group == gvr.Group
+}
+
+// matchesSubjects returns true if userInfo is any of the subjects.
+func matchesSubjects(subjects []v1alpha1.Subject, userInfo *authenticationv1.UserInfo) bool {
+	if userInfo == nil {
+		return false
+	}
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case rbacv1.UserKind:
+			if userInfo.Username == subject.Name {
+				return true
+			}
+		case rbacv1.GroupKind:
+			for _, group := range userInfo.Groups {
+				if group == subject.Name {
+					return true
+				}
+			}
+		case rbacv1.ServiceAccountKind:
+			if userInfo.Username == fmt.Sprintf("system:serviceaccount:%s:%s", subject.Namespace, subject.Name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchImagePattern returns true if the image matches the Glob or Regex of the
+// pattern, and none of its ExcludeGlob.
+func matchImagePattern(pattern v1alpha1.ImagePattern, image string) (bool, error) {
+	var matched bool
+	var err error
+	switch {
+	case pattern.Glob != "":
+		matched, err = glob.Match(pattern.Glob, image)
+	case pattern.Regex != "":
+		matched, err = glob.MatchRegex(pattern.Regex, image)
+	}
+	if err != nil || !matched {
+		return false, err
+	}
+	for _, exclude := range pattern.ExcludeGlob {
+		excluded, err := glob.Match(exclude, image)
+		if err != nil {
+			return false, err
+		}
+		if excluded {
+			return false, nil
+		}
+	}
+	return true, nil
+}
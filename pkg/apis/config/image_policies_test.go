@@ -22,7 +22,11 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
 	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	. "knative.dev/pkg/configmap/testing"
 	_ "knative.dev/pkg/system/testing"
 )
@@ -78,7 +82,7 @@ func TestGetAuthorities(t *testing.T) {
 			{name: "multiline json cert", image: "ghcr.io/example/foo", matchedPolicy: "cluster-image-policy-json", checkPubKey: true},
 		} {
 			t.Run(tc.name, func(t *testing.T) {
-				c, err := defaults.GetMatchingPolicies(tc.image, "Pod", "v1", map[string]string{})
+				c, err := defaults.GetMatchingPolicies(tc.image, "Pod", "v1", map[string]string{}, nil, nil)
 				checkGetMatches(t, c, err)
 				if got := getAuthority(t, c, tc.matchedPolicy).Key.Data; got != inlineKeyData {
 					t.Errorf("Did not get what I wanted %q, got %+v", inlineKeyData, got)
@@ -96,7 +100,7 @@ func TestGetAuthorities(t *testing.T) {
 
 	t.Run("keyless authority", func(t *testing.T) {
 		matchedPolicy := "cluster-image-policy-2"
-		c, err := defaults.GetMatchingPolicies("rando3", "Pod", "v1", map[string]string{})
+		c, err := defaults.GetMatchingPolicies("rando3", "Pod", "v1", map[string]string{}, nil, nil)
 		checkGetMatches(t, c, err)
 		authority := getAuthority(t, c, matchedPolicy)
 		if authority.Keyless == nil {
@@ -127,7 +131,7 @@ func TestGetAuthorities(t *testing.T) {
 	})
 
 	t.Run("multiple matches", func(t *testing.T) {
-		c, err := defaults.GetMatchingPolicies("regexstringtoo", "Pod", "v1", map[string]string{})
+		c, err := defaults.GetMatchingPolicies("regexstringtoo", "Pod", "v1", map[string]string{}, nil, nil)
 		checkGetMatches(t, c, err)
 		if len(c) != 2 {
 			t.Errorf("Wanted two matches, got %d", len(c))
@@ -142,7 +146,7 @@ func TestGetAuthorities(t *testing.T) {
 
 	t.Run("attestations and top level policy", func(t *testing.T) {
 		matchedPolicy := "cluster-image-policy-with-policy-attestations"
-		c, err := defaults.GetMatchingPolicies("withattestations", "Pod", "v1", map[string]string{})
+		c, err := defaults.GetMatchingPolicies("withattestations", "Pod", "v1", map[string]string{}, nil, nil)
 		checkGetMatches(t, c, err)
 		if len(c) != 1 {
 			t.Errorf("Wanted 1 match, got %d", len(c))
@@ -171,7 +175,7 @@ func TestGetAuthorities(t *testing.T) {
 
 	t.Run("source oci", func(t *testing.T) {
 		matchedPolicy := "cluster-image-policy-source-oci"
-		c, err := defaults.GetMatchingPolicies("sourceocionly", "Pod", "v1", map[string]string{})
+		c, err := defaults.GetMatchingPolicies("sourceocionly", "Pod", "v1", map[string]string{}, nil, nil)
 		checkGetMatches(t, c, err)
 		if len(c) != 1 {
 			t.Errorf("Wanted 1 match, got %d", len(c))
@@ -188,7 +192,7 @@ func TestGetAuthorities(t *testing.T) {
 
 	t.Run("source signature pull secrets", func(t *testing.T) {
 		matchedPolicy := "cluster-image-policy-source-oci-signature-pull-secrets"
-		c, err := defaults.GetMatchingPolicies("sourceocisignaturepullsecrets", "Pod", "v1", map[string]string{"match": "match"})
+		c, err := defaults.GetMatchingPolicies("sourceocisignaturepullsecrets", "Pod", "v1", map[string]string{"match": "match"}, nil, nil)
 		checkGetMatches(t, c, err)
 		if len(c) != 1 {
 			t.Errorf("Wanted 1 match, got %d", len(c))
@@ -219,7 +223,7 @@ func TestGetAuthorities(t *testing.T) {
 			{name: "unknown apiVersion", apiVersion: "blah/v1alpha1", wantMatches: 0},
 		} {
 			t.Run(tc.name, func(t *testing.T) {
-				c, err := defaults.GetMatchingPolicies("match-pods", "Pod", tc.apiVersion, map[string]string{"match": "match"})
+				c, err := defaults.GetMatchingPolicies("match-pods", "Pod", tc.apiVersion, map[string]string{"match": "match"}, nil, nil)
 				if tc.wantMatches > 0 {
 					checkGetMatches(t, c, err)
 				} else if err != nil {
@@ -233,6 +237,247 @@ func TestGetAuthorities(t *testing.T) {
 	})
 }
 
+func TestGetNamespacedPolicies(t *testing.T) {
+	_, example := ConfigMapsFromTestFile(t, ImagePoliciesConfigName)
+	defaults, err := NewImagePoliciesConfigFromConfigMap(example)
+	if err != nil {
+		t.Error("NewImagePoliciesConfigFromConfigMap(example) =", err)
+	}
+
+	// Namespaced policies must never be returned for cluster wide matching.
+	c, err := defaults.GetMatchingPolicies("namespaced", "Pod", "v1", map[string]string{}, nil, nil)
+	if err != nil {
+		t.Fatalf("GetMatchingPolicies() = %v", err)
+	}
+	if len(c) != 0 {
+		t.Errorf("Wanted 0 matches, got %d", len(c))
+	}
+
+	for _, tc := range []struct {
+		name        string
+		namespace   string
+		wantMatches int
+	}{
+		{name: "same namespace", namespace: "tenant", wantMatches: 1},
+		{name: "other namespace", namespace: "other", wantMatches: 0},
+		{name: "no namespace", namespace: "", wantMatches: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := defaults.GetMatchingNamespacedPolicies(tc.namespace, "namespaced", "Pod", "v1", map[string]string{}, nil, nil)
+			if err != nil {
+				t.Fatalf("GetMatchingNamespacedPolicies() = %v", err)
+			}
+			if got := len(c); got != tc.wantMatches {
+				t.Errorf("Wanted %d matches, got %d", tc.wantMatches, got)
+			}
+		})
+	}
+}
+
+func TestGetMatchingPoliciesImagePatterns(t *testing.T) {
+	c := &ImagePolicyConfig{Policies: map[string]webhookcip.ClusterImagePolicy{
+		"glob-with-exclusions": {Images: []v1alpha1.ImagePattern{{
+			Glob:        "registry.example.com/**",
+			ExcludeGlob: []string{"registry.example.com/sandbox/**", "registry.example.com/*/scratch:**"},
+		}}},
+		"regex": {Images: []v1alpha1.ImagePattern{{
+			Regex: `^ghcr\.io/example/[^/]+:v[0-9]+(\.[0-9]+)*$`,
+		}}},
+	}}
+	for _, tc := range []struct {
+		image string
+		want  []string
+	}{
+		{image: "registry.example.com/team/app:v1", want: []string{"glob-with-exclusions"}},
+		{image: "registry.example.com/sandbox/app:v1"},
+		{image: "registry.example.com/team/scratch:latest"},
+		{image: "ghcr.io/example/app:v1.2", want: []string{"regex"}},
+		{image: "ghcr.io/example/app:latest"},
+	} {
+		t.Run(tc.image, func(t *testing.T) {
+			got, err := c.GetMatchingPolicies(tc.image, "Pod", "v1", map[string]string{}, nil, nil)
+			if err != nil {
+				t.Fatalf("GetMatchingPolicies() = %v", err)
+			}
+			if len(got) != len(tc.want) {
+				t.Fatalf("GetMatchingPolicies() = %v, wanted %v", got, tc.want)
+			}
+			for _, name := range tc.want {
+				if _, ok := got[name]; !ok {
+					t.Errorf("GetMatchingPolicies() = %v, wanted %v", got, tc.want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetMatchingPoliciesNamespaceSelector(t *testing.T) {
+	prod := &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+	c := &ImagePolicyConfig{Policies: map[string]webhookcip.ClusterImagePolicy{
+		"prod": {
+			Images: []v1alpha1.ImagePattern{{Glob: "**"}},
+			Match:  []v1alpha1.MatchResource{{NamespaceSelector: prod}},
+		},
+		"prod-deployments": {
+			Images: []v1alpha1.ImagePattern{{Glob: "**"}},
+			Match: []v1alpha1.MatchResource{{
+				GroupVersionResource: metav1.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+				NamespaceSelector:    prod,
+			}},
+		},
+	}}
+	for _, tc := range []struct {
+		name            string
+		kind            string
+		apiVersion      string
+		namespaceLabels map[string]string
+		want            int
+	}{
+		{name: "prod pod", kind: "Pod", apiVersion: "v1", namespaceLabels: map[string]string{"env": "prod"}, want: 1},
+		{name: "prod deployment", kind: "Deployment", apiVersion: "apps/v1", namespaceLabels: map[string]string{"env": "prod", "team": "a"}, want: 2},
+		{name: "dev deployment", kind: "Deployment", apiVersion: "apps/v1", namespaceLabels: map[string]string{"env": "dev"}, want: 0},
+		{name: "unknown namespace", kind: "Pod", apiVersion: "v1", want: 0},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := c.GetMatchingPolicies("ghcr.io/example/app", tc.kind, tc.apiVersion, map[string]string{}, tc.namespaceLabels, nil)
+			if err != nil {
+				t.Fatalf("GetMatchingPolicies() = %v", err)
+			}
+			if len(got) != tc.want {
+				t.Errorf("GetMatchingPolicies() = %v, wanted %d matches", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHasNamespaceSelector(t *testing.T) {
+	prod := &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}}
+	c := &ImagePolicyConfig{Policies: map[string]webhookcip.ClusterImagePolicy{
+		"prod-registry": {
+			Images: []v1alpha1.ImagePattern{{Glob: "registry.example.com/**"}},
+			Match:  []v1alpha1.MatchResource{{NamespaceSelector: prod}},
+		},
+		"tenant_prod": {
+			Namespace: "tenant",
+			Images:    []v1alpha1.ImagePattern{{Glob: "**"}},
+			Match:     []v1alpha1.MatchResource{{NamespaceSelector: prod}},
+		},
+		"everyone": {
+			Images: []v1alpha1.ImagePattern{{Glob: "**"}},
+		},
+	}}
+	for _, tc := range []struct {
+		name      string
+		namespace string
+		image     string
+		want      bool
+	}{
+		{name: "matching image", namespace: "default", image: "registry.example.com/app", want: true},
+		{name: "other image", namespace: "default", image: "ghcr.io/example/app"},
+		{name: "other image in tenant", namespace: "tenant", image: "ghcr.io/example/app", want: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := c.HasNamespaceSelector(tc.namespace, tc.image); got != tc.want {
+				t.Errorf("HasNamespaceSelector() = %t, wanted %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetMatchingPoliciesSubjects(t *testing.T) {
+	c := &ImagePolicyConfig{Policies: map[string]webhookcip.ClusterImagePolicy{
+		"humans": {
+			Images: []v1alpha1.ImagePattern{{Glob: "**"}},
+			Match: []v1alpha1.MatchResource{{Subjects: []v1alpha1.Subject{
+				{Kind: "User", Name: "alice@example.com"},
+				{Kind: "Group", Name: "developers"},
+			}}},
+		},
+		"ci": {
+			Images: []v1alpha1.ImagePattern{{Glob: "**"}},
+			Match: []v1alpha1.MatchResource{{Subjects: []v1alpha1.Subject{
+				{Kind: "ServiceAccount", Namespace: "ci", Name: "deployer"},
+			}}},
+		},
+	}}
+	for _, tc := range []struct {
+		name     string
+		userInfo *authenticationv1.UserInfo
+		want     string
+	}{
+		{name: "user", userInfo: &authenticationv1.UserInfo{Username: "alice@example.com"}, want: "humans"},
+		{name: "group", userInfo: &authenticationv1.UserInfo{Username: "bob@example.com", Groups: []string{"system:authenticated", "developers"}}, want: "humans"},
+		{name: "service account", userInfo: &authenticationv1.UserInfo{Username: "system:serviceaccount:ci:deployer"}, want: "ci"},
+		{name: "other service account", userInfo: &authenticationv1.UserInfo{Username: "system:serviceaccount:default:deployer"}},
+		{name: "no user info"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := c.GetMatchingPolicies("ghcr.io/example/app", "Pod", "v1", map[string]string{}, nil, tc.userInfo)
+			if err != nil {
+				t.Fatalf("GetMatchingPolicies() = %v", err)
+			}
+			if tc.want == "" {
+				if len(got) != 0 {
+					t.Errorf("GetMatchingPolicies() = %v, wanted no matches", got)
+				}
+				return
+			}
+			if _, ok := got[tc.want]; !ok || len(got) != 1 {
+				t.Errorf("GetMatchingPolicies() = %v, wanted %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetSubjectPolicies(t *testing.T) {
+	ci := []v1alpha1.Subject{{Kind: "ServiceAccount", Namespace: "ci", Name: "deployer"}}
+	c := &ImagePolicyConfig{Policies: map[string]webhookcip.ClusterImagePolicy{
+		"ci": {
+			Images: []v1alpha1.ImagePattern{{Glob: "**"}},
+			Match:  []v1alpha1.MatchResource{{Subjects: ci}},
+		},
+		"ci-or-deployments": {
+			Images: []v1alpha1.ImagePattern{{Glob: "**"}},
+			Match: []v1alpha1.MatchResource{{Subjects: ci}, {
+				GroupVersionResource: metav1.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+			}},
+		},
+		"ci-other-images": {
+			Images: []v1alpha1.ImagePattern{{Glob: "registry.example.com/**"}},
+			Match:  []v1alpha1.MatchResource{{Subjects: ci}},
+		},
+		"tenant_ci": {
+			Namespace: "tenant",
+			Images:    []v1alpha1.ImagePattern{{Glob: "**"}},
+			Match:     []v1alpha1.MatchResource{{Subjects: ci}},
+		},
+		"everyone": {
+			Images: []v1alpha1.ImagePattern{{Glob: "**"}},
+		},
+	}}
+	for _, tc := range []struct {
+		name       string
+		namespace  string
+		kind       string
+		apiVersion string
+		want       []string
+	}{
+		{name: "pod", kind: "Pod", apiVersion: "v1", want: []string{"ci", "ci-or-deployments"}},
+		{name: "deployment", kind: "Deployment", apiVersion: "apps/v1", want: []string{"ci"}},
+		{name: "pod in tenant", namespace: "tenant", kind: "Pod", apiVersion: "v1", want: []string{"ci", "ci-or-deployments", "tenant_ci"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := c.GetSubjectPolicies(tc.namespace, "ghcr.io/example/app", tc.kind, tc.apiVersion, map[string]string{}, nil)
+			if err != nil {
+				t.Fatalf("GetSubjectPolicies() = %v", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("GetSubjectPolicies() (-want, +got): %s", diff)
+			}
+		})
+	}
+}
+
 func TestFailsToLoadInvalid(t *testing.T) {
 	wantErr := "failed to parse the entry \"cluster-image-policy-0\""
 	_, example := ConfigMapsFromTestFile(t, "config-invalid-image-policy")
@@ -0,0 +1,170 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// PolicyExceptionsConfigName is the name of ConfigMap created by the
+	// reconciler from the PolicyExceptions, and consumed by the admission
+	// webhook.
+	PolicyExceptionsConfigName = "config-policy-exceptions"
+)
+
+// PolicyException is the compiled form of a v1alpha1.PolicyException.
+type PolicyException struct {
+	// UID and ResourceVersion of the PolicyException this was compiled from.
+	UID             types.UID `json:"uid"`
+	ResourceVersion string    `json:"resourceVersion"`
+
+	Images        []v1alpha1.ImagePattern `json:"images"`
+	Namespaces    []string                `json:"namespaces,omitempty"`
+	Policies      []string                `json:"policies"`
+	Expires       metav1.Time             `json:"expires"`
+	Justification string                  `json:"justification"`
+}
+
+// ConvertPolicyException converts the PolicyException into the form stored
+// in the ConfigMap.
+func ConvertPolicyException(in *v1alpha1.PolicyException) *PolicyException {
+	copyIn := in.DeepCopy()
+	return &PolicyException{
+		UID:             copyIn.UID,
+		ResourceVersion: copyIn.ResourceVersion,
+		Images:          copyIn.Spec.Images,
+		Namespaces:      copyIn.Spec.Namespaces,
+		Policies:        copyIn.Spec.Policies,
+		Expires:         copyIn.Spec.Expires,
+		Justification:   copyIn.Spec.Justification,
+	}
+}
+
+// Expired returns true if the PolicyException is no longer in effect at the
+// given time.
+func (e *PolicyException) Expired(now time.Time) bool {
+	return !now.Before(e.Expires.Time)
+}
+
+// Exempts returns true if the PolicyException exempts the image in the
+// namespace from the given policy. This does not take expiry into account.
+func (e *PolicyException) Exempts(namespace, image, policy string) (bool, error) {
+	if !contains(e.Policies, policy) {
+		return false, nil
+	}
+	if len(e.Namespaces) > 0 && !contains(e.Namespaces, namespace) {
+		return false, nil
+	}
+	var lastError error
+	for _, pattern := range e.Images {
+		matched, err := matchImagePattern(pattern, image)
+		if err != nil {
+			lastError = err
+			continue
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, lastError
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+type PolicyExceptionsConfig struct {
+	// Exceptions holds the compiled PolicyExceptions, keyed by name.
+	Exceptions map[string]PolicyException
+}
+
+// NewPolicyExceptionsConfigFromMap creates a PolicyExceptionsConfig from the
+// supplied Map
+func NewPolicyExceptionsConfigFromMap(data map[string]string) (*PolicyExceptionsConfig, error) {
+	ret := &PolicyExceptionsConfig{Exceptions: make(map[string]PolicyException, len(data))}
+	for k, v := range data {
+		// This is the example that we use to document / test the ConfigMap.
+		if k == "_example" {
+			continue
+		}
+		if v == "" {
+			return nil, fmt.Errorf("configmap has an entry %q but no value", k)
+		}
+		exception := &PolicyException{}
+		if err := parseEntry(v, exception); err != nil {
+			return nil, fmt.Errorf("failed to parse the entry %q : %q : %w", k, v, err)
+		}
+		ret.Exceptions[k] = *exception
+	}
+	return ret, nil
+}
+
+// NewPolicyExceptionsConfigFromConfigMap creates a PolicyExceptionsConfig
+// from the supplied ConfigMap
+func NewPolicyExceptionsConfigFromConfigMap(config *corev1.ConfigMap) (*PolicyExceptionsConfig, error) {
+	return NewPolicyExceptionsConfigFromMap(config.Data)
+}
+
+// GetExemptions returns which of the given policies the image is exempted
+// from in the namespace at the given time. Returned map contains the name of
+// the policy as the key, and the name of the PolicyException that exempts
+// it as the value. Expired PolicyExceptions are ignored.
+func (c *PolicyExceptionsConfig) GetExemptions(namespace, image string, policies []string, now time.Time) (map[string]string, error) {
+	if c == nil {
+		return nil, errors.New("config is nil")
+	}
+	// Go through the exceptions in order, so that if multiple exceptions
+	// exempt the same policy the result is stable.
+	names := make([]string, 0, len(c.Exceptions))
+	for name := range c.Exceptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var lastError error
+	ret := make(map[string]string)
+	for _, policy := range policies {
+		for _, name := range names {
+			exception := c.Exceptions[name]
+			if exception.Expired(now) {
+				continue
+			}
+			exempts, err := exception.Exempts(namespace, image, policy)
+			if err != nil {
+				lastError = err
+			}
+			if exempts {
+				ret[policy] = name
+				break
+			}
+		}
+	}
+	return ret, lastError
+}
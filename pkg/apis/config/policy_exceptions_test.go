@@ -0,0 +1,133 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+
+	. "knative.dev/pkg/configmap/testing"
+)
+
+func TestGetExemptions(t *testing.T) {
+	_, example := ConfigMapsFromTestFile(t, PolicyExceptionsConfigName)
+	c, err := NewPolicyExceptionsConfigFromConfigMap(example)
+	if err != nil {
+		t.Fatalf("NewPolicyExceptionsConfigFromConfigMap() = %v", err)
+	}
+	if len(c.Exceptions) != 2 {
+		t.Fatalf("Wanted 2 exceptions, got %d", len(c.Exceptions))
+	}
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		namespace string
+		image     string
+		policies  []string
+		now       time.Time
+		want      map[string]string
+	}{{
+		name:      "exempted",
+		namespace: "vendor",
+		image:     "registry.example.com/vendor/app@sha256:cc40b04fb5ab7a9be3bf1e5ae7ae3a0c23ec7d5e2ff2b1d9f0e2d0f1d8a6b2c3",
+		policies:  []string{"signed-images", "other-policy"},
+		now:       now,
+		want:      map[string]string{"signed-images": "vendor-exception"},
+	}, {
+		name:      "other namespace",
+		namespace: "default",
+		image:     "registry.example.com/vendor/app@sha256:cc40b04fb5ab7a9be3bf1e5ae7ae3a0c23ec7d5e2ff2b1d9f0e2d0f1d8a6b2c3",
+		policies:  []string{"signed-images"},
+		now:       now,
+		want:      map[string]string{},
+	}, {
+		name:      "other image",
+		namespace: "vendor",
+		image:     "registry.example.com/other/app@sha256:cc40b04fb5ab7a9be3bf1e5ae7ae3a0c23ec7d5e2ff2b1d9f0e2d0f1d8a6b2c3",
+		policies:  []string{"signed-images"},
+		now:       now,
+		want:      map[string]string{},
+	}, {
+		name:      "expired",
+		namespace: "vendor",
+		image:     "registry.example.com/vendor/app@sha256:cc40b04fb5ab7a9be3bf1e5ae7ae3a0c23ec7d5e2ff2b1d9f0e2d0f1d8a6b2c3",
+		policies:  []string{"signed-images"},
+		now:       time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		want:      map[string]string{},
+	}, {
+		name:      "wildcard before expiry",
+		namespace: "default",
+		image:     "registry.example.com/other/app@sha256:cc40b04fb5ab7a9be3bf1e5ae7ae3a0c23ec7d5e2ff2b1d9f0e2d0f1d8a6b2c3",
+		policies:  []string{"signed-images"},
+		now:       time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+		want:      map[string]string{"signed-images": "expired-exception"},
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := c.GetExemptions(test.namespace, test.image, test.policies, test.now)
+			if err != nil {
+				t.Fatalf("GetExemptions() = %v", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("GetExemptions() -want,+got: %s", diff)
+			}
+		})
+	}
+}
+
+func TestExemptsRegex(t *testing.T) {
+	const image = "registry.example.com/vendor/app@sha256:cc40b04fb5ab7a9be3bf1e5ae7ae3a0c23ec7d5e2ff2b1d9f0e2d0f1d8a6b2c3"
+	tests := []struct {
+		name    string
+		regex   string
+		image   string
+		want    bool
+		wantErr bool
+	}{{
+		name:  "matching",
+		regex: `registry\.example\.com/vendor/.*`,
+		image: image,
+		want:  true,
+	}, {
+		name:  "other registry",
+		regex: `registry\.example\.com/vendor/.*`,
+		image: "attacker.example.com/registry.example.com/vendor/app@sha256:cc40b04fb5ab7a9be3bf1e5ae7ae3a0c23ec7d5e2ff2b1d9f0e2d0f1d8a6b2c3",
+	}, {
+		name:    "invalid",
+		regex:   `registry\.example\.com/vendor/(`,
+		image:   image,
+		wantErr: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := &PolicyException{
+				Images:   []v1alpha1.ImagePattern{{Regex: test.regex}},
+				Policies: []string{"signed-images"},
+			}
+			got, err := e.Exempts("vendor", test.image, "signed-images")
+			if (err != nil) != test.wantErr {
+				t.Errorf("Exempts() error = %v, wanted error %t", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("Exempts() = %t, wanted %t", got, test.want)
+			}
+		})
+	}
+}
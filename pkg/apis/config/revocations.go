@@ -0,0 +1,182 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/common"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// RevocationsConfigName is the name of ConfigMap created by the
+	// reconciler from the Revocations, and consumed by the admission
+	// webhook.
+	RevocationsConfigName = "config-revocations"
+)
+
+// Revocation is the compiled form of a v1alpha1.Revocation. The key
+// fingerprints and certificate serials are normalized, see
+// common.NormalizeKeyFingerprint and common.NormalizeCertificateSerial.
+type Revocation struct {
+	// UID and ResourceVersion of the Revocation this was compiled from.
+	UID             types.UID `json:"uid"`
+	ResourceVersion string    `json:"resourceVersion"`
+
+	KeyFingerprints    []string                   `json:"keyFingerprints,omitempty"`
+	Identities         []v1alpha1.RevokedIdentity `json:"identities,omitempty"`
+	CertificateSerials []string                   `json:"certificateSerials,omitempty"`
+	Digests            []string                   `json:"digests,omitempty"`
+	CompromisedAt      *metav1.Time               `json:"compromisedAt,omitempty"`
+	Reason             string                     `json:"reason,omitempty"`
+}
+
+// ConvertRevocation converts the Revocation into the form stored in the
+// ConfigMap.
+func ConvertRevocation(in *v1alpha1.Revocation) *Revocation {
+	copyIn := in.DeepCopy()
+	ret := &Revocation{
+		UID:             copyIn.UID,
+		ResourceVersion: copyIn.ResourceVersion,
+		Identities:      copyIn.Spec.Identities,
+		Digests:         copyIn.Spec.Digests,
+		CompromisedAt:   copyIn.Spec.CompromisedAt,
+		Reason:          copyIn.Spec.Reason,
+	}
+	// These have been validated by the webhook, so they can be normalized.
+	for _, fingerprint := range copyIn.Spec.KeyFingerprints {
+		if normalized, err := common.NormalizeKeyFingerprint(fingerprint); err == nil {
+			ret.KeyFingerprints = append(ret.KeyFingerprints, normalized)
+		}
+	}
+	for _, serial := range copyIn.Spec.CertificateSerials {
+		if normalized, err := common.NormalizeCertificateSerial(serial); err == nil {
+			ret.CertificateSerials = append(ret.CertificateSerials, normalized)
+		}
+	}
+	return ret
+}
+
+// RevocationCandidate describes a verified signature or attestation that is
+// checked against the Revocations. Fields that do not apply to the
+// signature, for example the identity of a signature made with a key, are
+// left empty.
+type RevocationCandidate struct {
+	// KeyFingerprint is the normalized fingerprint of the key the signature
+	// was verified with, or of the key in its certificate.
+	KeyFingerprint string
+	// Issuer and Subject are the Fulcio identity of the certificate.
+	Issuer  string
+	Subject string
+	// CertificateSerial is the normalized serial number of the certificate.
+	CertificateSerial string
+	// Digest is the digest of the signature or attestation.
+	Digest string
+	// SignedAt is when the signature was made, if known.
+	SignedAt *time.Time
+}
+
+// Revokes returns true if the Revocation revokes the candidate.
+func (r *Revocation) Revokes(candidate RevocationCandidate) bool {
+	if !r.matches(candidate) {
+		return false
+	}
+	// Signatures that are known to have been made before the compromise
+	// are still trusted.
+	if r.CompromisedAt != nil && candidate.SignedAt != nil && candidate.SignedAt.Before(r.CompromisedAt.Time) {
+		return false
+	}
+	return true
+}
+
+func (r *Revocation) matches(candidate RevocationCandidate) bool {
+	if candidate.KeyFingerprint != "" && contains(r.KeyFingerprints, candidate.KeyFingerprint) {
+		return true
+	}
+	if candidate.Issuer != "" || candidate.Subject != "" {
+		for _, identity := range r.Identities {
+			if identity.Issuer == candidate.Issuer && identity.Subject == candidate.Subject {
+				return true
+			}
+		}
+	}
+	if candidate.CertificateSerial != "" && contains(r.CertificateSerials, candidate.CertificateSerial) {
+		return true
+	}
+	if candidate.Digest != "" && contains(r.Digests, candidate.Digest) {
+		return true
+	}
+	return false
+}
+
+type RevocationsConfig struct {
+	// Revocations holds the compiled Revocations, keyed by name.
+	Revocations map[string]Revocation
+}
+
+// NewRevocationsConfigFromMap creates a RevocationsConfig from the supplied
+// Map
+func NewRevocationsConfigFromMap(data map[string]string) (*RevocationsConfig, error) {
+	ret := &RevocationsConfig{Revocations: make(map[string]Revocation, len(data))}
+	for k, v := range data {
+		// This is the example that we use to document / test the ConfigMap.
+		if k == "_example" {
+			continue
+		}
+		if v == "" {
+			return nil, fmt.Errorf("configmap has an entry %q but no value", k)
+		}
+		revocation := &Revocation{}
+		if err := parseEntry(v, revocation); err != nil {
+			return nil, fmt.Errorf("failed to parse the entry %q : %q : %w", k, v, err)
+		}
+		ret.Revocations[k] = *revocation
+	}
+	return ret, nil
+}
+
+// NewRevocationsConfigFromConfigMap creates a RevocationsConfig from the
+// supplied ConfigMap
+func NewRevocationsConfigFromConfigMap(config *corev1.ConfigMap) (*RevocationsConfig, error) {
+	return NewRevocationsConfigFromMap(config.Data)
+}
+
+// Revoked returns the name of the Revocation that revokes the candidate, and
+// whether there is one. If several Revocations revoke it, the first one by
+// name is returned.
+func (c *RevocationsConfig) Revoked(candidate RevocationCandidate) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	names := make([]string, 0, len(c.Revocations))
+	for name := range c.Revocations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		revocation := c.Revocations[name]
+		if revocation.Revokes(candidate) {
+			return name, true
+		}
+	}
+	return "", false
+}
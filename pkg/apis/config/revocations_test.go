@@ -0,0 +1,113 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "knative.dev/pkg/configmap/testing"
+)
+
+func TestConvertRevocation(t *testing.T) {
+	in := &v1alpha1.Revocation{
+		ObjectMeta: metav1.ObjectMeta{UID: "uid", ResourceVersion: "1"},
+		Spec: v1alpha1.RevocationSpec{
+			KeyFingerprints:    []string{"2C:F2:4D:BA:5F:B0:A3:0E:26:E8:3B:2A:C5:B9:E2:9E:1B:16:1E:5C:1F:A7:42:5E:73:04:33:62:93:8B:98:24"},
+			CertificateSerials: []string{"00:54:66:7A:4D:08:18:B8:B8"},
+			Reason:             "testing",
+		},
+	}
+	want := &Revocation{
+		UID:                "uid",
+		ResourceVersion:    "1",
+		KeyFingerprints:    []string{"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		CertificateSerials: []string{"54667a4d0818b8b8"},
+		Reason:             "testing",
+	}
+	if diff := cmp.Diff(want, ConvertRevocation(in)); diff != "" {
+		t.Error("Unexpected Revocation (-want, +got):", diff)
+	}
+}
+
+func TestRevoked(t *testing.T) {
+	_, example := ConfigMapsFromTestFile(t, RevocationsConfigName)
+	c, err := NewRevocationsConfigFromConfigMap(example)
+	if err != nil {
+		t.Fatalf("NewRevocationsConfigFromConfigMap() = %v", err)
+	}
+	if len(c.Revocations) != 2 {
+		t.Fatalf("Wanted 2 revocations, got %d", len(c.Revocations))
+	}
+	beforeCompromise := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	afterCompromise := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		candidate RevocationCandidate
+		want      string
+	}{{
+		name:      "key revoked",
+		candidate: RevocationCandidate{KeyFingerprint: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+		want:      "leaked-ci-key",
+	}, {
+		name:      "key signed after compromise",
+		candidate: RevocationCandidate{KeyFingerprint: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", SignedAt: &afterCompromise},
+		want:      "leaked-ci-key",
+	}, {
+		name:      "key signed before compromise",
+		candidate: RevocationCandidate{KeyFingerprint: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", SignedAt: &beforeCompromise},
+	}, {
+		name:      "other key",
+		candidate: RevocationCandidate{KeyFingerprint: "486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7"},
+	}, {
+		name: "identity revoked",
+		candidate: RevocationCandidate{
+			Issuer:   "https://token.actions.githubusercontent.com",
+			Subject:  "https://github.com/example/repo/.github/workflows/release.yaml@refs/heads/main",
+			SignedAt: &beforeCompromise,
+		},
+		want: "compromised-workflow",
+	}, {
+		name: "other subject",
+		candidate: RevocationCandidate{
+			Issuer:  "https://token.actions.githubusercontent.com",
+			Subject: "https://github.com/example/repo/.github/workflows/other.yaml@refs/heads/main",
+		},
+	}, {
+		name:      "certificate revoked",
+		candidate: RevocationCandidate{CertificateSerial: "54667a4d0818b8b8"},
+		want:      "compromised-workflow",
+	}, {
+		name:      "digest revoked",
+		candidate: RevocationCandidate{Digest: "sha256:a1e82f6a5f6dfc735165d3442e7cc5a615f72abac3db19452481f5f3c90fbfa8"},
+		want:      "compromised-workflow",
+	}, {
+		name: "nothing to match",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, revoked := c.Revoked(test.candidate)
+			if revoked != (test.want != "") || got != test.want {
+				t.Errorf("Revoked() = %q, %v, wanted %q", got, revoked, test.want)
+			}
+		})
+	}
+}
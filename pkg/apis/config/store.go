@@ -26,8 +26,10 @@ type cfgKey struct{}
 // Config holds the collection of configurations that we attach to contexts.
 // +k8s:deepcopy-gen=false
 type Config struct {
-	ImagePolicyConfig  *ImagePolicyConfig
-	SigstoreKeysConfig *SigstoreKeysMap
+	ImagePolicyConfig      *ImagePolicyConfig
+	SigstoreKeysConfig     *SigstoreKeysMap
+	PolicyExceptionsConfig *PolicyExceptionsConfig
+	RevocationsConfig      *RevocationsConfig
 }
 
 // FromContext extracts a Config from the provided context.
@@ -47,9 +49,13 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 	}
 	config, _ := NewImagePoliciesConfigFromMap(map[string]string{})
 	sigstoreKeysMap, _ := NewSigstoreKeysFromMap(map[string]string{})
+	policyExceptions, _ := NewPolicyExceptionsConfigFromMap(map[string]string{})
+	revocations, _ := NewRevocationsConfigFromMap(map[string]string{})
 	return &Config{
-		ImagePolicyConfig:  config,
-		SigstoreKeysConfig: sigstoreKeysMap,
+		ImagePolicyConfig:      config,
+		SigstoreKeysConfig:     sigstoreKeysMap,
+		PolicyExceptionsConfig: policyExceptions,
+		RevocationsConfig:      revocations,
 	}
 }
 
@@ -72,8 +78,10 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 			"image-policies",
 			logger,
 			configmap.Constructors{
-				ImagePoliciesConfigName: NewImagePoliciesConfigFromConfigMap,
-				SigstoreKeysConfigName:  NewSigstoreKeysFromConfigMap,
+				ImagePoliciesConfigName:    NewImagePoliciesConfigFromConfigMap,
+				SigstoreKeysConfigName:     NewSigstoreKeysFromConfigMap,
+				PolicyExceptionsConfigName: NewPolicyExceptionsConfigFromConfigMap,
+				RevocationsConfigName:      NewRevocationsConfigFromConfigMap,
 			},
 			onAfterStore...,
 		),
@@ -90,7 +98,9 @@ func (s *Store) ToContext(ctx context.Context) context.Context {
 // Load creates a Config from the current config state of the Store.
 func (s *Store) Load() *Config {
 	return &Config{
-		ImagePolicyConfig:  s.UntypedLoad(ImagePoliciesConfigName).(*ImagePolicyConfig),
-		SigstoreKeysConfig: s.UntypedLoad(SigstoreKeysConfigName).(*SigstoreKeysMap),
+		ImagePolicyConfig:      s.UntypedLoad(ImagePoliciesConfigName).(*ImagePolicyConfig),
+		SigstoreKeysConfig:     s.UntypedLoad(SigstoreKeysConfigName).(*SigstoreKeysMap),
+		PolicyExceptionsConfig: s.UntypedLoad(PolicyExceptionsConfigName).(*PolicyExceptionsConfig),
+		RevocationsConfig:      s.UntypedLoad(RevocationsConfigName).(*RevocationsConfig),
 	}
 }
@@ -40,9 +40,13 @@ func TestStoreLoadWithContext(t *testing.T) {
 
 	_, imagePolicies := ConfigMapsFromTestFile(t, ImagePoliciesConfigName)
 	_, sigstoreKeysMap := ConfigMapsFromTestFile(t, SigstoreKeysConfigName)
+	_, policyExceptions := ConfigMapsFromTestFile(t, PolicyExceptionsConfigName)
+	_, revocations := ConfigMapsFromTestFile(t, RevocationsConfigName)
 
 	store.OnConfigChanged(imagePolicies)
 	store.OnConfigChanged(sigstoreKeysMap)
+	store.OnConfigChanged(policyExceptions)
+	store.OnConfigChanged(revocations)
 
 	config := FromContextOrDefaults(store.ToContext(context.Background()))
 
@@ -58,6 +62,18 @@ func TestStoreLoadWithContext(t *testing.T) {
 			t.Error("Unexpected defaults config (-want, +got):", diff)
 		}
 	})
+	t.Run("policy-exceptions", func(t *testing.T) {
+		expected, _ := NewPolicyExceptionsConfigFromConfigMap(policyExceptions)
+		if diff := cmp.Diff(expected, config.PolicyExceptionsConfig, ignoreStuff...); diff != "" {
+			t.Error("Unexpected defaults config (-want, +got):", diff)
+		}
+	})
+	t.Run("revocations", func(t *testing.T) {
+		expected, _ := NewRevocationsConfigFromConfigMap(revocations)
+		if diff := cmp.Diff(expected, config.RevocationsConfig, ignoreStuff...); diff != "" {
+			t.Error("Unexpected defaults config (-want, +got):", diff)
+		}
+	})
 }
 
 func TestStoreLoadWithContextOrDefaults(t *testing.T) {
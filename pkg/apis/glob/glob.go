@@ -84,16 +84,38 @@ func Match(glob, image string) (bool, error) {
 
 	// TODO: do we want ":" to count as a separator like "/" is?
 
+	return match(re, image)
+}
+
+// MatchRegex will return true if the image reference matches the requested
+// regular expression.
+//
+// The regular expression is matched against the fully qualified image
+// reference (e.g. "index.docker.io/library/ubuntu:latest" for "ubuntu"), and
+// like glob patterns it is implicitly anchored, that is it must match the
+// whole reference.
+//
+// If the regular expression or the image reference is invalid, an error will
+// be returned.
+func MatchRegex(regex, image string) (bool, error) {
+	re, err := regexp.Compile("^(?:" + regex + ")$")
+	if err != nil {
+		return false, err
+	}
+	return match(re, image)
+}
+
+func match(re *regexp.Regexp, image string) (bool, error) {
 	ref, err := name.ParseReference(image, name.WeakValidation)
 	if err != nil {
 		return false, err
 	}
 
-	match := re.MatchString(ref.Name())
-	if !match && ref.Name() != image {
+	matched := re.MatchString(ref.Name())
+	if !matched && ref.Name() != image {
 		// If the image was not fully qualified, try matching the glob against the original non-fully-qualified.
 		// This should be a warning and this behavior should eventually be removed.
-		match = re.MatchString(image)
+		matched = re.MatchString(image)
 	}
-	return match, nil
+	return matched, nil
 }
@@ -91,3 +91,39 @@ func TestGlobMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestRegexMatch(t *testing.T) {
+	for _, c := range []struct {
+		image, regex string
+		wantMatch    bool
+		wantErr      bool
+	}{
+		{image: "foo", regex: "^index\\.docker\\.io/library/foo:latest$", wantMatch: true},
+		{image: "foo", regex: `index\.docker\.io/library/foo:latest`, wantMatch: true}, // implicitly anchored.
+		{image: "foo", regex: "library/foo", wantMatch: false},                         // must match the whole name.
+		{image: "foo", regex: "^foo$", wantMatch: true},                                // matches because of deprecated fallback logic.
+		{image: "vendor.io/app", regex: `vendor\.io/.*`, wantMatch: true},
+		{image: "attacker.io/vendor.io/app", regex: `vendor\.io/.*`, wantMatch: false},
+		{image: "vendor.io.attacker.io/app", regex: `vendor\.io`, wantMatch: false},
+		{image: "ghcr.io/foo/bar", regex: `ghcr\.io/foo/bar|docker\.io/.*`, wantMatch: true}, // alternations are anchored as a whole.
+		{image: "attacker.io/ghcr.io/foo/bar", regex: `docker\.io/.*|ghcr\.io/foo/bar.*`, wantMatch: false},
+		{image: "ghcr.io/foo/bar:v1.2.3", regex: `^ghcr\.io/foo/[^/]+:v[0-9]+\.[0-9]+\.[0-9]+$`, wantMatch: true},
+		{image: "ghcr.io/foo/bar:latest", regex: `^ghcr\.io/foo/[^/]+:v[0-9]+\.[0-9]+\.[0-9]+$`, wantMatch: false},
+		{image: "ghcr.io/foo/sandbox/bar", regex: `^ghcr\.io/foo/(app|tools)/.*`, wantMatch: false},
+		{image: "ghcr.io/foo/app/bar", regex: `^ghcr\.io/foo/(app|tools)/.*`, wantMatch: true},
+
+		// Various error cases.
+		{image: "invalid&name", regex: ".*", wantMatch: false, wantErr: true},    // invalid refs are not matched.
+		{image: "invalid-regex", regex: "[a-z", wantMatch: false, wantErr: true}, // invalid regexes are rejected.
+	} {
+		t.Run(c.image+"|"+c.regex, func(t *testing.T) {
+			match, err := MatchRegex(c.regex, c.image)
+			if match != c.wantMatch {
+				t.Errorf("match: got %t, want %t", match, c.wantMatch)
+			}
+			if gotErr := err != nil; gotErr != c.wantErr {
+				t.Errorf("err: got %v, want %t", err, c.wantErr)
+			}
+		})
+	}
+}
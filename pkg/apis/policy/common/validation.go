@@ -15,14 +15,20 @@
 package common
 
 import (
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 	"net"
 	"regexp"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	registryfuncs "github.com/google/go-containerregistry/pkg/name"
+	ggcrv1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/sigstore/sigstore/pkg/signature/kms/aws"
 	"github.com/sigstore/sigstore/pkg/signature/kms/azure"
 	"github.com/sigstore/sigstore/pkg/signature/kms/gcp"
@@ -33,6 +39,9 @@ import (
 
 const (
 	ociRepoDelimiter = "/"
+
+	// MaxSLSABuildLevel is the highest build level of the SLSA build track.
+	MaxSLSABuildLevel = 3
 )
 
 var (
@@ -42,6 +51,38 @@ var (
 	ValidPredicateTypes = sets.NewString("custom", "slsaprovenance", "spdx",
 		"spdxjson", "cyclonedx", "link", "vuln")
 
+	// ValidSLSAPredicateTypes are the predicate types of the SLSA provenance
+	// that an attestation slsa block can be checked against.
+	ValidSLSAPredicateTypes = sets.NewString("slsaprovenance", "slsaprovenance02",
+		"slsaprovenance1", "https://slsa.dev/provenance/v0.2", "https://slsa.dev/provenance/v1")
+
+	// ValidVulnerabilityPredicateTypes are the predicate types of the
+	// vulnerability scans that an attestation vulnerability block can be
+	// checked against.
+	ValidVulnerabilityPredicateTypes = sets.NewString("vuln", "https://cosign.sigstore.dev/attestation/vuln/v1")
+
+	// ValidCertificateExtensions are the names of the Fulcio certificate
+	// extensions that keyless identities can match on.
+	// See also: https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+	ValidCertificateExtensions = sets.NewString("buildSignerURI", "buildSignerDigest",
+		"runnerEnvironment", "sourceRepositoryURI", "sourceRepositoryDigest",
+		"sourceRepositoryRef", "sourceRepositoryIdentifier", "sourceRepositoryOwnerURI",
+		"sourceRepositoryOwnerIdentifier", "buildConfigURI", "buildConfigDigest",
+		"buildTrigger", "runInvocationURI", "sourceRepositoryVisibilityAtSigning")
+
+	// ValidVulnerabilitySeverities are the severities that vulnerabilities
+	// are counted by.
+	ValidVulnerabilitySeverities = sets.NewString("CRITICAL", "HIGH", "MEDIUM", "LOW", "UNKNOWN")
+
+	// ValidExtKeyUsages are the names of the extended key usages that a
+	// certificate authority can require, besides their OIDs.
+	ValidExtKeyUsages = sets.NewString("any", "serverAuth", "clientAuth", "codeSigning",
+		"emailProtection", "timeStamping", "ocspSigning")
+
+	// ValidNotationTrustStoreTypes are the types of the Notary Project trust
+	// stores that a notation authority can verify signatures against.
+	ValidNotationTrustStoreTypes = sets.NewString("ca", "signingAuthority")
+
 	// If a static matches, define the behaviour for it.
 	ValidStaticRefTypes = sets.NewString("fail", "pass")
 
@@ -168,3 +209,150 @@ func ValidateKMS(kms string) *apis.FieldError {
 	}
 	return errs
 }
+
+// ValidateOID returns a non-nil error if oid is not a dotted decimal object
+// identifier, for example 1.3.6.1.4.1.57264.
+func ValidateOID(oid string) error {
+	if _, err := x509.ParseOID(oid); err != nil {
+		return fmt.Errorf("invalid OID %q: %w", oid, err)
+	}
+	return nil
+}
+
+// ValidateCRLs returns a non-nil error if data is not a list of PEM encoded
+// certificate revocation lists.
+func ValidateCRLs(data []byte) error {
+	found := false
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "X509 CRL" {
+			return fmt.Errorf("unexpected PEM block of type %q", block.Type)
+		}
+		if _, err := x509.ParseRevocationList(block.Bytes); err != nil {
+			return fmt.Errorf("parsing certificate revocation list: %w", err)
+		}
+		found = true
+	}
+	if !found {
+		return errors.New("no PEM encoded certificate revocation lists found")
+	}
+	return nil
+}
+
+// x509SubjectPrefix prefixes the distinguished name of a Notary Project
+// trusted identity.
+const x509SubjectPrefix = "x509.subject:"
+
+// ParseTrustedIdentity parses a Notary Project trusted identity, for example
+// "x509.subject: C=US, ST=WA, O=Example Corp", into the attributes of its
+// distinguished name keyed by their type. It returns nil for the wildcard
+// "*". Like Notation, it requires the C, ST and O attributes.
+func ParseTrustedIdentity(identity string) (map[string]string, error) {
+	if identity == "*" {
+		return nil, nil
+	}
+	dn, ok := strings.CutPrefix(identity, x509SubjectPrefix)
+	if !ok {
+		return nil, fmt.Errorf("trusted identity %q must be * or start with %s", identity, x509SubjectPrefix)
+	}
+	attributes, err := ParseDistinguishedName(dn)
+	if err != nil {
+		return nil, err
+	}
+	for _, required := range []string{"C", "ST", "O"} {
+		if _, ok := attributes[required]; !ok {
+			return nil, fmt.Errorf("trusted identity %q must have the C, ST and O attributes", identity)
+		}
+	}
+	return attributes, nil
+}
+
+// ParseDistinguishedName parses a distinguished name in the string form of
+// RFC 4514, as returned by pkix.Name.String, into its attributes keyed by
+// their type. Attributes that occur more than once, or multi-valued RDNs, are
+// not supported.
+func ParseDistinguishedName(dn string) (map[string]string, error) {
+	attributes := map[string]string{}
+	var attribute []string
+	var current strings.Builder
+	escaped := false
+	add := func() error {
+		attribute = append(attribute, current.String())
+		current.Reset()
+		if len(attribute) != 2 {
+			return fmt.Errorf("distinguished name %q has an attribute without a value", dn)
+		}
+		typ, value := strings.ToUpper(strings.TrimSpace(attribute[0])), strings.TrimSpace(attribute[1])
+		attribute = nil
+		if typ == "" || value == "" {
+			return fmt.Errorf("distinguished name %q has an empty attribute", dn)
+		}
+		if _, ok := attributes[typ]; ok {
+			return fmt.Errorf("distinguished name %q has more than one %s attribute", dn, typ)
+		}
+		attributes[typ] = value
+		return nil
+	}
+	for _, r := range dn {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '=' && len(attribute) == 0:
+			attribute = append(attribute, current.String())
+			current.Reset()
+		case r == '+':
+			return nil, fmt.Errorf("distinguished name %q has a multi-valued RDN", dn)
+		case r == ',' || r == ';':
+			if err := add(); err != nil {
+				return nil, err
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if escaped {
+		return nil, fmt.Errorf("distinguished name %q ends with an escape", dn)
+	}
+	if err := add(); err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// NormalizeKeyFingerprint returns the hex encoded SHA-256 fingerprint of a
+// public key, optionally separated by colons, in lower case without colons.
+func NormalizeKeyFingerprint(fingerprint string) (string, error) {
+	normalized := strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+	if b, err := hex.DecodeString(normalized); err != nil || len(b) != sha256.Size {
+		return "", fmt.Errorf("fingerprint %q is not a hex encoded SHA-256 digest", fingerprint)
+	}
+	return normalized, nil
+}
+
+// NormalizeCertificateSerial returns the hex encoded serial number of a
+// certificate, optionally separated by colons, in lower case without colons
+// or leading zeros, the way big.Int formats it in base 16.
+func NormalizeCertificateSerial(serial string) (string, error) {
+	n, ok := new(big.Int).SetString(strings.ReplaceAll(serial, ":", ""), 16)
+	if !ok || n.Sign() < 0 {
+		return "", fmt.Errorf("serial number %q is not hex encoded", serial)
+	}
+	return n.Text(16), nil
+}
+
+// ParsePlatform parses a platform in os/arch[/variant] form, for example
+// linux/arm64/v8.
+func ParsePlatform(platform string) (*ggcrv1.Platform, error) {
+	p, err := ggcrv1.ParsePlatform(platform)
+	if err != nil || p.OS == "" || p.Architecture == "" || p.OSVersion != "" {
+		return nil, fmt.Errorf("platform %q is not in os/arch[/variant] form", platform)
+	}
+	return p, nil
+}
@@ -72,3 +72,166 @@ func TestValidateOCI(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTrustedIdentity(t *testing.T) {
+	tests := []struct {
+		identity    string
+		want        map[string]string
+		errorString string
+	}{{
+		identity: "*",
+	}, {
+		identity: "x509.subject: C=US, ST=WA, L=Seattle, O=Example Corp",
+		want:     map[string]string{"C": "US", "ST": "WA", "L": "Seattle", "O": "Example Corp"},
+	}, {
+		identity: `x509.subject:C=US,ST=WA,O=Example\, Inc.`,
+		want:     map[string]string{"C": "US", "ST": "WA", "O": "Example, Inc."},
+	}, {
+		identity:    "C=US, ST=WA, O=Example Corp",
+		errorString: `trusted identity "C=US, ST=WA, O=Example Corp" must be * or start with x509.subject:`,
+	}, {
+		identity:    "x509.subject: C=US, O=Example Corp",
+		errorString: `trusted identity "x509.subject: C=US, O=Example Corp" must have the C, ST and O attributes`,
+	}, {
+		identity:    "x509.subject: C=US, ST=WA, O=Example Corp, OU=Build, OU=Release",
+		errorString: `distinguished name " C=US, ST=WA, O=Example Corp, OU=Build, OU=Release" has more than one OU attribute`,
+	}, {
+		identity:    "x509.subject: C=US+ST=WA, O=Example Corp",
+		errorString: `distinguished name " C=US+ST=WA, O=Example Corp" has a multi-valued RDN`,
+	}, {
+		identity:    "x509.subject: C=US, ST, O=Example Corp",
+		errorString: `distinguished name " C=US, ST, O=Example Corp" has an attribute without a value`,
+	}}
+	for _, test := range tests {
+		t.Run(test.identity, func(t *testing.T) {
+			got, err := ParseTrustedIdentity(test.identity)
+			if test.errorString == "" {
+				if err != nil {
+					t.Error("Unexpected error", err.Error())
+				}
+				if diff := cmp.Diff(test.want, got); diff != "" {
+					t.Error("Unexpected attributes (-want, +got):", diff)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if diff := cmp.Diff(test.errorString, err.Error()); diff != "" {
+				t.Error("Unexpected error mesage (-want, +got):", diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeKeyFingerprint(t *testing.T) {
+	const fingerprint = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	tests := []struct {
+		fingerprint string
+		want        string
+		errorString string
+	}{{
+		fingerprint: fingerprint,
+		want:        fingerprint,
+	}, {
+		fingerprint: "2C:F2:4D:BA:5F:B0:A3:0E:26:E8:3B:2A:C5:B9:E2:9E:1B:16:1E:5C:1F:A7:42:5E:73:04:33:62:93:8B:98:24",
+		want:        fingerprint,
+	}, {
+		fingerprint: "2cf24dba",
+		errorString: `fingerprint "2cf24dba" is not a hex encoded SHA-256 digest`,
+	}}
+	for _, test := range tests {
+		t.Run(test.fingerprint, func(t *testing.T) {
+			got, err := NormalizeKeyFingerprint(test.fingerprint)
+			if test.errorString == "" {
+				if err != nil {
+					t.Error("Unexpected error", err.Error())
+				}
+				if got != test.want {
+					t.Errorf("NormalizeKeyFingerprint() = %q, wanted %q", got, test.want)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.errorString {
+				t.Errorf("NormalizeKeyFingerprint() = %v, wanted %q", err, test.errorString)
+			}
+		})
+	}
+}
+
+func TestNormalizeCertificateSerial(t *testing.T) {
+	tests := []struct {
+		serial      string
+		want        string
+		errorString string
+	}{{
+		serial: "54667a4d0818b8b8422cb14160831453fb68aac3f",
+		want:   "54667a4d0818b8b8422cb14160831453fb68aac3f",
+	}, {
+		serial: "00:B9:D5:89:57:E7:53:46",
+		want:   "b9d58957e75346",
+	}, {
+		serial:      "not-a-serial",
+		errorString: `serial number "not-a-serial" is not hex encoded`,
+	}, {
+		serial:      "-1",
+		errorString: `serial number "-1" is not hex encoded`,
+	}}
+	for _, test := range tests {
+		t.Run(test.serial, func(t *testing.T) {
+			got, err := NormalizeCertificateSerial(test.serial)
+			if test.errorString == "" {
+				if err != nil {
+					t.Error("Unexpected error", err.Error())
+				}
+				if got != test.want {
+					t.Errorf("NormalizeCertificateSerial() = %q, wanted %q", got, test.want)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.errorString {
+				t.Errorf("NormalizeCertificateSerial() = %v, wanted %q", err, test.errorString)
+			}
+		})
+	}
+}
+
+func TestParsePlatform(t *testing.T) {
+	tests := []struct {
+		platform    string
+		want        string
+		errorString string
+	}{{
+		platform: "linux/amd64",
+		want:     "linux/amd64",
+	}, {
+		platform: "linux/arm64/v8",
+		want:     "linux/arm64/v8",
+	}, {
+		platform:    "linux",
+		errorString: `platform "linux" is not in os/arch[/variant] form`,
+	}, {
+		platform:    "windows/amd64:10.0.17763.1577",
+		errorString: `platform "windows/amd64:10.0.17763.1577" is not in os/arch[/variant] form`,
+	}, {
+		platform:    "linux/arm64/v8/extra",
+		errorString: `platform "linux/arm64/v8/extra" is not in os/arch[/variant] form`,
+	}}
+	for _, test := range tests {
+		t.Run(test.platform, func(t *testing.T) {
+			got, err := ParsePlatform(test.platform)
+			if test.errorString == "" {
+				if err != nil {
+					t.Fatal("Unexpected error", err.Error())
+				}
+				if got.String() != test.want {
+					t.Errorf("ParsePlatform() = %q, wanted %q", got.String(), test.want)
+				}
+				return
+			}
+			if err == nil || err.Error() != test.errorString {
+				t.Errorf("ParsePlatform() = %v, wanted %q", err, test.errorString)
+			}
+		})
+	}
+}
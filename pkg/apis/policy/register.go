@@ -28,4 +28,22 @@ var (
 		Group:    GroupName,
 		Resource: "clusterimagepolicies",
 	}
+
+	// ImagePolicyResource represents an ImagePolicy
+	ImagePolicyResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "imagepolicies",
+	}
+
+	// PolicyExceptionResource represents a PolicyException
+	PolicyExceptionResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "policyexceptions",
+	}
+
+	// RevocationResource represents a Revocation
+	RevocationResource = schema.GroupResource{
+		Group:    GroupName,
+		Resource: "revocations",
+	}
 )
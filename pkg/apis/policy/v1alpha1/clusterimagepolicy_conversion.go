@@ -17,9 +17,12 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/sigstore/policy-controller/pkg/apis/policy/v1beta1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/ptr"
 )
@@ -52,7 +55,7 @@ func (c *ClusterImagePolicy) ConvertFrom(ctx context.Context, obj apis.Convertib
 
 func (spec *ClusterImagePolicySpec) ConvertTo(ctx context.Context, sink *v1beta1.ClusterImagePolicySpec) error {
 	for _, image := range spec.Images {
-		sink.Images = append(sink.Images, v1beta1.ImagePattern{Glob: image.Glob})
+		sink.Images = append(sink.Images, v1beta1.ImagePattern{Glob: image.Glob, Regex: image.Regex, ExcludeGlob: image.ExcludeGlob})
 	}
 	for _, authority := range spec.Authorities {
 		v1beta1Authority := v1beta1.Authority{}
@@ -75,6 +78,15 @@ func (spec *ClusterImagePolicySpec) ConvertTo(ctx context.Context, sink *v1beta1
 		spec.Policy.ConvertTo(ctx, sink.Policy)
 	}
 	sink.Mode = spec.Mode
+	if spec.AuthorityThreshold != nil {
+		sink.AuthorityThreshold = ptr.Int32(*spec.AuthorityThreshold)
+	}
+	if spec.VerifyPlatforms != nil {
+		sink.VerifyPlatforms = &v1beta1.PlatformVerification{
+			Platforms:    slices.Clone(spec.VerifyPlatforms.Platforms),
+			Attestations: spec.VerifyPlatforms.Attestations,
+		}
+	}
 	return nil
 }
 
@@ -83,6 +95,12 @@ func (matchResource *MatchResource) ConvertTo(_ context.Context, sink *v1beta1.M
 	if matchResource.ResourceSelector != nil {
 		sink.ResourceSelector = matchResource.ResourceSelector.DeepCopy()
 	}
+	if matchResource.NamespaceSelector != nil {
+		sink.NamespaceSelector = matchResource.NamespaceSelector.DeepCopy()
+	}
+	for _, subject := range matchResource.Subjects {
+		sink.Subjects = append(sink.Subjects, v1beta1.Subject{Kind: subject.Kind, Name: subject.Name, Namespace: subject.Namespace})
+	}
 
 	return nil
 }
@@ -117,6 +135,14 @@ func (authority *Authority) ConvertTo(ctx context.Context, sink *v1beta1.Authori
 			v1beta1Att.Policy = &v1beta1.Policy{}
 			att.Policy.ConvertTo(ctx, v1beta1Att.Policy)
 		}
+		if att.SLSA != nil {
+			v1beta1Att.SLSA = &v1beta1.SLSA{}
+			att.SLSA.ConvertTo(ctx, v1beta1Att.SLSA)
+		}
+		if att.Vulnerability != nil {
+			v1beta1Att.Vulnerability = &v1beta1.Vulnerability{}
+			att.Vulnerability.ConvertTo(ctx, v1beta1Att.Vulnerability)
+		}
 		sink.Attestations = append(sink.Attestations, v1beta1Att)
 	}
 	if authority.Key != nil {
@@ -129,7 +155,7 @@ func (authority *Authority) ConvertTo(ctx context.Context, sink *v1beta1.Authori
 			TrustRootRef: authority.Keyless.TrustRootRef,
 		}
 		for _, id := range authority.Keyless.Identities {
-			sink.Keyless.Identities = append(sink.Keyless.Identities, v1beta1.Identity{Issuer: id.Issuer, Subject: id.Subject, IssuerRegExp: id.IssuerRegExp, SubjectRegExp: id.SubjectRegExp})
+			sink.Keyless.Identities = append(sink.Keyless.Identities, v1beta1.Identity{Issuer: id.Issuer, Subject: id.Subject, IssuerRegExp: id.IssuerRegExp, SubjectRegExp: id.SubjectRegExp, Extensions: maps.Clone(id.Extensions), ExtensionsRegExp: maps.Clone(id.ExtensionsRegExp)})
 		}
 		if authority.Keyless.CACert != nil {
 			sink.Keyless.CACert = &v1beta1.KeyRef{}
@@ -139,6 +165,14 @@ func (authority *Authority) ConvertTo(ctx context.Context, sink *v1beta1.Authori
 			sink.Keyless.InsecureIgnoreSCT = authority.Keyless.InsecureIgnoreSCT
 		}
 	}
+	if authority.Certificate != nil {
+		sink.Certificate = &v1beta1.CertificateRef{}
+		authority.Certificate.ConvertTo(ctx, sink.Certificate)
+	}
+	if authority.Notation != nil {
+		sink.Notation = &v1beta1.NotationRef{}
+		authority.Notation.ConvertTo(ctx, sink.Notation)
+	}
 	if authority.Static != nil {
 		sink.Static = &v1beta1.StaticRef{
 			Action:  authority.Static.Action,
@@ -176,6 +210,9 @@ func (p *Policy) ConvertTo(_ context.Context, sink *v1beta1.Policy) {
 	if p.IncludeTypeMeta != nil {
 		sink.IncludeTypeMeta = ptr.Bool(*p.IncludeTypeMeta)
 	}
+	if p.IncludeAttestationPayloads != nil {
+		sink.IncludeAttestationPayloads = ptr.Bool(*p.IncludeAttestationPayloads)
+	}
 }
 
 func (p *Policy) ConvertFrom(_ context.Context, source *v1beta1.Policy) {
@@ -206,6 +243,55 @@ func (p *Policy) ConvertFrom(_ context.Context, source *v1beta1.Policy) {
 	if source.IncludeTypeMeta != nil {
 		p.IncludeTypeMeta = ptr.Bool(*source.IncludeTypeMeta)
 	}
+	if source.IncludeAttestationPayloads != nil {
+		p.IncludeAttestationPayloads = ptr.Bool(*source.IncludeAttestationPayloads)
+	}
+}
+
+func (slsa *SLSA) ConvertTo(_ context.Context, sink *v1beta1.SLSA) {
+	for _, builder := range slsa.Builders {
+		sink.Builders = append(sink.Builders, v1beta1.SLSABuilder{ID: builder.ID, BuildLevel: builder.BuildLevel})
+	}
+	sink.BuildTypes = append(sink.BuildTypes, slsa.BuildTypes...)
+	sink.SourceURIs = append(sink.SourceURIs, slsa.SourceURIs...)
+	sink.SourceRefs = append(sink.SourceRefs, slsa.SourceRefs...)
+	sink.MinBuildLevel = slsa.MinBuildLevel
+}
+
+func (slsa *SLSA) ConvertFrom(_ context.Context, source *v1beta1.SLSA) {
+	for _, builder := range source.Builders {
+		slsa.Builders = append(slsa.Builders, SLSABuilder{ID: builder.ID, BuildLevel: builder.BuildLevel})
+	}
+	slsa.BuildTypes = append(slsa.BuildTypes, source.BuildTypes...)
+	slsa.SourceURIs = append(slsa.SourceURIs, source.SourceURIs...)
+	slsa.SourceRefs = append(slsa.SourceRefs, source.SourceRefs...)
+	slsa.MinBuildLevel = source.MinBuildLevel
+}
+
+func (vuln *Vulnerability) ConvertTo(_ context.Context, sink *v1beta1.Vulnerability) {
+	for severity, count := range vuln.MaxSeverityCounts {
+		if sink.MaxSeverityCounts == nil {
+			sink.MaxSeverityCounts = make(map[string]int32, len(vuln.MaxSeverityCounts))
+		}
+		sink.MaxSeverityCounts[severity] = count
+	}
+	sink.IgnoredCVEs = append(sink.IgnoredCVEs, vuln.IgnoredCVEs...)
+	if vuln.MaxScanAge != nil {
+		sink.MaxScanAge = &metav1.Duration{Duration: vuln.MaxScanAge.Duration}
+	}
+}
+
+func (vuln *Vulnerability) ConvertFrom(_ context.Context, source *v1beta1.Vulnerability) {
+	for severity, count := range source.MaxSeverityCounts {
+		if vuln.MaxSeverityCounts == nil {
+			vuln.MaxSeverityCounts = make(map[string]int32, len(source.MaxSeverityCounts))
+		}
+		vuln.MaxSeverityCounts[severity] = count
+	}
+	vuln.IgnoredCVEs = append(vuln.IgnoredCVEs, source.IgnoredCVEs...)
+	if source.MaxScanAge != nil {
+		vuln.MaxScanAge = &metav1.Duration{Duration: source.MaxScanAge.Duration}
+	}
 }
 
 func (key *KeyRef) ConvertTo(_ context.Context, sink *v1beta1.KeyRef) {
@@ -213,11 +299,37 @@ func (key *KeyRef) ConvertTo(_ context.Context, sink *v1beta1.KeyRef) {
 	sink.Data = key.Data
 	sink.KMS = key.KMS
 	sink.HashAlgorithm = key.HashAlgorithm
+	sink.ValidFrom = key.ValidFrom.DeepCopy()
+	sink.ValidUntil = key.ValidUntil.DeepCopy()
+}
+
+func (cert *CertificateRef) ConvertTo(_ context.Context, sink *v1beta1.CertificateRef) {
+	sink.CABundle = cert.CABundle
+	sink.SecretRef = cert.SecretRef.DeepCopy()
+	sink.TrustRootRef = cert.TrustRootRef
+	sink.SubjectAlternativeNames = append(sink.SubjectAlternativeNames, cert.SubjectAlternativeNames...)
+	sink.ExtKeyUsages = append(sink.ExtKeyUsages, cert.ExtKeyUsages...)
+	sink.Issuer = cert.Issuer
+	sink.PolicyOIDs = append(sink.PolicyOIDs, cert.PolicyOIDs...)
+	if cert.CRL != nil {
+		sink.CRL = &v1beta1.CRLRef{
+			Data:      cert.CRL.Data,
+			SecretRef: cert.CRL.SecretRef.DeepCopy(),
+		}
+	}
+}
+
+func (notation *NotationRef) ConvertTo(_ context.Context, sink *v1beta1.NotationRef) {
+	sink.CABundle = notation.CABundle
+	sink.SecretRef = notation.SecretRef.DeepCopy()
+	sink.TrustRootRef = notation.TrustRootRef
+	sink.TrustStoreType = notation.TrustStoreType
+	sink.TrustedIdentities = append(sink.TrustedIdentities, notation.TrustedIdentities...)
 }
 
 func (spec *ClusterImagePolicySpec) ConvertFrom(ctx context.Context, source *v1beta1.ClusterImagePolicySpec) error {
 	for _, image := range source.Images {
-		spec.Images = append(spec.Images, ImagePattern{Glob: image.Glob})
+		spec.Images = append(spec.Images, ImagePattern{Glob: image.Glob, Regex: image.Regex, ExcludeGlob: image.ExcludeGlob})
 	}
 	for i := range source.Authorities {
 		authority := Authority{}
@@ -236,6 +348,15 @@ func (spec *ClusterImagePolicySpec) ConvertFrom(ctx context.Context, source *v1b
 		spec.Match = append(spec.Match, matchResource)
 	}
 	spec.Mode = source.Mode
+	if source.AuthorityThreshold != nil {
+		spec.AuthorityThreshold = ptr.Int32(*source.AuthorityThreshold)
+	}
+	if source.VerifyPlatforms != nil {
+		spec.VerifyPlatforms = &PlatformVerification{
+			Platforms:    slices.Clone(source.VerifyPlatforms.Platforms),
+			Attestations: source.VerifyPlatforms.Attestations,
+		}
+	}
 	if source.Policy != nil {
 		spec.Policy = &Policy{}
 		spec.Policy.ConvertFrom(ctx, source.Policy)
@@ -273,6 +394,14 @@ func (authority *Authority) ConvertFrom(ctx context.Context, source *v1beta1.Aut
 			attestation.Policy = &Policy{}
 			attestation.Policy.ConvertFrom(ctx, att.Policy)
 		}
+		if att.SLSA != nil {
+			attestation.SLSA = &SLSA{}
+			attestation.SLSA.ConvertFrom(ctx, att.SLSA)
+		}
+		if att.Vulnerability != nil {
+			attestation.Vulnerability = &Vulnerability{}
+			attestation.Vulnerability.ConvertFrom(ctx, att.Vulnerability)
+		}
 		authority.Attestations = append(authority.Attestations, attestation)
 	}
 	if source.Key != nil {
@@ -285,7 +414,7 @@ func (authority *Authority) ConvertFrom(ctx context.Context, source *v1beta1.Aut
 			TrustRootRef: source.Keyless.TrustRootRef,
 		}
 		for _, id := range source.Keyless.Identities {
-			authority.Keyless.Identities = append(authority.Keyless.Identities, Identity{Issuer: id.Issuer, Subject: id.Subject, IssuerRegExp: id.IssuerRegExp, SubjectRegExp: id.SubjectRegExp})
+			authority.Keyless.Identities = append(authority.Keyless.Identities, Identity{Issuer: id.Issuer, Subject: id.Subject, IssuerRegExp: id.IssuerRegExp, SubjectRegExp: id.SubjectRegExp, Extensions: maps.Clone(id.Extensions), ExtensionsRegExp: maps.Clone(id.ExtensionsRegExp)})
 		}
 		if source.Keyless.CACert != nil {
 			authority.Keyless.CACert = &KeyRef{}
@@ -295,6 +424,14 @@ func (authority *Authority) ConvertFrom(ctx context.Context, source *v1beta1.Aut
 			authority.Keyless.InsecureIgnoreSCT = source.Keyless.InsecureIgnoreSCT
 		}
 	}
+	if source.Certificate != nil {
+		authority.Certificate = &CertificateRef{}
+		authority.Certificate.ConvertFrom(ctx, source.Certificate)
+	}
+	if source.Notation != nil {
+		authority.Notation = &NotationRef{}
+		authority.Notation.ConvertFrom(ctx, source.Notation)
+	}
 	if source.Static != nil {
 		authority.Static = &StaticRef{
 			Action:  source.Static.Action,
@@ -309,6 +446,32 @@ func (key *KeyRef) ConvertFrom(_ context.Context, source *v1beta1.KeyRef) {
 	key.Data = source.Data
 	key.KMS = source.KMS
 	key.HashAlgorithm = source.HashAlgorithm
+	key.ValidFrom = source.ValidFrom.DeepCopy()
+	key.ValidUntil = source.ValidUntil.DeepCopy()
+}
+
+func (cert *CertificateRef) ConvertFrom(_ context.Context, source *v1beta1.CertificateRef) {
+	cert.CABundle = source.CABundle
+	cert.SecretRef = source.SecretRef.DeepCopy()
+	cert.TrustRootRef = source.TrustRootRef
+	cert.SubjectAlternativeNames = append(cert.SubjectAlternativeNames, source.SubjectAlternativeNames...)
+	cert.ExtKeyUsages = append(cert.ExtKeyUsages, source.ExtKeyUsages...)
+	cert.Issuer = source.Issuer
+	cert.PolicyOIDs = append(cert.PolicyOIDs, source.PolicyOIDs...)
+	if source.CRL != nil {
+		cert.CRL = &CRLRef{
+			Data:      source.CRL.Data,
+			SecretRef: source.CRL.SecretRef.DeepCopy(),
+		}
+	}
+}
+
+func (notation *NotationRef) ConvertFrom(_ context.Context, source *v1beta1.NotationRef) {
+	notation.CABundle = source.CABundle
+	notation.SecretRef = source.SecretRef.DeepCopy()
+	notation.TrustRootRef = source.TrustRootRef
+	notation.TrustStoreType = source.TrustStoreType
+	notation.TrustedIdentities = append(notation.TrustedIdentities, source.TrustedIdentities...)
 }
 
 func (matchResource *MatchResource) ConvertFrom(_ context.Context, source *v1beta1.MatchResource) error {
@@ -316,5 +479,11 @@ func (matchResource *MatchResource) ConvertFrom(_ context.Context, source *v1bet
 	if source.ResourceSelector != nil {
 		matchResource.ResourceSelector = source.ResourceSelector.DeepCopy()
 	}
+	if source.NamespaceSelector != nil {
+		matchResource.NamespaceSelector = source.NamespaceSelector.DeepCopy()
+	}
+	for _, subject := range source.Subjects {
+		matchResource.Subjects = append(matchResource.Subjects, Subject{Kind: subject.Kind, Name: subject.Name, Namespace: subject.Namespace})
+	}
 	return nil
 }
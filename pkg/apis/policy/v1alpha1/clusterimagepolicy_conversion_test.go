@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	v1 "k8s.io/api/core/v1"
@@ -273,6 +274,70 @@ func TestConversionRoundTripV1beta1(t *testing.T) {
 				},
 			},
 		},
+	}, {name: "static, authorityThreshold",
+		in: &v1beta1.ClusterImagePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-cip",
+			},
+			Spec: v1beta1.ClusterImagePolicySpec{
+				Images: []v1beta1.ImagePattern{{Glob: "*"}},
+				Authorities: []v1beta1.Authority{
+					{Name: "build", Static: &v1beta1.StaticRef{Action: "pass"}},
+					{Name: "security", Static: &v1beta1.StaticRef{Action: "pass"}},
+				},
+				AuthorityThreshold: ptr.Int32(2),
+			},
+		},
+	}, {name: "key, verifyPlatforms",
+		in: &v1beta1.ClusterImagePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-cip",
+			},
+			Spec: v1beta1.ClusterImagePolicySpec{
+				Images: []v1beta1.ImagePattern{{Glob: "*"}},
+				Authorities: []v1beta1.Authority{
+					{Key: &v1beta1.KeyRef{
+						SecretRef: &v1.SecretReference{Name: "mysecret"}}},
+				},
+				VerifyPlatforms: &v1beta1.PlatformVerification{
+					Platforms:    []string{"linux/amd64", "linux/arm64"},
+					Attestations: true,
+				},
+			},
+		},
+	}, {name: "key, attestations with slsa and vulnerability",
+		in: &v1beta1.ClusterImagePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-cip",
+			},
+			Spec: v1beta1.ClusterImagePolicySpec{
+				Images: []v1beta1.ImagePattern{{Glob: "*"}},
+				Authorities: []v1beta1.Authority{
+					{Key: &v1beta1.KeyRef{
+						SecretRef: &v1.SecretReference{Name: "mysecret"}},
+						Attestations: []v1beta1.Attestation{{
+							Name:          "provenance",
+							PredicateType: "https://slsa.dev/provenance/v1",
+							SLSA: &v1beta1.SLSA{
+								Builders:      []v1beta1.SLSABuilder{{ID: "https://github.com/actions/runner/github-hosted", BuildLevel: 2}},
+								BuildTypes:    []string{"https://actions.github.io/buildtypes/workflow/v1"},
+								SourceURIs:    []string{"git+https://github.com/sigstore/policy-controller"},
+								SourceRefs:    []string{"refs/heads/main"},
+								MinBuildLevel: 2,
+							},
+						}, {
+							Name:          "vuln",
+							PredicateType: "https://cosign.sigstore.dev/attestation/vuln/v1",
+							Vulnerability: &v1beta1.Vulnerability{
+								MaxSeverityCounts: map[string]int32{"CRITICAL": 0},
+								IgnoredCVEs:       []string{"CVE-2021-44228"},
+								MaxScanAge:        &metav1.Duration{Duration: time.Hour},
+							},
+						}},
+					},
+				},
+			},
+		},
 	}, {name: "key, keyless, source, and rfc3161timestamp, regexp",
 		in: &v1beta1.ClusterImagePolicy{
 			ObjectMeta: metav1.ObjectMeta{
@@ -313,6 +378,60 @@ func TestConversionRoundTripV1beta1(t *testing.T) {
 				},
 			},
 		},
+	}, {name: "certificate",
+		in: &v1beta1.ClusterImagePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-cip",
+			},
+			Spec: v1beta1.ClusterImagePolicySpec{
+				Images: []v1beta1.ImagePattern{{Glob: "*"}},
+				Authorities: []v1beta1.Authority{
+					{Certificate: &v1beta1.CertificateRef{
+						SecretRef:               &v1.SecretReference{Name: "ca-secret"},
+						SubjectAlternativeNames: []string{"builder@example.com"},
+						ExtKeyUsages:            []string{"codeSigning"},
+						Issuer:                  "CN=Example Code Signing CA,O=Example Corp",
+						PolicyOIDs:              []string{"1.3.6.1.4.1.99999.1"},
+						CRL:                     &v1beta1.CRLRef{SecretRef: &v1.SecretReference{Name: "crl-secret"}},
+					},
+					},
+				},
+			},
+		},
+	}, {name: "notation",
+		in: &v1beta1.ClusterImagePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-cip",
+			},
+			Spec: v1beta1.ClusterImagePolicySpec{
+				Images: []v1beta1.ImagePattern{{Glob: "*"}},
+				Authorities: []v1beta1.Authority{
+					{Notation: &v1beta1.NotationRef{
+						SecretRef:         &v1.SecretReference{Name: "truststore-secret"},
+						TrustStoreType:    "signingAuthority",
+						TrustedIdentities: []string{"x509.subject: C=US, ST=WA, O=Example Corp"},
+					},
+					},
+				},
+			},
+		},
+	}, {name: "key validity",
+		in: &v1beta1.ClusterImagePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-cip",
+			},
+			Spec: v1beta1.ClusterImagePolicySpec{
+				Images: []v1beta1.ImagePattern{{Glob: "*"}},
+				Authorities: []v1beta1.Authority{
+					{Key: &v1beta1.KeyRef{
+						KMS:        "kms",
+						ValidFrom:  &metav1.Time{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+						ValidUntil: &metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+					},
+					},
+				},
+			},
+		},
 	}}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -19,6 +19,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sigstore/policy-controller/pkg/apis/policy/common"
 	"github.com/stretchr/testify/require"
@@ -30,6 +31,10 @@ import (
 
 const validPublicKey = "-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEaEOVJCFtduYr3xqTxeRWSW32CY/s\nTBNZj4oIUPl8JvhVPJ1TKDPlNcuT4YphSt6t3yOmMvkdQbCj8broX6vijw==\n-----END PUBLIC KEY-----"
 
+const validCACert = "-----BEGIN CERTIFICATE-----\nMIIBozCCAUmgAwIBAgIBATAKBggqhkjOPQQDAjA5MRUwEwYDVQQKEwxFeGFtcGxl\nIENvcnAxIDAeBgNVBAMTF0V4YW1wbGUgQ29kZSBTaWduaW5nIENBMB4XDTI2MDEw\nMTAwMDAwMFoXDTM2MDEwMTAwMDAwMFowOTEVMBMGA1UEChMMRXhhbXBsZSBDb3Jw\nMSAwHgYDVQQDExdFeGFtcGxlIENvZGUgU2lnbmluZyBDQTBZMBMGByqGSM49AgEG\nCCqGSM49AwEHA0IABPuL7LgFZELI3s1+gC8iGuqzIDAIVi71GKm3qbsi1J3h6vur\n8wl6BTVHPld2A3oYX6op5wYw7kAEC++3GxGjok2jQjBAMA4GA1UdDwEB/wQEAwIB\nBjAPBgNVHRMBAf8EBTADAQH/MB0GA1UdDgQWBBS1R1D4EuSjNgm8nQXY0W8vlFs3\nhTAKBggqhkjOPQQDAgNIADBFAiAFwkYG/JKL89noeKwCN0IY76Yw02mY5FSLsYr5\nhmGVtAIhAMm7R9z5wXqsO+3TvUDp8rUulKQWmFRckKjzmfcINnky\n-----END CERTIFICATE-----\n"
+
+const validCRL = "-----BEGIN X509 CRL-----\nMIHyMIGZAgEBMAoGCCqGSM49BAMCMDkxFTATBgNVBAoTDEV4YW1wbGUgQ29ycDEg\nMB4GA1UEAxMXRXhhbXBsZSBDb2RlIFNpZ25pbmcgQ0EXDTI2MDEwMTAwMDAwMFoX\nDTM2MDEwMTAwMDAwMFqgLzAtMB8GA1UdIwQYMBaAFLVHUPgS5KM2CbydBdjRby+U\nWzeFMAoGA1UdFAQDAgEBMAoGCCqGSM49BAMCA0gAMEUCIQD+abHrRLcZAtwFaXbG\nFCayItDcIA/6+uZ6us6Mtp8zoAIgPvXBYWZirqw5eVUnlnm+bHLFR45cZU4brVbP\nJ9yIBro=\n-----END X509 CRL-----\n"
+
 func TestImagePatternValidation(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -37,7 +42,7 @@ func TestImagePatternValidation(t *testing.T) {
 		policy      ClusterImagePolicy
 	}{{
 		name:        "Should fail when glob is not present",
-		errorString: "missing field(s): spec.authorities, spec.images[0].glob",
+		errorString: "expected exactly one, got neither: spec.images[0].glob, spec.images[0].regex\nmissing field(s): spec.authorities",
 		policy: ClusterImagePolicy{
 			Spec: ClusterImagePolicySpec{
 				Images: []ImagePattern{
@@ -69,6 +74,44 @@ func TestImagePatternValidation(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name:        "Should fail with both glob and regex",
+		errorString: "expected exactly one, got both: spec.images[0].glob, spec.images[0].regex\nmissing field(s): spec.authorities",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob:  "ghcr.io/example/*",
+						Regex: "^ghcr\\.io/example/.*",
+					},
+				},
+			},
+		},
+	}, {
+		name:        "Regex should fail with invalid regex",
+		errorString: "invalid value: ****: spec.images[0].regex\nregex is invalid: error parsing regexp: missing argument to repetition operator: `*`\nmissing field(s): spec.authorities",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Regex: "****",
+					},
+				},
+			},
+		},
+	}, {
+		name:        "ExcludeGlob should fail with invalid glob",
+		errorString: "invalid value: $FOO*: spec.images[0].excludeGlob[1]\nglob is invalid: invalid glob \"$FOO*\"\nmissing field(s): spec.authorities",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Regex:       "^ghcr\\.io/example/.*",
+						ExcludeGlob: []string{"ghcr.io/example/sandbox/**", "$FOO*"},
+					},
+				},
+			},
+		},
 	}, {
 		name:        "missing image and authorities in the spec",
 		errorString: "missing field(s): spec.authorities, spec.images",
@@ -552,6 +595,83 @@ func TestModeValidation(t *testing.T) {
 	}
 }
 
+func TestAuthorityThresholdValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		errorString string
+		threshold   *int32
+	}{{
+		name: "Should work when threshold is not set",
+	}, {
+		name:      "Should work with threshold 1",
+		threshold: ptr.Int32(1),
+	}, {
+		name:      "Should work when all authorities are required",
+		threshold: ptr.Int32(2),
+	}, {
+		name:        "Should not work with threshold 0",
+		threshold:   ptr.Int32(0),
+		errorString: "invalid value: 0: spec.authorityThreshold\nmust be at least 1",
+	}, {
+		name:        "Should not work with threshold greater than the number of authorities",
+		threshold:   ptr.Int32(3),
+		errorString: "invalid value: 3: spec.authorityThreshold\nmust not be greater than the number of authorities (2)",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := ClusterImagePolicy{
+				Spec: ClusterImagePolicySpec{
+					Images: []ImagePattern{{Glob: "globbityglob"}},
+					Authorities: []Authority{
+						{Name: "build", Static: &StaticRef{Action: "pass"}},
+						{Name: "security", Static: &StaticRef{Action: "pass"}},
+					},
+					AuthorityThreshold: test.threshold,
+				},
+			}
+			err := policy.Validate(context.TODO())
+			validateError(t, test.errorString, "", err)
+		})
+	}
+}
+
+func TestVerifyPlatformsValidation(t *testing.T) {
+	tests := []struct {
+		name            string
+		errorString     string
+		verifyPlatforms *PlatformVerification
+	}{{
+		name: "Should work when verifyPlatforms is not set",
+	}, {
+		name:            "Should work with all platforms",
+		verifyPlatforms: &PlatformVerification{Attestations: true},
+	}, {
+		name:            "Should work with platforms",
+		verifyPlatforms: &PlatformVerification{Platforms: []string{"linux/amd64", "linux/arm64/v8"}},
+	}, {
+		name:            "Should not work with invalid platform",
+		verifyPlatforms: &PlatformVerification{Platforms: []string{"linux/amd64", "linux"}},
+		errorString:     "invalid value: linux: spec.verifyPlatforms.platforms[1]\nplatform \"linux\" is not in os/arch[/variant] form",
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := ClusterImagePolicy{
+				Spec: ClusterImagePolicySpec{
+					Images: []ImagePattern{{Glob: "globbityglob"}},
+					Authorities: []Authority{
+						{Name: "build", Static: &StaticRef{Action: "pass"}},
+					},
+					VerifyPlatforms: test.verifyPlatforms,
+				},
+			}
+			err := policy.Validate(context.TODO())
+			validateError(t, test.errorString, "", err)
+		})
+	}
+}
+
 func TestAuthoritiesValidation(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -560,7 +680,7 @@ func TestAuthoritiesValidation(t *testing.T) {
 		policy      ClusterImagePolicy
 	}{{
 		name:        "Should fail when authority is empty",
-		errorString: "expected exactly one, got neither: spec.authorities[0].key, spec.authorities[0].keyless, spec.authorities[0].static",
+		errorString: "expected exactly one, got neither: spec.authorities[0].certificate, spec.authorities[0].key, spec.authorities[0].keyless, spec.authorities[0].notation, spec.authorities[0].static",
 		policy: ClusterImagePolicy{
 			Spec: ClusterImagePolicySpec{
 				Images: []ImagePattern{
@@ -575,7 +695,7 @@ func TestAuthoritiesValidation(t *testing.T) {
 		},
 	}, {
 		name:        "Should fail when key/keyless specified",
-		errorString: "expected exactly one, got both: spec.authorities[0].key, spec.authorities[0].keyless, spec.authorities[0].static",
+		errorString: "expected exactly one, got both: spec.authorities[0].certificate, spec.authorities[0].key, spec.authorities[0].keyless, spec.authorities[0].notation, spec.authorities[0].static",
 		policy: ClusterImagePolicy{
 			Spec: ClusterImagePolicySpec{
 				Images: []ImagePattern{
@@ -593,7 +713,7 @@ func TestAuthoritiesValidation(t *testing.T) {
 		},
 	}, {
 		name:        "Should fail when key/static specified",
-		errorString: "expected exactly one, got both: spec.authorities[0].key, spec.authorities[0].keyless, spec.authorities[0].static",
+		errorString: "expected exactly one, got both: spec.authorities[0].certificate, spec.authorities[0].key, spec.authorities[0].keyless, spec.authorities[0].notation, spec.authorities[0].static",
 		policy: ClusterImagePolicy{
 			Spec: ClusterImagePolicySpec{
 				Images: []ImagePattern{
@@ -611,7 +731,7 @@ func TestAuthoritiesValidation(t *testing.T) {
 		},
 	}, {
 		name:        "Should fail when keyless/static specified",
-		errorString: "expected exactly one, got both: spec.authorities[0].key, spec.authorities[0].keyless, spec.authorities[0].static",
+		errorString: "expected exactly one, got both: spec.authorities[0].certificate, spec.authorities[0].key, spec.authorities[0].keyless, spec.authorities[0].notation, spec.authorities[0].static",
 		policy: ClusterImagePolicy{
 			Spec: ClusterImagePolicySpec{
 				Images: []ImagePattern{
@@ -629,7 +749,7 @@ func TestAuthoritiesValidation(t *testing.T) {
 		},
 	}, {
 		name:        "Should fail when key/keyless/static specified",
-		errorString: "expected exactly one, got both: spec.authorities[0].key, spec.authorities[0].keyless, spec.authorities[0].static",
+		errorString: "expected exactly one, got both: spec.authorities[0].certificate, spec.authorities[0].key, spec.authorities[0].keyless, spec.authorities[0].notation, spec.authorities[0].static",
 		policy: ClusterImagePolicy{
 			Spec: ClusterImagePolicySpec{
 				Images: []ImagePattern{
@@ -646,6 +766,154 @@ func TestAuthoritiesValidation(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "Should pass with certificate authority",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Authorities: []Authority{
+					{
+						Certificate: &CertificateRef{
+							CABundle:                validCACert,
+							SubjectAlternativeNames: []string{"builds@example.com"},
+							ExtKeyUsages:            []string{"codeSigning", "1.3.6.1.5.5.7.3.3"},
+							Issuer:                  "CN=Example Code Signing CA,O=Example Corp",
+							PolicyOIDs:              []string{"1.3.6.1.4.1.99999.1"},
+							CRL:                     &CRLRef{Data: validCRL},
+						},
+					},
+				},
+			},
+		},
+	}, {
+		name: "Should pass with notation",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Authorities: []Authority{
+					{
+						Notation: &NotationRef{
+							CABundle:          validCACert,
+							TrustStoreType:    "signingAuthority",
+							TrustedIdentities: []string{"x509.subject: C=US, ST=WA, O=Example Corp"},
+						},
+					},
+				},
+			},
+		},
+	}, {
+		name:        "Should fail with invalid notation",
+		errorString: "expected exactly one, got both: spec.authorities[0].notation.caBundle, spec.authorities[0].notation.secretRef, spec.authorities[0].notation.trustRootRef\ninvalid value: *: spec.authorities[0].notation.trustedIdentities[0]\n* must be the only trusted identity\ninvalid value: tsa: spec.authorities[0].notation.trustStoreType\nmust be one of ca, signingAuthority\ninvalid value: x509.subject: C=US: spec.authorities[0].notation.trustedIdentities[1]\ntrusted identity \"x509.subject: C=US\" must have the C, ST and O attributes",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Authorities: []Authority{
+					{
+						Notation: &NotationRef{
+							SecretRef:         &v1.SecretReference{Name: "truststore"},
+							TrustRootRef:      "trust-root",
+							TrustStoreType:    "tsa",
+							TrustedIdentities: []string{"*", "x509.subject: C=US"},
+						},
+					},
+				},
+			},
+		},
+	}, {
+		name:        "Should fail with notation and ctlog",
+		errorString: "expected exactly one, got both: spec.authorities[0].ctlog, spec.authorities[0].notation",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Authorities: []Authority{
+					{
+						Notation: &NotationRef{TrustRootRef: "trust-root"},
+						CTLog:    &TLog{URL: apis.HTTPS("rekor.sigstore.dev")},
+					},
+				},
+			},
+		},
+	}, {
+		name: "Should pass with key validity",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Authorities: []Authority{
+					{
+						Key: &KeyRef{
+							Data:       validPublicKey,
+							ValidFrom:  &metav1.Time{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+							ValidUntil: &metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+						},
+						CTLog: &TLog{URL: apis.HTTPS("rekor.sigstore.dev")},
+					},
+				},
+			},
+		},
+	}, {
+		name:        "Should fail with invalid key validity",
+		errorString: "invalid value: 2025-01-01T00:00:00Z: spec.authorities[0].key.validUntil\nvalidUntil must be after validFrom\nvalidFrom and validUntil require a ctlog or rfc3161timestamp to establish the signing time: spec.authorities[0].ctlog, spec.authorities[0].rfc3161timestamp",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Authorities: []Authority{
+					{
+						Key: &KeyRef{
+							Data:       validPublicKey,
+							ValidFrom:  &metav1.Time{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+							ValidUntil: &metav1.Time{Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+						},
+					},
+				},
+			},
+		},
+	}, {
+		name:        "Should fail with invalid certificate authority",
+		errorString: "expected exactly one, got both: spec.authorities[0].certificate.caBundle, spec.authorities[0].certificate.secretRef, spec.authorities[0].certificate.trustRootRef\nexpected exactly one, got neither: spec.authorities[0].certificate.crl.data, spec.authorities[0].certificate.crl.secretRef\ninvalid value: codesigning: spec.authorities[0].certificate.extKeyUsages[0]\nmust be an OID or one of any, clientAuth, codeSigning, emailProtection, ocspSigning, serverAuth, timeStamping\ninvalid value: not a certificate: spec.authorities[0].certificate.caBundle\nmust contain PEM encoded certificates\ninvalid value: not-an-oid: spec.authorities[0].certificate.policyOIDs[0]",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Authorities: []Authority{
+					{
+						Certificate: &CertificateRef{
+							CABundle:     "not a certificate",
+							TrustRootRef: "trust-root",
+							ExtKeyUsages: []string{"codesigning"},
+							PolicyOIDs:   []string{"not-an-oid"},
+							CRL:          &CRLRef{},
+						},
+					},
+				},
+			},
+		},
 	}, {
 		name:        "Should fail when static and sources,attestations, and ctlog is specified, warn about legacy short predicate type",
 		errorString: "expected exactly one, got both: spec.authorities[0].attestations, spec.authorities[0].ctlog, spec.authorities[0].source, spec.authorities[0].static",
@@ -949,6 +1217,28 @@ func TestAuthoritiesValidation(t *testing.T) {
 			},
 		},
 		errorString: "must not set the field(s): spec.authorities[0].attestations.policy.includeTypeMeta",
+	}, {
+		name: "Should fail with attestations policy specifying includeAttestationPayloads",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{{Glob: "gcr.io/*"}},
+				Authorities: []Authority{
+					{
+						Key: &KeyRef{KMS: "hashivault://key/path"},
+						Attestations: []Attestation{
+							{Name: "first", PredicateType: "https://cosign.sigstore.dev/attestation/vuln/v1"},
+							{Name: "second", PredicateType: "https://cosign.sigstore.dev/attestation/v1", Policy: &Policy{
+								Type:                       "cue",
+								Data:                       `predicateType: "cosign.sigstore.dev/attestation/vuln/v1"`,
+								IncludeAttestationPayloads: ptr.Bool(true),
+							},
+							},
+						},
+					},
+				},
+			},
+		},
+		errorString: "must not set the field(s): spec.authorities[0].attestations.policy.includeAttestationPayloads",
 	}, {
 		name:        "Should fail with signaturePullSecret name empty",
 		errorString: "missing field(s): spec.authorities[0].source[0].signaturePullSecrets[0].name",
@@ -1046,7 +1336,24 @@ func TestAttestationsValidation(t *testing.T) {
 				Data: `predicateType: "cosign.sigstore.dev/attestation/vuln/v1"`,
 			},
 		},
-		errorString: "invalid value: not-cue: policy.type\nonly [cue,rego] are supported at the moment",
+		errorString: "invalid value: not-cue: policy.type\nonly [cue,rego,cel] are supported at the moment",
+	}, {
+		name: "custom with cel policy",
+		attestation: Attestation{Name: "second", PredicateType: "https://cosign.sigstore.dev/attestation/v1",
+			Policy: &Policy{
+				Type: "cel",
+				Data: `input.predicateType == "cosign.sigstore.dev/attestation/vuln/v1"`,
+			},
+		},
+	}, {
+		name: "custom with cel policy not evaluating to a bool",
+		attestation: Attestation{Name: "second", PredicateType: "https://cosign.sigstore.dev/attestation/v1",
+			Policy: &Policy{
+				Type: "cel",
+				Data: `input.predicateType + "foo"`,
+			},
+		},
+		errorString: "invalid value: input.predicateType + \"foo\": policy.data\nexpression must evaluate to a bool, not string",
 	}, {
 		name: "custom with missing policy data and configMapRef",
 		attestation: Attestation{Name: "second", PredicateType: "https://cosign.sigstore.dev/attestation/v1",
@@ -1127,6 +1434,79 @@ func TestAttestationsValidation(t *testing.T) {
 				Data: `predicateType: "cosign.sigstore.dev/attestation/vuln/v1"`,
 			},
 		},
+	}, {
+		name: "slsa",
+		attestation: Attestation{Name: "provenance", PredicateType: "https://slsa.dev/provenance/v1",
+			SLSA: &SLSA{
+				Builders:      []SLSABuilder{{ID: "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml", BuildLevel: 3}},
+				SourceURIs:    []string{"git+https://github.com/sigstore/policy-controller"},
+				SourceRefs:    []string{"refs/heads/main"},
+				MinBuildLevel: 3,
+			},
+		},
+	}, {
+		name: "slsa without predicate type",
+		attestation: Attestation{Name: "provenance",
+			SLSA: &SLSA{
+				Builders: []SLSABuilder{{ID: "https://github.com/actions/runner"}},
+			},
+		},
+	}, {
+		name: "slsa with a predicate type that is not provenance",
+		attestation: Attestation{Name: "provenance", PredicateType: "https://cosign.sigstore.dev/attestation/v1",
+			SLSA: &SLSA{
+				Builders: []SLSABuilder{{ID: "https://github.com/actions/runner"}},
+			},
+		},
+		errorString: "invalid value: https://cosign.sigstore.dev/attestation/v1: predicateType\nmust be a SLSA provenance predicate type when slsa is specified",
+	}, {
+		name:        "slsa with missing builders",
+		attestation: Attestation{Name: "provenance", PredicateType: "https://slsa.dev/provenance/v1", SLSA: &SLSA{}},
+		errorString: "missing field(s): slsa.builders",
+	}, {
+		name: "slsa with invalid builders and build level",
+		attestation: Attestation{Name: "provenance", PredicateType: "https://slsa.dev/provenance/v0.2",
+			SLSA: &SLSA{
+				Builders:      []SLSABuilder{{BuildLevel: 4}},
+				MinBuildLevel: -1,
+			},
+		},
+		errorString: "expected 0 <= -1 <= 3: slsa.minBuildLevel\nexpected 0 <= 4 <= 3: slsa.builders[0].buildLevel\nmissing field(s): slsa.builders[0].id",
+	}, {
+		name: "vulnerability",
+		attestation: Attestation{Name: "vuln", PredicateType: "https://cosign.sigstore.dev/attestation/vuln/v1",
+			Vulnerability: &Vulnerability{
+				MaxSeverityCounts: map[string]int32{"CRITICAL": 0, "HIGH": 5},
+				IgnoredCVEs:       []string{"CVE-2021-44228"},
+				MaxScanAge:        &metav1.Duration{Duration: 7 * 24 * time.Hour},
+			},
+		},
+	}, {
+		name: "vulnerability with a predicate type that is not a scan",
+		attestation: Attestation{Name: "vuln", PredicateType: "https://spdx.dev/Document",
+			Vulnerability: &Vulnerability{
+				MaxSeverityCounts: map[string]int32{"CRITICAL": 0},
+			},
+		},
+		errorString: "invalid value: https://spdx.dev/Document: predicateType\nmust be a vulnerability scan predicate type when vulnerability is specified",
+	}, {
+		name: "vulnerability without severity counts or scan age",
+		attestation: Attestation{Name: "vuln", PredicateType: "https://cosign.sigstore.dev/attestation/vuln/v1",
+			Vulnerability: &Vulnerability{
+				IgnoredCVEs: []string{"CVE-2021-44228"},
+			},
+		},
+		errorString: "expected at least one, got neither: vulnerability.maxScanAge, vulnerability.maxSeverityCounts",
+	}, {
+		name: "vulnerability with invalid severities, ignored CVEs and scan age",
+		attestation: Attestation{Name: "vuln", PredicateType: "https://cosign.sigstore.dev/attestation/vuln/v1",
+			Vulnerability: &Vulnerability{
+				MaxSeverityCounts: map[string]int32{"SEVERE": -1},
+				IgnoredCVEs:       []string{"CVE-2021-44228", ""},
+				MaxScanAge:        &metav1.Duration{},
+			},
+		},
+		errorString: "invalid key name \"SEVERE\": vulnerability.maxSeverityCounts\nmust be one of CRITICAL, HIGH, MEDIUM, LOW or UNKNOWN\ninvalid value: : vulnerability.ignoredCVEs[1]\ninvalid value: -1: vulnerability.maxSeverityCounts.SEVERE\nmust not be negative\ninvalid value: 0s: vulnerability.maxScanAge\nmust be positive",
 	},
 	}
 
@@ -1372,6 +1752,59 @@ func TestIdentitiesValidation(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "Should pass with certificate extensions",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Authorities: []Authority{
+					{
+						Keyless: &KeylessRef{
+							URL: &apis.URL{
+								Host: "myhost",
+							},
+							Identities: []Identity{{
+								Issuer:           "https://token.actions.githubusercontent.com",
+								SubjectRegExp:    "https://github.com/sigstore/policy-controller/.*",
+								Extensions:       map[string]string{"sourceRepositoryURI": "https://github.com/sigstore/policy-controller", "runnerEnvironment": "github-hosted"},
+								ExtensionsRegExp: map[string]string{"sourceRepositoryRef": "refs/tags/v.*"},
+							}},
+						},
+					},
+				},
+			},
+		},
+	}, {
+		name:        "Should fail with invalid certificate extensions",
+		errorString: "expected exactly one, got both: spec.authorities[0].keyless.identities[0].extensions[sourceRepositoryURI], spec.authorities[0].keyless.identities[0].extensionsRegExp[sourceRepositoryURI]\ninvalid key name \"workflow\": spec.authorities[0].keyless.identities[0].extensions\nunsupported certificate extension\ninvalid value: (: spec.authorities[0].keyless.identities[0].extensionsRegExp[sourceRepositoryRef]\nregex is invalid: error parsing regexp: missing closing ): `(`",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Authorities: []Authority{
+					{
+						Keyless: &KeylessRef{
+							URL: &apis.URL{
+								Host: "myhost",
+							},
+							Identities: []Identity{{
+								Issuer:           "https://token.actions.githubusercontent.com",
+								Subject:          "https://github.com/sigstore/policy-controller/.github/workflows/release.yaml@refs/heads/main",
+								Extensions:       map[string]string{"sourceRepositoryURI": "https://github.com/sigstore/policy-controller", "workflow": "release"},
+								ExtensionsRegExp: map[string]string{"sourceRepositoryURI": ".*", "sourceRepositoryRef": "("},
+							}},
+						},
+					},
+				},
+			},
+		},
 	},
 	}
 	for _, test := range tests {
@@ -1553,6 +1986,108 @@ func TestMatchValidation(t *testing.T) {
 				},
 			},
 		},
+	}, {
+		name: "Should pass with match namespace selector",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Match: []MatchResource{
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"env": "prod"},
+						},
+					},
+				},
+				Authorities: []Authority{
+					{
+						Static: &StaticRef{Action: "pass"},
+					},
+				},
+			},
+		},
+	}, {
+		name: "Should pass with match subjects",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Match: []MatchResource{
+					{
+						Subjects: []Subject{
+							{Kind: "User", Name: "alice@example.com"},
+							{Kind: "Group", Name: "developers"},
+							{Kind: "ServiceAccount", Namespace: "ci", Name: "deployer"},
+						},
+					},
+				},
+				Authorities: []Authority{
+					{
+						Static: &StaticRef{Action: "pass"},
+					},
+				},
+			},
+		},
+	}, {
+		name:        "Should fail with invalid match subjects",
+		errorString: "invalid value: Role: spec.match[0].subjects[0].kind\nunsupported kind, must be one of User, Group or ServiceAccount\nmissing field(s): spec.match[0].subjects[1].name, spec.match[0].subjects[2].namespace\nmust not set the field(s): spec.match[0].subjects[1].namespace",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Match: []MatchResource{
+					{
+						Subjects: []Subject{
+							{Kind: "Role", Name: "admin"},
+							{Kind: "User", Namespace: "default"},
+							{Kind: "ServiceAccount", Name: "deployer"},
+						},
+					},
+				},
+				Authorities: []Authority{
+					{
+						Static: &StaticRef{Action: "pass"},
+					},
+				},
+			},
+		},
+	}, {
+		name:        "Should fail with invalid match namespace selector",
+		errorString: "\"Foo\" is not a valid label selector operator: spec.match[0].namespaceSelector",
+		policy: ClusterImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{
+					{
+						Glob: "globbityglob",
+					},
+				},
+				Match: []MatchResource{
+					{
+						NamespaceSelector: &metav1.LabelSelector{
+							MatchExpressions: []metav1.LabelSelectorRequirement{{
+								Key:      "env",
+								Operator: "Foo",
+								Values:   []string{"prod"},
+							}},
+						},
+					},
+				},
+				Authorities: []Authority{
+					{
+						Static: &StaticRef{Action: "pass"},
+					},
+				},
+			},
+		},
 	}, {
 		name:        "Should fail with invalid match resource type",
 		errorString: "invalid value: myobject: spec.match[0].resource\nunsupported resource name",
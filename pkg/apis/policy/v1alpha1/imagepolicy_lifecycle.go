@@ -0,0 +1,42 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"knative.dev/pkg/apis"
+)
+
+// GetConditionSet retrieves the condition set for this resource.
+// Implements the KRShaped interface. ImagePolicy goes through the same steps
+// as the ClusterImagePolicy so it shares the conditions with it.
+func (*ImagePolicy) GetConditionSet() apis.ConditionSet {
+	return cipCondSet
+}
+
+// IsReady returns if the ImagePolicy was compiled successfully to
+// ConfigMap.
+func (c *ImagePolicy) IsReady() bool {
+	cs := c.Status
+	return cs.ObservedGeneration == c.Generation &&
+		cs.GetCondition(ClusterImagePolicyConditionReady).IsTrue()
+}
+
+// IsFailed returns true if the resource has observed
+// the latest generation and ready is false.
+func (c *ImagePolicy) IsFailed() bool {
+	cs := c.Status
+	return cs.ObservedGeneration == c.Generation &&
+		cs.GetCondition(ClusterImagePolicyConditionReady).IsFalse()
+}
@@ -0,0 +1,78 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// ImagePolicy defines the images that go through verification and the
+// authorities used for verification, for workloads in the same namespace as
+// the ImagePolicy. It has the same shape as a ClusterImagePolicy, and is
+// evaluated in addition to (never instead of) any matching
+// ClusterImagePolicies, so it can only ever further restrict what gets
+// admitted into its namespace. Since it is owned by the namespace tenants,
+// it can not reference Secrets, ConfigMaps, remote policies or KMS keys,
+// which the policy-controller would access on their behalf, nor select
+// namespaces.
+//
+// +genclient
+// +genreconciler:krshapedlogic=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ImagePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the ImagePolicy (from the client).
+	Spec ClusterImagePolicySpec `json:"spec"`
+
+	// Status represents the current state of the ImagePolicy.
+	// This data may be out of date.
+	// +optional
+	Status ClusterImagePolicyStatus `json:"status,omitempty"`
+}
+
+var (
+	_ apis.Validatable   = (*ImagePolicy)(nil)
+	_ apis.Defaultable   = (*ImagePolicy)(nil)
+	_ kmeta.OwnerRefable = (*ImagePolicy)(nil)
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*ImagePolicy)(nil)
+)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (c *ImagePolicy) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("ImagePolicy")
+}
+
+// GetStatus retrieves the status of the ImagePolicy.
+// Implements the KRShaped interface.
+func (c *ImagePolicy) GetStatus() *duckv1.Status {
+	return &c.Status.Status
+}
+
+// ImagePolicyList is a list of ImagePolicy resources
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ImagePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []ImagePolicy `json:"items"`
+}
@@ -0,0 +1,98 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (c *ImagePolicy) Validate(ctx context.Context) *apis.FieldError {
+	// If we're doing status updates, do not validate the spec.
+	if apis.IsInStatusUpdate(ctx) {
+		return nil
+	}
+	errs := c.Spec.Validate(ctx)
+	errs = errs.Also(validateNamespacedSpec(&c.Spec))
+	return errs.ViaField("spec")
+}
+
+// validateNamespacedSpec checks that the spec does not make use of anything
+// that belongs to the policy-controller. ImagePolicies are owned by the
+// namespace tenants, so they must not be able to read Secrets or ConfigMaps
+// that belong to the cluster administrators, to have the policy-controller
+// fetch remote policies from its own network position, or to resolve KMS
+// keys with its own cloud credentials.
+func validateNamespacedSpec(spec *ClusterImagePolicySpec) *apis.FieldError {
+	var errs *apis.FieldError
+	for i, authority := range spec.Authorities {
+		if authority.Key != nil {
+			errs = errs.Also(validateNamespacedKey(authority.Key).ViaField("key").ViaFieldIndex("authorities", i))
+		}
+		if authority.Keyless != nil && authority.Keyless.CACert != nil {
+			errs = errs.Also(validateNamespacedKey(authority.Keyless.CACert).ViaField("ca-cert").ViaField("keyless").ViaFieldIndex("authorities", i))
+		}
+		if authority.Certificate != nil {
+			if authority.Certificate.SecretRef != nil {
+				errs = errs.Also(apis.ErrDisallowedFields("secretRef").ViaField("certificate").ViaFieldIndex("authorities", i))
+			}
+			if authority.Certificate.CRL != nil && authority.Certificate.CRL.SecretRef != nil {
+				errs = errs.Also(apis.ErrDisallowedFields("secretRef").ViaField("crl").ViaField("certificate").ViaFieldIndex("authorities", i))
+			}
+		}
+		if authority.Notation != nil && authority.Notation.SecretRef != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("secretRef").ViaField("notation").ViaFieldIndex("authorities", i))
+		}
+		for j, att := range authority.Attestations {
+			if att.Policy != nil {
+				errs = errs.Also(validateNamespacedPolicy(att.Policy).ViaField("policy").ViaFieldIndex("attestations", j).ViaFieldIndex("authorities", i))
+			}
+		}
+	}
+	if spec.Policy != nil {
+		errs = errs.Also(validateNamespacedPolicy(spec.Policy).ViaField("policy"))
+	}
+	// ImagePolicies only apply to their own namespace.
+	for i, match := range spec.Match {
+		if match.NamespaceSelector != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("namespaceSelector").ViaFieldIndex("match", i))
+		}
+	}
+	return errs
+}
+
+func validateNamespacedKey(key *KeyRef) *apis.FieldError {
+	var errs *apis.FieldError
+	if key.SecretRef != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("secretRef"))
+	}
+	if key.KMS != "" {
+		errs = errs.Also(apis.ErrDisallowedFields("kms"))
+	}
+	return errs
+}
+
+func validateNamespacedPolicy(policy *Policy) *apis.FieldError {
+	var errs *apis.FieldError
+	if policy.ConfigMapRef != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("configMapRef"))
+	}
+	if policy.Remote != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("remote"))
+	}
+	return errs
+}
@@ -0,0 +1,177 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestImagePolicyValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		errorString string
+		policy      ImagePolicy
+	}{{
+		name: "Should pass with inline key",
+		policy: ImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{{Glob: "*"}},
+				Authorities: []Authority{{
+					Key: &KeyRef{Data: validPublicKey},
+				}},
+			},
+		},
+	}, {
+		name:        "Should fail with key secretRef",
+		errorString: "must not set the field(s): spec.authorities[0].key.secretRef",
+		policy: ImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{{Glob: "*"}},
+				Authorities: []Authority{{
+					Key: &KeyRef{SecretRef: &v1.SecretReference{Name: "key"}},
+				}},
+			},
+		},
+	}, {
+		name:        "Should fail with keyless ca-cert secretRef",
+		errorString: "must not set the field(s): spec.authorities[0].keyless.ca-cert.secretRef",
+		policy: ImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{{Glob: "*"}},
+				Authorities: []Authority{{
+					Keyless: &KeylessRef{
+						CACert:     &KeyRef{SecretRef: &v1.SecretReference{Name: "ca"}},
+						Identities: []Identity{{Issuer: "issuer", Subject: "subject"}},
+					},
+				}},
+			},
+		},
+	}, {
+		name:        "Should fail with certificate secretRefs",
+		errorString: "must not set the field(s): spec.authorities[0].certificate.crl.secretRef, spec.authorities[0].certificate.secretRef",
+		policy: ImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{{Glob: "*"}},
+				Authorities: []Authority{{
+					Certificate: &CertificateRef{
+						SecretRef: &v1.SecretReference{Name: "ca"},
+						CRL:       &CRLRef{SecretRef: &v1.SecretReference{Name: "crl"}},
+					},
+				}},
+			},
+		},
+	}, {
+		name:        "Should fail with notation secretRef",
+		errorString: "must not set the field(s): spec.authorities[0].notation.secretRef",
+		policy: ImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{{Glob: "*"}},
+				Authorities: []Authority{{
+					Notation: &NotationRef{SecretRef: &v1.SecretReference{Name: "truststore"}},
+				}},
+			},
+		},
+	}, {
+		name:        "Should fail with policy configMapRef",
+		errorString: "must not set the field(s): spec.policy.configMapRef",
+		policy: ImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{{Glob: "*"}},
+				Authorities: []Authority{{
+					Key: &KeyRef{Data: validPublicKey},
+				}},
+				Policy: &Policy{
+					Type:         "cue",
+					ConfigMapRef: &ConfigMapReference{Name: "policy", Key: "policy"},
+				},
+			},
+		},
+	}, {
+		name:        "Should fail with KMS keys",
+		errorString: "must not set the field(s): spec.authorities[0].key.kms, spec.authorities[1].keyless.ca-cert.kms",
+		policy: ImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{{Glob: "*"}},
+				Authorities: []Authority{{
+					Key: &KeyRef{KMS: "gcpkms://projects/project/locations/global/keyRings/ring/cryptoKeys/key"},
+				}, {
+					Keyless: &KeylessRef{
+						CACert:     &KeyRef{KMS: "gcpkms://projects/project/locations/global/keyRings/ring/cryptoKeys/key"},
+						Identities: []Identity{{Issuer: "issuer", Subject: "subject"}},
+					},
+				}},
+			},
+		},
+	}, {
+		name:        "Should fail with remote policies",
+		errorString: "must not set the field(s): spec.authorities[0].attestations[0].policy.remote, spec.policy.remote",
+		policy: ImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{{Glob: "*"}},
+				Authorities: []Authority{{
+					Key: &KeyRef{Data: validPublicKey},
+					Attestations: []Attestation{{
+						Name:          "attestation",
+						PredicateType: "https://cosign.sigstore.dev/attestation/v1",
+						Policy: &Policy{
+							Type:   "cue",
+							Remote: &RemotePolicy{URL: *apis.HTTPS("example.com"), Sha256sum: "123123123"},
+						},
+					}},
+				}},
+				Policy: &Policy{
+					Type:   "cue",
+					Remote: &RemotePolicy{URL: *apis.HTTPS("example.com"), Sha256sum: "123123123"},
+				},
+			},
+		},
+	}, {
+		name:        "Should fail with namespaceSelector",
+		errorString: "must not set the field(s): spec.match[0].namespaceSelector",
+		policy: ImagePolicy{
+			Spec: ClusterImagePolicySpec{
+				Images: []ImagePattern{{Glob: "*"}},
+				Authorities: []Authority{{
+					Key: &KeyRef{Data: validPublicKey},
+				}},
+				Match: []MatchResource{{
+					GroupVersionResource: metav1.GroupVersionResource{Version: "v1", Resource: "pods"},
+					NamespaceSelector:    &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				}},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.policy.Validate(context.TODO())
+			if test.errorString != "" {
+				if err == nil {
+					t.Fatalf("Validate() = nil, wanted %q", test.errorString)
+				}
+				if err.Error() != test.errorString {
+					t.Errorf("Validate() = %q, wanted %q", err.Error(), test.errorString)
+				}
+			} else if err != nil {
+				t.Errorf("Validate() = %v, wanted nil", err)
+			}
+		})
+	}
+}
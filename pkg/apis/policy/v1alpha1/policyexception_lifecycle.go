@@ -0,0 +1,77 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"knative.dev/pkg/apis"
+)
+
+const expiredReason = "Expired"
+
+var peCondSet = apis.NewLivingConditionSet(
+	PolicyExceptionConditionActive,
+	PolicyExceptionConditionCMUpdated,
+)
+
+// GetConditionSet retrieves the condition set for this resource.
+// Implements the KRShaped interface.
+func (*PolicyException) GetConditionSet() apis.ConditionSet {
+	return peCondSet
+}
+
+// IsReady returns if the PolicyException is in effect.
+func (e *PolicyException) IsReady() bool {
+	es := e.Status
+	return es.ObservedGeneration == e.Generation &&
+		es.GetCondition(PolicyExceptionConditionReady).IsTrue()
+}
+
+// IsFailed returns true if the resource has observed
+// the latest generation and ready is false.
+func (e *PolicyException) IsFailed() bool {
+	es := e.Status
+	return es.ObservedGeneration == e.Generation &&
+		es.GetCondition(PolicyExceptionConditionReady).IsFalse()
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (es *PolicyExceptionStatus) InitializeConditions() {
+	peCondSet.Manage(es).InitializeConditions()
+}
+
+// MarkActive marks the status saying that the PolicyException has not
+// expired yet.
+func (es *PolicyExceptionStatus) MarkActive() {
+	peCondSet.Manage(es).MarkTrue(PolicyExceptionConditionActive)
+}
+
+// MarkExpired surfaces that the PolicyException has expired, and is no
+// longer honoured.
+func (es *PolicyExceptionStatus) MarkExpired(msg string) {
+	peCondSet.Manage(es).MarkFalse(PolicyExceptionConditionActive, expiredReason, msg)
+}
+
+// MarkCMUpdateFailed surfaces a failure that we were unable to reflect the
+// PolicyException into the compiled ConfigMap.
+func (es *PolicyExceptionStatus) MarkCMUpdateFailed(msg string) {
+	peCondSet.Manage(es).MarkFalse(PolicyExceptionConditionCMUpdated, updateCMFailedReason, msg)
+}
+
+// MarkCMUpdatedOK marks the status saying that the ConfigMap has been
+// updated.
+func (es *PolicyExceptionStatus) MarkCMUpdatedOK() {
+	peCondSet.Manage(es).MarkTrue(PolicyExceptionConditionCMUpdated)
+}
@@ -0,0 +1,119 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// PolicyException exempts specific images from specific
+// ClusterImagePolicies, optionally only in some namespaces, until it
+// expires. This is meant for time-bound exemptions, for example for a vendor
+// image that is not signed yet, without weakening the ClusterImagePolicy
+// for everyone else.
+//
+// +genclient
+// +genclient:nonNamespaced
+// +genreconciler:krshapedlogic=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PolicyException struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the PolicyException (from the client).
+	Spec PolicyExceptionSpec `json:"spec"`
+
+	// Status represents the current state of the PolicyException.
+	// This data may be out of date.
+	// +optional
+	Status PolicyExceptionStatus `json:"status,omitempty"`
+}
+
+var (
+	_ apis.Validatable   = (*PolicyException)(nil)
+	_ apis.Defaultable   = (*PolicyException)(nil)
+	_ kmeta.OwnerRefable = (*PolicyException)(nil)
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*PolicyException)(nil)
+)
+
+const (
+	// PolicyExceptionConditionReady is set when the PolicyException is in
+	// effect, that is it has not expired and has been compiled into the
+	// underlying ConfigMap properly.
+	PolicyExceptionConditionReady = apis.ConditionReady
+	// PolicyExceptionConditionActive is set to True while the
+	// PolicyException has not expired.
+	PolicyExceptionConditionActive apis.ConditionType = "Active"
+	// PolicyExceptionConditionCMUpdated is set to True when the
+	// PolicyException has been successfully added to the ConfigMap holding
+	// all the PolicyExceptions.
+	PolicyExceptionConditionCMUpdated apis.ConditionType = "ConfigMapUpdated"
+)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (e *PolicyException) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("PolicyException")
+}
+
+// PolicyExceptionSpec defines which images are exempted from which
+// ClusterImagePolicies, where, until when and why.
+type PolicyExceptionSpec struct {
+	// Images defines the patterns of image names that are exempted. Use a
+	// digest to only exempt a particular build of an image.
+	Images []ImagePattern `json:"images"`
+	// Namespaces where the images are exempted. If empty, the images are
+	// exempted in all namespaces.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Policies are the names of the ClusterImagePolicies the images are
+	// exempted from.
+	Policies []string `json:"policies"`
+	// Expires is when the exception stops being honoured.
+	Expires metav1.Time `json:"expires"`
+	// Justification explains why the exception is needed, for the benefit
+	// of whoever audits it.
+	Justification string `json:"justification"`
+}
+
+// PolicyExceptionStatus represents the current state of a
+// PolicyException.
+type PolicyExceptionStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Broker that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.Status `json:",inline"`
+}
+
+// GetStatus retrieves the status of the PolicyException.
+// Implements the KRShaped interface.
+func (e *PolicyException) GetStatus() *duckv1.Status {
+	return &e.Status.Status
+}
+
+// PolicyExceptionList is a list of PolicyException resources
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type PolicyExceptionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []PolicyException `json:"items"`
+}
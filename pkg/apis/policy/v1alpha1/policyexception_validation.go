@@ -0,0 +1,69 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (e *PolicyException) Validate(ctx context.Context) *apis.FieldError {
+	// If we're doing status updates, do not validate the spec.
+	if apis.IsInStatusUpdate(ctx) {
+		return nil
+	}
+	return e.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (spec *PolicyExceptionSpec) Validate(ctx context.Context) (errors *apis.FieldError) {
+	if len(spec.Images) == 0 {
+		errors = errors.Also(apis.ErrMissingField("images"))
+	}
+	for i, image := range spec.Images {
+		errors = errors.Also(image.Validate(ctx).ViaFieldIndex("images", i))
+	}
+	for i, ns := range spec.Namespaces {
+		if msgs := validation.IsDNS1123Label(ns); len(msgs) > 0 {
+			fe := apis.ErrInvalidArrayValue(ns, "namespaces", i)
+			fe.Details = strings.Join(msgs, ", ")
+			errors = errors.Also(fe)
+		}
+	}
+	if len(spec.Policies) == 0 {
+		errors = errors.Also(apis.ErrMissingField("policies"))
+	}
+	for i, policy := range spec.Policies {
+		if policy == "" {
+			errors = errors.Also(apis.ErrInvalidArrayValue(policy, "policies", i))
+		}
+	}
+	if spec.Expires.IsZero() {
+		errors = errors.Also(apis.ErrMissingField("expires"))
+	} else if apis.IsInCreate(ctx) && !spec.Expires.After(time.Now()) {
+		// Allow updating exceptions that have expired since, for example to
+		// remove the finalizer, but there's no point in creating one.
+		errors = errors.Also(apis.ErrInvalidValue(spec.Expires.String(), "expires", "must be in the future"))
+	}
+	if spec.Justification == "" {
+		errors = errors.Also(apis.ErrMissingField("justification"))
+	}
+	return
+}
@@ -0,0 +1,112 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+func TestPolicyExceptionValidation(t *testing.T) {
+	future := metav1.NewTime(time.Now().Add(time.Hour))
+	past := metav1.NewTime(time.Now().Add(-time.Hour))
+	validSpec := func() PolicyExceptionSpec {
+		return PolicyExceptionSpec{
+			Images:        []ImagePattern{{Glob: "registry.example.com/vendor/*"}},
+			Namespaces:    []string{"vendor"},
+			Policies:      []string{"signed-images"},
+			Expires:       future,
+			Justification: "Vendor is working on signing their images, TICKET-123",
+		}
+	}
+	tests := []struct {
+		name        string
+		create      bool
+		errorString string
+		spec        func(*PolicyExceptionSpec)
+	}{{
+		name: "Should pass",
+	}, {
+		name:   "Should pass on create",
+		create: true,
+	}, {
+		name: "Should pass expired on update",
+		spec: func(spec *PolicyExceptionSpec) { spec.Expires = past },
+	}, {
+		name:        "Should fail expired on create",
+		create:      true,
+		errorString: "spec.expires",
+		spec:        func(spec *PolicyExceptionSpec) { spec.Expires = past },
+	}, {
+		name:        "Should fail without images",
+		errorString: "missing field(s): spec.images",
+		spec:        func(spec *PolicyExceptionSpec) { spec.Images = nil },
+	}, {
+		name:        "Should fail with invalid glob",
+		errorString: "spec.images[0].glob",
+		spec:        func(spec *PolicyExceptionSpec) { spec.Images = []ImagePattern{{Glob: "["}} },
+	}, {
+		name: "Should pass with regex",
+		spec: func(spec *PolicyExceptionSpec) {
+			spec.Images = []ImagePattern{{Regex: `registry\.example\.com/vendor/[^/]+`}}
+		},
+	}, {
+		name:        "Should fail with invalid namespace",
+		errorString: "invalid value: not_a_namespace: spec.namespaces[0]",
+		spec:        func(spec *PolicyExceptionSpec) { spec.Namespaces = []string{"not_a_namespace"} },
+	}, {
+		name:        "Should fail without policies",
+		errorString: "missing field(s): spec.policies",
+		spec:        func(spec *PolicyExceptionSpec) { spec.Policies = nil },
+	}, {
+		name:        "Should fail without expires",
+		errorString: "missing field(s): spec.expires",
+		spec:        func(spec *PolicyExceptionSpec) { spec.Expires = metav1.Time{} },
+	}, {
+		name:        "Should fail without justification",
+		errorString: "missing field(s): spec.justification",
+		spec:        func(spec *PolicyExceptionSpec) { spec.Justification = "" },
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pe := PolicyException{Spec: validSpec()}
+			if test.spec != nil {
+				test.spec(&pe.Spec)
+			}
+			ctx := context.TODO()
+			if test.create {
+				ctx = apis.WithinCreate(ctx)
+			}
+			err := pe.Validate(ctx)
+			if test.errorString != "" {
+				if err == nil {
+					t.Fatalf("Validate() = nil, wanted %q", test.errorString)
+				}
+				if !strings.Contains(err.Error(), test.errorString) {
+					t.Errorf("Validate() = %q, wanted %q", err.Error(), test.errorString)
+				}
+			} else if err != nil {
+				t.Errorf("Validate() = %v, wanted nil", err)
+			}
+		})
+	}
+}
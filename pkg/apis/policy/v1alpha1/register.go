@@ -47,6 +47,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&ClusterImagePolicy{},
 		&ClusterImagePolicyList{},
+		&ImagePolicy{},
+		&ImagePolicyList{},
+		&PolicyException{},
+		&PolicyExceptionList{},
+		&Revocation{},
+		&RevocationList{},
 		&TrustRoot{},
 		&TrustRootList{},
 	)
@@ -0,0 +1,62 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"knative.dev/pkg/apis"
+)
+
+var rvCondSet = apis.NewLivingConditionSet(
+	RevocationConditionCMUpdated,
+)
+
+// GetConditionSet retrieves the condition set for this resource.
+// Implements the KRShaped interface.
+func (*Revocation) GetConditionSet() apis.ConditionSet {
+	return rvCondSet
+}
+
+// IsReady returns if the Revocation is in effect.
+func (r *Revocation) IsReady() bool {
+	rs := r.Status
+	return rs.ObservedGeneration == r.Generation &&
+		rs.GetCondition(RevocationConditionReady).IsTrue()
+}
+
+// IsFailed returns true if the resource has observed
+// the latest generation and ready is false.
+func (r *Revocation) IsFailed() bool {
+	rs := r.Status
+	return rs.ObservedGeneration == r.Generation &&
+		rs.GetCondition(RevocationConditionReady).IsFalse()
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (rs *RevocationStatus) InitializeConditions() {
+	rvCondSet.Manage(rs).InitializeConditions()
+}
+
+// MarkCMUpdateFailed surfaces a failure that we were unable to reflect the
+// Revocation into the compiled ConfigMap.
+func (rs *RevocationStatus) MarkCMUpdateFailed(msg string) {
+	rvCondSet.Manage(rs).MarkFalse(RevocationConditionCMUpdated, updateCMFailedReason, msg)
+}
+
+// MarkCMUpdatedOK marks the status saying that the ConfigMap has been
+// updated.
+func (rs *RevocationStatus) MarkCMUpdatedOK() {
+	rvCondSet.Manage(rs).MarkTrue(RevocationConditionCMUpdated)
+}
@@ -0,0 +1,137 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// Revocation revokes signing keys, Fulcio identities, certificates or
+// individual signatures and attestations across all the
+// ClusterImagePolicies and ImagePolicies, for example when a CI identity or
+// a signing key has been compromised. Signatures and attestations that
+// match a Revocation are discarded, whichever authority verified them.
+//
+// +genclient
+// +genclient:nonNamespaced
+// +genreconciler:krshapedlogic=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Revocation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec holds the desired state of the Revocation (from the client).
+	Spec RevocationSpec `json:"spec"`
+
+	// Status represents the current state of the Revocation.
+	// This data may be out of date.
+	// +optional
+	Status RevocationStatus `json:"status,omitempty"`
+}
+
+var (
+	_ apis.Validatable   = (*Revocation)(nil)
+	_ apis.Defaultable   = (*Revocation)(nil)
+	_ kmeta.OwnerRefable = (*Revocation)(nil)
+	// Check that the type conforms to the duck Knative Resource shape.
+	_ duckv1.KRShaped = (*Revocation)(nil)
+)
+
+const (
+	// RevocationConditionReady is set when the Revocation is in effect,
+	// that is it has been compiled into the underlying ConfigMap properly.
+	RevocationConditionReady = apis.ConditionReady
+	// RevocationConditionCMUpdated is set to True when the Revocation has
+	// been successfully added to the ConfigMap holding all the Revocations.
+	RevocationConditionCMUpdated apis.ConditionType = "ConfigMapUpdated"
+)
+
+// GetGroupVersionKind implements kmeta.OwnerRefable
+func (r *Revocation) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("Revocation")
+}
+
+// RevocationSpec defines what is revoked, and since when. At least one of
+// KeyFingerprints, Identities, CertificateSerials or Digests must be
+// specified.
+type RevocationSpec struct {
+	// KeyFingerprints are the fingerprints of the revoked public keys, that
+	// is the hex encoded SHA-256 digest of the DER encoded public key. They
+	// match signatures verified with the key, and signatures whose
+	// certificate holds the key.
+	// +optional
+	KeyFingerprints []string `json:"keyFingerprints,omitempty"`
+	// Identities are the revoked Fulcio identities.
+	// +optional
+	Identities []RevokedIdentity `json:"identities,omitempty"`
+	// CertificateSerials are the hex encoded serial numbers of the revoked
+	// signing certificates, optionally separated by colons.
+	// +optional
+	CertificateSerials []string `json:"certificateSerials,omitempty"`
+	// Digests are the digests of the revoked signatures or attestations,
+	// that is of the OCI layers holding them, for example
+	// sha256:a1e82f6a5f6dfc735165d3442e7cc5a615f72abac3db19452481f5f3c90fbfa8.
+	// +optional
+	Digests []string `json:"digests,omitempty"`
+	// CompromisedAt is when the revoked keys, identities, certificates or
+	// signatures were compromised. If set, signatures made before it, as
+	// attested by their RFC3161 timestamp or Rekor integrated time, are not
+	// revoked. If not set, all the matching signatures are revoked.
+	// +optional
+	CompromisedAt *metav1.Time `json:"compromisedAt,omitempty"`
+	// Reason explains why the signatures are revoked, for the benefit of
+	// whoever audits it.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
+// RevokedIdentity is a Fulcio identity, that is the OIDC issuer and the
+// subject of a Fulcio certificate.
+type RevokedIdentity struct {
+	// Issuer is the OIDC issuer of the identity.
+	Issuer string `json:"issuer"`
+	// Subject is the subject of the identity, for example an email address
+	// or the URI of a CI workflow.
+	Subject string `json:"subject"`
+}
+
+// RevocationStatus represents the current state of a Revocation.
+type RevocationStatus struct {
+	// inherits duck/v1 Status, which currently provides:
+	// * ObservedGeneration - the 'Generation' of the Broker that was last processed by the controller.
+	// * Conditions - the latest available observations of a resource's current state.
+	duckv1.Status `json:",inline"`
+}
+
+// GetStatus retrieves the status of the Revocation.
+// Implements the KRShaped interface.
+func (r *Revocation) GetStatus() *duckv1.Status {
+	return &r.Status.Status
+}
+
+// RevocationList is a list of Revocation resources
+//
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type RevocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Revocation `json:"items"`
+}
@@ -0,0 +1,74 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/common"
+	"knative.dev/pkg/apis"
+)
+
+// Validate implements apis.Validatable
+func (r *Revocation) Validate(ctx context.Context) *apis.FieldError {
+	// If we're doing status updates, do not validate the spec.
+	if apis.IsInStatusUpdate(ctx) {
+		return nil
+	}
+	return r.Spec.Validate(ctx).ViaField("spec")
+}
+
+func (spec *RevocationSpec) Validate(_ context.Context) (errors *apis.FieldError) {
+	if len(spec.KeyFingerprints) == 0 && len(spec.Identities) == 0 && len(spec.CertificateSerials) == 0 && len(spec.Digests) == 0 {
+		errors = errors.Also(apis.ErrMissingOneOf("certificateSerials", "digests", "identities", "keyFingerprints"))
+	}
+	for i, fingerprint := range spec.KeyFingerprints {
+		if _, err := common.NormalizeKeyFingerprint(fingerprint); err != nil {
+			fe := apis.ErrInvalidArrayValue(fingerprint, "keyFingerprints", i)
+			fe.Details = err.Error()
+			errors = errors.Also(fe)
+		}
+	}
+	for i, identity := range spec.Identities {
+		errors = errors.Also(identity.Validate().ViaFieldIndex("identities", i))
+	}
+	for i, serial := range spec.CertificateSerials {
+		if _, err := common.NormalizeCertificateSerial(serial); err != nil {
+			fe := apis.ErrInvalidArrayValue(serial, "certificateSerials", i)
+			fe.Details = err.Error()
+			errors = errors.Also(fe)
+		}
+	}
+	for i, digest := range spec.Digests {
+		if _, err := v1.NewHash(digest); err != nil {
+			fe := apis.ErrInvalidArrayValue(digest, "digests", i)
+			fe.Details = err.Error()
+			errors = errors.Also(fe)
+		}
+	}
+	return
+}
+
+func (identity *RevokedIdentity) Validate() (errors *apis.FieldError) {
+	if identity.Issuer == "" {
+		errors = errors.Also(apis.ErrMissingField("issuer"))
+	}
+	if identity.Subject == "" {
+		errors = errors.Also(apis.ErrMissingField("subject"))
+	}
+	return
+}
@@ -0,0 +1,83 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRevocationValidation(t *testing.T) {
+	validSpec := func() RevocationSpec {
+		return RevocationSpec{
+			KeyFingerprints:    []string{"2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+			Identities:         []RevokedIdentity{{Issuer: "https://token.actions.githubusercontent.com", Subject: "https://github.com/example/repo/.github/workflows/release.yaml@refs/heads/main"}},
+			CertificateSerials: []string{"54:66:7a:4d:08:18:b8:b8"},
+			Digests:            []string{"sha256:a1e82f6a5f6dfc735165d3442e7cc5a615f72abac3db19452481f5f3c90fbfa8"},
+			Reason:             "CI credentials leaked, INCIDENT-123",
+		}
+	}
+	tests := []struct {
+		name        string
+		errorString string
+		spec        func(*RevocationSpec)
+	}{{
+		name: "Should pass",
+	}, {
+		name:        "Should fail without anything to revoke",
+		errorString: "expected exactly one, got neither: spec.certificateSerials, spec.digests, spec.identities, spec.keyFingerprints",
+		spec:        func(spec *RevocationSpec) { *spec = RevocationSpec{Reason: "nothing"} },
+	}, {
+		name:        "Should fail with invalid fingerprint",
+		errorString: "invalid value: abcd: spec.keyFingerprints[0]",
+		spec:        func(spec *RevocationSpec) { spec.KeyFingerprints = []string{"abcd"} },
+	}, {
+		name:        "Should fail with incomplete identity",
+		errorString: "missing field(s): spec.identities[0].subject",
+		spec: func(spec *RevocationSpec) {
+			spec.Identities = []RevokedIdentity{{Issuer: "https://accounts.google.com"}}
+		},
+	}, {
+		name:        "Should fail with invalid serial",
+		errorString: "invalid value: xyz: spec.certificateSerials[0]",
+		spec:        func(spec *RevocationSpec) { spec.CertificateSerials = []string{"xyz"} },
+	}, {
+		name:        "Should fail with invalid digest",
+		errorString: "invalid value: sha256:abcd: spec.digests[0]",
+		spec:        func(spec *RevocationSpec) { spec.Digests = []string{"sha256:abcd"} },
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rv := Revocation{Spec: validSpec()}
+			if test.spec != nil {
+				test.spec(&rv.Spec)
+			}
+			err := rv.Validate(context.TODO())
+			if test.errorString != "" {
+				if err == nil {
+					t.Fatalf("Validate() = nil, wanted %q", test.errorString)
+				}
+				if !strings.Contains(err.Error(), test.errorString) {
+					t.Errorf("Validate() = %q, wanted %q", err.Error(), test.errorString)
+				}
+			} else if err != nil {
+				t.Errorf("Validate() = %v, wanted nil", err)
+			}
+		})
+	}
+}
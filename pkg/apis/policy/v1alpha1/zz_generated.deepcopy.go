@@ -34,6 +34,16 @@ func (in *Attestation) DeepCopyInto(out *Attestation) {
 		*out = new(Policy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SLSA != nil {
+		in, out := &in.SLSA, &out.SLSA
+		*out = new(SLSA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vulnerability != nil {
+		in, out := &in.Vulnerability, &out.Vulnerability
+		*out = new(Vulnerability)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -60,6 +70,16 @@ func (in *Authority) DeepCopyInto(out *Authority) {
 		*out = new(KeylessRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(CertificateRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notation != nil {
+		in, out := &in.Notation, &out.Notation
+		*out = new(NotationRef)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Static != nil {
 		in, out := &in.Static, &out.Static
 		*out = new(StaticRef)
@@ -102,6 +122,27 @@ func (in *Authority) DeepCopy() *Authority {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRLRef) DeepCopyInto(out *CRLRef) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRLRef.
+func (in *CRLRef) DeepCopy() *CRLRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CRLRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateAuthority) DeepCopyInto(out *CertificateAuthority) {
 	*out = *in
@@ -125,6 +166,47 @@ func (in *CertificateAuthority) DeepCopy() *CertificateAuthority {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRef) DeepCopyInto(out *CertificateRef) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.SubjectAlternativeNames != nil {
+		in, out := &in.SubjectAlternativeNames, &out.SubjectAlternativeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtKeyUsages != nil {
+		in, out := &in.ExtKeyUsages, &out.ExtKeyUsages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyOIDs != nil {
+		in, out := &in.PolicyOIDs, &out.PolicyOIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CRL != nil {
+		in, out := &in.CRL, &out.CRL
+		*out = new(CRLRef)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRef.
+func (in *CertificateRef) DeepCopy() *CertificateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterImagePolicy) DeepCopyInto(out *ClusterImagePolicy) {
 	*out = *in
@@ -192,7 +274,9 @@ func (in *ClusterImagePolicySpec) DeepCopyInto(out *ClusterImagePolicySpec) {
 	if in.Images != nil {
 		in, out := &in.Images, &out.Images
 		*out = make([]ImagePattern, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Authorities != nil {
 		in, out := &in.Authorities, &out.Authorities
@@ -213,6 +297,16 @@ func (in *ClusterImagePolicySpec) DeepCopyInto(out *ClusterImagePolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AuthorityThreshold != nil {
+		in, out := &in.AuthorityThreshold, &out.AuthorityThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VerifyPlatforms != nil {
+		in, out := &in.VerifyPlatforms, &out.VerifyPlatforms
+		*out = new(PlatformVerification)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -278,6 +372,20 @@ func (in *DistinguishedName) DeepCopy() *DistinguishedName {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Identity) DeepCopyInto(out *Identity) {
 	*out = *in
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtensionsRegExp != nil {
+		in, out := &in.ExtensionsRegExp, &out.ExtensionsRegExp
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -294,6 +402,11 @@ func (in *Identity) DeepCopy() *Identity {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePattern) DeepCopyInto(out *ImagePattern) {
 	*out = *in
+	if in.ExcludeGlob != nil {
+		in, out := &in.ExcludeGlob, &out.ExcludeGlob
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -307,6 +420,67 @@ func (in *ImagePattern) DeepCopy() *ImagePattern {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicy) DeepCopyInto(out *ImagePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicy.
+func (in *ImagePolicy) DeepCopy() *ImagePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImagePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicyList) DeepCopyInto(out *ImagePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImagePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicyList.
+func (in *ImagePolicyList) DeepCopy() *ImagePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImagePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeyRef) DeepCopyInto(out *KeyRef) {
 	*out = *in
@@ -315,6 +489,14 @@ func (in *KeyRef) DeepCopyInto(out *KeyRef) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.ValidFrom != nil {
+		in, out := &in.ValidFrom, &out.ValidFrom
+		*out = (*in).DeepCopy()
+	}
+	if in.ValidUntil != nil {
+		in, out := &in.ValidUntil, &out.ValidUntil
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -339,7 +521,9 @@ func (in *KeylessRef) DeepCopyInto(out *KeylessRef) {
 	if in.Identities != nil {
 		in, out := &in.Identities, &out.Identities
 		*out = make([]Identity, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.CACert != nil {
 		in, out := &in.CACert, &out.CACert
@@ -373,6 +557,16 @@ func (in *MatchResource) DeepCopyInto(out *MatchResource) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]Subject, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -386,6 +580,53 @@ func (in *MatchResource) DeepCopy() *MatchResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotationRef) DeepCopyInto(out *NotationRef) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.TrustedIdentities != nil {
+		in, out := &in.TrustedIdentities, &out.TrustedIdentities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotationRef.
+func (in *NotationRef) DeepCopy() *NotationRef {
+	if in == nil {
+		return nil
+	}
+	out := new(NotationRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformVerification) DeepCopyInto(out *PlatformVerification) {
+	*out = *in
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformVerification.
+func (in *PlatformVerification) DeepCopy() *PlatformVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Policy) DeepCopyInto(out *Policy) {
 	*out = *in
@@ -419,6 +660,11 @@ func (in *Policy) DeepCopyInto(out *Policy) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IncludeAttestationPayloads != nil {
+		in, out := &in.IncludeAttestationPayloads, &out.IncludeAttestationPayloads
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -432,6 +678,118 @@ func (in *Policy) DeepCopy() *Policy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyException) DeepCopyInto(out *PolicyException) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyException.
+func (in *PolicyException) DeepCopy() *PolicyException {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyException)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyException) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyExceptionList) DeepCopyInto(out *PolicyExceptionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PolicyException, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionList.
+func (in *PolicyExceptionList) DeepCopy() *PolicyExceptionList {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExceptionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolicyExceptionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyExceptionSpec) DeepCopyInto(out *PolicyExceptionSpec) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImagePattern, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Expires.DeepCopyInto(&out.Expires)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionSpec.
+func (in *PolicyExceptionSpec) DeepCopy() *PolicyExceptionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExceptionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolicyExceptionStatus) DeepCopyInto(out *PolicyExceptionStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolicyExceptionStatus.
+func (in *PolicyExceptionStatus) DeepCopy() *PolicyExceptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolicyExceptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RFC3161Timestamp) DeepCopyInto(out *RFC3161Timestamp) {
 	*out = *in
@@ -513,6 +871,140 @@ func (in *Repository) DeepCopy() *Repository {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Revocation) DeepCopyInto(out *Revocation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Revocation.
+func (in *Revocation) DeepCopy() *Revocation {
+	if in == nil {
+		return nil
+	}
+	out := new(Revocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Revocation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevocationList) DeepCopyInto(out *RevocationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Revocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevocationList.
+func (in *RevocationList) DeepCopy() *RevocationList {
+	if in == nil {
+		return nil
+	}
+	out := new(RevocationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RevocationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevocationSpec) DeepCopyInto(out *RevocationSpec) {
+	*out = *in
+	if in.KeyFingerprints != nil {
+		in, out := &in.KeyFingerprints, &out.KeyFingerprints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Identities != nil {
+		in, out := &in.Identities, &out.Identities
+		*out = make([]RevokedIdentity, len(*in))
+		copy(*out, *in)
+	}
+	if in.CertificateSerials != nil {
+		in, out := &in.CertificateSerials, &out.CertificateSerials
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Digests != nil {
+		in, out := &in.Digests, &out.Digests
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CompromisedAt != nil {
+		in, out := &in.CompromisedAt, &out.CompromisedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevocationSpec.
+func (in *RevocationSpec) DeepCopy() *RevocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RevocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevocationStatus) DeepCopyInto(out *RevocationStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevocationStatus.
+func (in *RevocationStatus) DeepCopy() *RevocationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RevocationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevokedIdentity) DeepCopyInto(out *RevokedIdentity) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevokedIdentity.
+func (in *RevokedIdentity) DeepCopy() *RevokedIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(RevokedIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SigstoreKeys) DeepCopyInto(out *SigstoreKeys) {
 	*out = *in
@@ -557,6 +1049,58 @@ func (in *SigstoreKeys) DeepCopy() *SigstoreKeys {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLSA) DeepCopyInto(out *SLSA) {
+	*out = *in
+	if in.Builders != nil {
+		in, out := &in.Builders, &out.Builders
+		*out = make([]SLSABuilder, len(*in))
+		copy(*out, *in)
+	}
+	if in.BuildTypes != nil {
+		in, out := &in.BuildTypes, &out.BuildTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceURIs != nil {
+		in, out := &in.SourceURIs, &out.SourceURIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceRefs != nil {
+		in, out := &in.SourceRefs, &out.SourceRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSA.
+func (in *SLSA) DeepCopy() *SLSA {
+	if in == nil {
+		return nil
+	}
+	out := new(SLSA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLSABuilder) DeepCopyInto(out *SLSABuilder) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSABuilder.
+func (in *SLSABuilder) DeepCopy() *SLSABuilder {
+	if in == nil {
+		return nil
+	}
+	out := new(SLSABuilder)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
@@ -599,6 +1143,22 @@ func (in *StaticRef) DeepCopy() *StaticRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Subject) DeepCopyInto(out *Subject) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subject.
+func (in *Subject) DeepCopy() *Subject {
+	if in == nil {
+		return nil
+	}
+	out := new(Subject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLog) DeepCopyInto(out *TLog) {
 	*out = *in
@@ -750,3 +1310,36 @@ func (in *TrustRootStatus) DeepCopy() *TrustRootStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
+	*out = *in
+	if in.MaxSeverityCounts != nil {
+		in, out := &in.MaxSeverityCounts, &out.MaxSeverityCounts
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IgnoredCVEs != nil {
+		in, out := &in.IgnoredCVEs, &out.IgnoredCVEs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxScanAge != nil {
+		in, out := &in.MaxScanAge, &out.MaxScanAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vulnerability.
+func (in *Vulnerability) DeepCopy() *Vulnerability {
+	if in == nil {
+		return nil
+	}
+	out := new(Vulnerability)
+	in.DeepCopyInto(out)
+	return out
+}
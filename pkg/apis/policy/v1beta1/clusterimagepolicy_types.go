@@ -97,6 +97,17 @@ type ClusterImagePolicySpec struct {
 	// Match allows selecting resources based on their properties.
 	// +optional
 	Match []MatchResource `json:"match,omitempty"`
+	// AuthorityThreshold is the number of Authorities that must be
+	// satisfied for the image to be admitted. If not specified, a single
+	// matching Authority is enough. Set it to the number of Authorities to
+	// require all of them to match.
+	// +optional
+	AuthorityThreshold *int32 `json:"authorityThreshold,omitempty"`
+	// VerifyPlatforms, if set, also requires the Authorities to be
+	// satisfied by the platform manifests of the image when it is an image
+	// index, rather than only by the index itself.
+	// +optional
+	VerifyPlatforms *PlatformVerification `json:"verifyPlatforms,omitempty"`
 }
 
 // ImagePattern defines a pattern and its associated authorties
@@ -104,7 +115,37 @@ type ClusterImagePolicySpec struct {
 // those authorities must be satisfied for the image to be admitted.
 type ImagePattern struct {
 	// Glob defines a globbing pattern.
-	Glob string `json:"glob"`
+	// +optional
+	Glob string `json:"glob,omitempty"`
+	// Regex defines a regular expression that the fully qualified image
+	// name (e.g. index.docker.io/library/busybox:latest) must match, for
+	// patterns that cannot be expressed as a Glob. Like a Glob, it must
+	// match the whole name, as if it was enclosed in ^ and $. Exactly one of
+	// Glob and Regex must be specified.
+	// +optional
+	Regex string `json:"regex,omitempty"`
+	// ExcludeGlob defines globbing patterns of image names that are
+	// excluded from this pattern even if they match its Glob or Regex.
+	// +optional
+	ExcludeGlob []string `json:"excludeGlob,omitempty"`
+}
+
+// PlatformVerification selects the platform manifests of an image index
+// that the Authorities must also be satisfied by.
+type PlatformVerification struct {
+	// Platforms restricts the verification to the platform manifests that
+	// satisfy one of these platforms, in os/arch[/variant] form, for example
+	// the platforms of the nodes of the cluster. Pods are not scheduled yet
+	// when they are admitted, so this is how to only verify the manifests
+	// the nodes will actually pull. If not specified, all the platform
+	// manifests of the index are verified.
+	// +optional
+	Platforms []string `json:"platforms,omitempty"`
+	// Attestations also requires the attestations of the Authorities that
+	// have any to be satisfied by each platform manifest. Otherwise only
+	// their signatures are verified on the platform manifests.
+	// +optional
+	Attestations bool `json:"attestations,omitempty"`
 }
 
 // The authorities block defines the rules for discovering and
@@ -126,6 +167,15 @@ type Authority struct {
 	// Keyless sets the configuration to verify the authority against a Fulcio instance.
 	// +optional
 	Keyless *KeylessRef `json:"keyless,omitempty"`
+	// Certificate sets the configuration to verify the authority against
+	// certificates issued by an X.509 certificate authority, for example an
+	// enterprise PKI, instead of Fulcio.
+	// +optional
+	Certificate *CertificateRef `json:"certificate,omitempty"`
+	// Notation sets the configuration to verify the authority against Notary
+	// Project signatures that are stored as OCI referrers of the image.
+	// +optional
+	Notation *NotationRef `json:"notation,omitempty"`
 	// Static specifies that signatures / attestations are not validated but
 	// instead a static policy is applied against matching images.
 	// +optional
@@ -166,6 +216,17 @@ type KeyRef struct {
 	// HashAlgorithm always defaults to sha256 if the algorithm hasn't been explicitly set
 	// +optional
 	HashAlgorithm string `json:"hashAlgorithm,omitempty"`
+	// ValidFrom is the time from which signatures made with the key are
+	// trusted. The signing time is taken from the RFC3161 timestamp or the
+	// Rekor integrated time of the signature, so a CTLog or RFC3161Timestamp
+	// must be configured on the authority.
+	// +optional
+	ValidFrom *metav1.Time `json:"validFrom,omitempty"`
+	// ValidUntil is the time until which signatures made with the key are
+	// trusted, for example when the key was rotated. Signatures made before
+	// it keep passing, but signatures made after it are rejected.
+	// +optional
+	ValidUntil *metav1.Time `json:"validUntil,omitempty"`
 }
 
 // StaticRef specifies that signatures / attestations are not validated but
@@ -205,6 +266,100 @@ type TLog struct {
 	TrustRootRef string `json:"trustRootRef,omitempty"`
 }
 
+// CertificateRef verifies signatures made with certificates issued by an
+// X.509 certificate authority instead of Fulcio. Neither a transparency log
+// nor an SCT is required, so verification works offline.
+// A CertificateRef must specify only one of CABundle, SecretRef or
+// TrustRootRef.
+type CertificateRef struct {
+	// CABundle contains the inline PEM encoded root and intermediate
+	// certificates of the certificate authority.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+	// SecretRef sets a reference to a secret with the PEM encoded root and
+	// intermediate certificates of the certificate authority.
+	// +optional
+	SecretRef *v1.SecretReference `json:"secretRef,omitempty"`
+	// Use the Certificate Chain from the referred TrustRoot.CertificateAuthorities
+	// +optional
+	TrustRootRef string `json:"trustRootRef,omitempty"`
+	// SubjectAlternativeNames are the email addresses, URIs, DNS names or IP
+	// addresses that the signing certificate may be issued to. The
+	// certificate must have at least one of them. If empty, any subject
+	// alternative name is allowed.
+	// +optional
+	SubjectAlternativeNames []string `json:"subjectAlternativeNames,omitempty"`
+	// ExtKeyUsages are the extended key usages that the signing certificate
+	// must have, either by name (serverAuth, clientAuth, codeSigning,
+	// emailProtection, timeStamping, ocspSigning or any) or by OID.
+	// codeSigning is always required.
+	// +optional
+	ExtKeyUsages []string `json:"extKeyUsages,omitempty"`
+	// Issuer is the distinguished name of the certificate authority that
+	// must have issued the signing certificate, in RFC 2253 form, for example
+	// CN=Example Code Signing CA,O=Example Corp,C=US.
+	// +optional
+	Issuer string `json:"issuer,omitempty"`
+	// PolicyOIDs are the certificate policy OIDs that the signing
+	// certificate must assert.
+	// +optional
+	PolicyOIDs []string `json:"policyOIDs,omitempty"`
+	// CRL sets the certificate revocation lists that the signing certificate
+	// is checked against.
+	// +optional
+	CRL *CRLRef `json:"crl,omitempty"`
+}
+
+// CRLRef contains the certificate revocation lists of a certificate
+// authority, inline or in a secret. A CRLRef must specify only one of Data
+// or SecretRef.
+// The revocation list of the issuer of the signing certificate must be
+// included, signed by that issuer and not past its next update, otherwise
+// the signature is rejected.
+type CRLRef struct {
+	// Data contains the inline PEM encoded certificate revocation lists.
+	// +optional
+	Data string `json:"data,omitempty"`
+	// SecretRef sets a reference to a secret with the PEM encoded
+	// certificate revocation lists.
+	// +optional
+	SecretRef *v1.SecretReference `json:"secretRef,omitempty"`
+}
+
+// NotationRef verifies Notary Project signatures, in JWS or COSE envelopes,
+// that are stored as OCI referrers of the image. The trust store holds the
+// root certificates that the signing certificate must chain to, and the
+// trusted identities are the trust policy.
+// A NotationRef must specify only one of CABundle, SecretRef or
+// TrustRootRef.
+type NotationRef struct {
+	// CABundle contains the inline PEM encoded root certificates of the
+	// trust store.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+	// SecretRef sets a reference to a secret with the PEM encoded root
+	// certificates of the trust store.
+	// +optional
+	SecretRef *v1.SecretReference `json:"secretRef,omitempty"`
+	// Use the Certificate Chain from the referred TrustRoot.CertificateAuthorities
+	// +optional
+	TrustRootRef string `json:"trustRootRef,omitempty"`
+	// TrustStoreType is the type of the trust store, either ca for
+	// signatures of the notary.x509 signing scheme, or signingAuthority for
+	// signatures of the notary.x509.signingAuthority signing scheme. If not
+	// specified, the default is ca.
+	// +optional
+	TrustStoreType string `json:"trustStoreType,omitempty"`
+	// TrustedIdentities are the signing certificates that are trusted, either
+	// "*" for any certificate that chains to the trust store, or x509.subject
+	// followed by a colon and the distinguished name that the subject of the
+	// certificate must contain, for example
+	// "x509.subject: C=US, ST=WA, O=Example Corp". If empty, any certificate
+	// that chains to the trust store is trusted.
+	// +optional
+	TrustedIdentities []string `json:"trustedIdentities,omitempty"`
+}
+
 // KeylessRef contains location of the validating certificate and the identities
 // against which to verify. KeylessRef will contain either the URL to the verifying
 // certificate, or it will contain the certificate data inline or in a secret.
@@ -234,11 +389,80 @@ type Attestation struct {
 	// policy.
 	Name string `json:"name"`
 	// PredicateType defines which predicate type to verify. Matches cosign verify-attestation options.
-	PredicateType string `json:"predicateType"`
+	// It can be omitted when SLSA is set.
+	// +optional
+	PredicateType string `json:"predicateType,omitempty"`
 	// Policy defines all of the matching signatures, and all of
 	// the matching attestations (whose attestations are verified).
 	// +optional
 	Policy *Policy `json:"policy,omitempty"`
+	// SLSA defines the checks to run against a SLSA provenance attestation,
+	// either alongside or instead of Policy. Attestations with either the
+	// v0.2 or the v1 SLSA provenance predicate type are checked, whichever
+	// PredicateType is set to.
+	// +optional
+	SLSA *SLSA `json:"slsa,omitempty"`
+	// Vulnerability defines the vulnerabilities that a cosign vuln
+	// attestation is allowed to report, either alongside or instead of
+	// Policy.
+	// +optional
+	Vulnerability *Vulnerability `json:"vulnerability,omitempty"`
+}
+
+// SLSA specifies the builders, build types and sources that a SLSA provenance
+// attestation is allowed to have.
+type SLSA struct {
+	// Builders that are allowed to have produced the provenance.
+	Builders []SLSABuilder `json:"builders"`
+	// BuildTypes that are allowed. If empty, any build type is allowed.
+	// +optional
+	BuildTypes []string `json:"buildTypes,omitempty"`
+	// SourceURIs of the repositories that the image is allowed to be built
+	// from, for example git+https://github.com/sigstore/policy-controller.
+	// The git+ prefix and the .git suffix are optional. If empty, any source
+	// repository is allowed.
+	// +optional
+	SourceURIs []string `json:"sourceURIs,omitempty"`
+	// SourceRefs that the image is allowed to be built from, for example
+	// refs/heads/main. If empty, any ref is allowed.
+	// +optional
+	SourceRefs []string `json:"sourceRefs,omitempty"`
+	// MinBuildLevel is the minimum SLSA build level that the builder of the
+	// provenance must meet, from 0 to 3.
+	// +optional
+	MinBuildLevel int32 `json:"minBuildLevel,omitempty"`
+}
+
+// SLSABuilder is a builder that is trusted to produce SLSA provenance.
+type SLSABuilder struct {
+	// ID of the builder. If it does not have a version (an @ suffix), then
+	// any version of the builder is allowed.
+	ID string `json:"id"`
+	// BuildLevel is the SLSA build level that the builder meets, from 0 to
+	// 3. The provenance does not record it, so it is vouched for here.
+	// +optional
+	BuildLevel int32 `json:"buildLevel,omitempty"`
+}
+
+// Vulnerability specifies the vulnerabilities that a vulnerability scan
+// attestation is allowed to report. Only the most recent scan of the image
+// is checked. Vulnerabilities that OpenVEX attestations verified by the same
+// authority mark as not_affected or fixed for the image are not counted. At
+// least one of MaxSeverityCounts and MaxScanAge must be set.
+type Vulnerability struct {
+	// MaxSeverityCounts is the maximum number of vulnerabilities of each
+	// severity (CRITICAL, HIGH, MEDIUM, LOW or UNKNOWN) that are allowed.
+	// Severities that are not listed are not limited, so a CRITICAL count
+	// of 0 blocks images with any critical vulnerability.
+	// +optional
+	MaxSeverityCounts map[string]int32 `json:"maxSeverityCounts,omitempty"`
+	// IgnoredCVEs are vulnerabilities that are not counted.
+	// +optional
+	IgnoredCVEs []string `json:"ignoredCVEs,omitempty"`
+	// MaxScanAge is how long ago the scan may have finished, for example
+	// 168h for a week. If not specified, scans of any age are allowed.
+	// +optional
+	MaxScanAge *metav1.Duration `json:"maxScanAge,omitempty"`
 }
 
 // RemotePolicy defines all the properties to fetch a remote policy
@@ -253,8 +477,12 @@ type RemotePolicy struct {
 // at least one authority matches).
 // Exactly one of Data, URL, or ConfigMapReference must be specified.
 type Policy struct {
-	// Which kind of policy this is, currently only rego or cue are supported.
-	// Furthermore, only cue is tested :)
+	// Which kind of policy this is, currently only rego, cue or cel are
+	// supported. A cel policy is an expression that must evaluate to true,
+	// with the document to validate available as `input`. Inline expressions
+	// are compiled when the policy is admitted, the ones in a ConfigMap or
+	// remote when the policy is reconciled, with compile errors reported in
+	// the status.
 	Type string `json:"type"`
 	// Data contains the policy definition.
 	// +optional
@@ -291,15 +519,47 @@ type Policy struct {
 	// evaluated iff at least one authority matches.
 	// +optional
 	IncludeTypeMeta *bool `json:"includeTypeMeta,omitempty"`
+	// IncludeAttestationPayloads controls whether the predicates of the
+	// verified attestations will be included and made available for CIP
+	// level policy evaluation, as the `predicate` of each attestation. Note
+	// that this only gets evaluated iff at least one authority matches.
+	// +optional
+	IncludeAttestationPayloads *bool `json:"includeAttestationPayloads,omitempty"`
 }
 
 // MatchResource allows selecting resources based on its version, group and resource.
-// It is also possible to select resources based on a list of matching labels.
+// It is also possible to select resources based on a list of matching labels,
+// on the labels of the namespace they are in, and on who is making the request.
 type MatchResource struct {
 	// +optional
 	metav1.GroupVersionResource `json:",inline"`
 	// +optional
 	ResourceSelector *metav1.LabelSelector `json:"selector,omitempty"`
+	// NamespaceSelector selects resources based on the labels of the
+	// namespace they are in. If no group, version and resource are
+	// specified, it applies to resources of any type.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Subjects selects resources based on the user, group or service account
+	// making the request to create or update them. If no group, version and
+	// resource are specified, it applies to resources of any type. Since
+	// there is no such request when auditing running Pods or with the policy
+	// tester, it never matches there, and a warning says the policy was
+	// skipped.
+	// +optional
+	Subjects []Subject `json:"subjects,omitempty"`
+}
+
+// Subject identifies a user, group or service account, the same way as the
+// subjects of a RoleBinding do.
+type Subject struct {
+	// Kind of the subject, one of User, Group or ServiceAccount.
+	Kind string `json:"kind"`
+	// Name of the user, group or service account.
+	Name string `json:"name"`
+	// Namespace of the service account. Only set for ServiceAccount.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // ConfigMapReference is cut&paste from SecretReference, but for the life of me
@@ -333,6 +593,17 @@ type Identity struct {
 	// SubjectRegExp specifies a regular expression to match the subject for this identity.
 	// +optional
 	SubjectRegExp string `json:"subjectRegExp,omitempty"`
+	// Extensions specifies the Fulcio certificate extensions that this
+	// identity must have, keyed by their name, for example
+	// sourceRepositoryURI or runnerEnvironment.
+	// See also: https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+	// +optional
+	Extensions map[string]string `json:"extensions,omitempty"`
+	// ExtensionsRegExp specifies regular expressions to match the Fulcio
+	// certificate extensions of this identity, keyed like Extensions. The
+	// regular expressions must match the whole value of the extension.
+	// +optional
+	ExtensionsRegExp map[string]string `json:"extensionsRegExp,omitempty"`
 }
 
 // RFC3161Timestamp specifies the URL to a RFC3161 time-stamping server that holds
@@ -20,11 +20,17 @@ import (
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"strings"
+	"time"
 
 	"github.com/sigstore/policy-controller/pkg/apis/glob"
 	"github.com/sigstore/policy-controller/pkg/apis/policy/common"
 	"github.com/sigstore/policy-controller/pkg/apis/signaturealgo"
+	policycel "github.com/sigstore/policy-controller/pkg/cel"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	"knative.dev/pkg/system"
 
@@ -63,20 +69,52 @@ func (spec *ClusterImagePolicySpec) Validate(ctx context.Context) (errors *apis.
 	for i, m := range spec.Match {
 		errors = errors.Also(m.Validate(ctx).ViaFieldIndex("match", i))
 	}
+	if spec.AuthorityThreshold != nil {
+		if *spec.AuthorityThreshold < 1 {
+			errors = errors.Also(apis.ErrInvalidValue(*spec.AuthorityThreshold, "authorityThreshold", "must be at least 1"))
+		} else if int(*spec.AuthorityThreshold) > len(spec.Authorities) {
+			errors = errors.Also(apis.ErrInvalidValue(*spec.AuthorityThreshold, "authorityThreshold", fmt.Sprintf("must not be greater than the number of authorities (%d)", len(spec.Authorities))))
+		}
+	}
+	errors = errors.Also(spec.VerifyPlatforms.Validate(ctx).ViaField("verifyPlatforms"))
 	// Note that we're within Spec here so that we can validate that the policy
 	// FetchConfigFile is only set within Spec.Policy.
 	errors = errors.Also(spec.Policy.Validate(apis.WithinSpec(ctx)))
 	return
 }
 
-func (image *ImagePattern) Validate(_ context.Context) *apis.FieldError {
-	if image.Glob == "" {
-		return apis.ErrMissingField("glob")
+func (pv *PlatformVerification) Validate(_ context.Context) (errors *apis.FieldError) {
+	if pv == nil {
+		return nil
+	}
+	for i, platform := range pv.Platforms {
+		if _, err := common.ParsePlatform(platform); err != nil {
+			fe := apis.ErrInvalidArrayValue(platform, "platforms", i)
+			fe.Details = err.Error()
+			errors = errors.Also(fe)
+		}
 	}
-	return ValidateGlob(image.Glob).ViaField("glob")
+	return
 }
 
-func (matchResource *MatchResource) Validate(_ context.Context) *apis.FieldError {
+func (image *ImagePattern) Validate(_ context.Context) (errs *apis.FieldError) {
+	switch {
+	case image.Glob == "" && image.Regex == "":
+		return apis.ErrMissingOneOf("glob", "regex")
+	case image.Glob != "" && image.Regex != "":
+		return apis.ErrMultipleOneOf("glob", "regex")
+	case image.Glob != "":
+		errs = ValidateGlob(image.Glob).ViaField("glob")
+	default:
+		errs = ValidateRegex(image.Regex).ViaField("regex")
+	}
+	for i, g := range image.ExcludeGlob {
+		errs = errs.Also(ValidateGlob(g).ViaFieldIndex("excludeGlob", i))
+	}
+	return errs
+}
+
+func (matchResource *MatchResource) Validate(ctx context.Context) *apis.FieldError {
 	var errs *apis.FieldError
 	if matchResource.Resource != "" && common.ValidResourceNames.Len() > 0 &&
 		!common.ValidResourceNames.Has(matchResource.Resource) {
@@ -86,21 +124,50 @@ func (matchResource *MatchResource) Validate(_ context.Context) *apis.FieldError
 	if matchResource.ResourceSelector != nil && (matchResource.Resource == "" && matchResource.Version == "" && matchResource.Group == "") {
 		errs = errs.Also(apis.ErrInvalidValue(matchResource.Resource, "selector", "selector requires a resource type to match the labels"))
 	}
+	if matchResource.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(matchResource.NamespaceSelector); err != nil {
+			errs = errs.Also(apis.ErrGeneric(err.Error(), "namespaceSelector"))
+		}
+	}
+	for i, subject := range matchResource.Subjects {
+		errs = errs.Also(subject.Validate(ctx).ViaFieldIndex("subjects", i))
+	}
+	return errs
+}
+
+func (subject *Subject) Validate(_ context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	if subject.Name == "" {
+		errs = errs.Also(apis.ErrMissingField("name"))
+	}
+	switch subject.Kind {
+	case rbacv1.UserKind, rbacv1.GroupKind:
+		if subject.Namespace != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("namespace"))
+		}
+	case rbacv1.ServiceAccountKind:
+		if subject.Namespace == "" {
+			errs = errs.Also(apis.ErrMissingField("namespace"))
+		}
+	case "":
+		errs = errs.Also(apis.ErrMissingField("kind"))
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(subject.Kind, "kind", "unsupported kind, must be one of User, Group or ServiceAccount"))
+	}
 	return errs
 }
 
 func (authority *Authority) Validate(ctx context.Context) *apis.FieldError {
 	var errs *apis.FieldError
-	if authority.Key == nil && authority.Keyless == nil && authority.Static == nil {
-		errs = errs.Also(apis.ErrMissingOneOf("key", "keyless", "static"))
+	switch authority.countTypes() {
+	case 0:
+		errs = errs.Also(apis.ErrMissingOneOf(authorityTypes...))
 		// Instead of returning all the missing subfields, just return here
 		// to give a more concise and arguably a more meaningful error message.
 		return errs
-	}
-	if (authority.Key != nil && authority.Keyless != nil) ||
-		(authority.Key != nil && authority.Static != nil) ||
-		(authority.Keyless != nil && authority.Static != nil) {
-		errs = errs.Also(apis.ErrMultipleOneOf("key", "keyless", "static"))
+	case 1:
+	default:
+		errs = errs.Also(apis.ErrMultipleOneOf(authorityTypes...))
 		// Instead of returning all the missing subfields, just return here
 		// to give a more concise and arguably a more meaningful error message.
 		return errs
@@ -108,10 +175,32 @@ func (authority *Authority) Validate(ctx context.Context) *apis.FieldError {
 
 	if authority.Key != nil {
 		errs = errs.Also(authority.Key.Validate(ctx).ViaField("key"))
+		// The validity window of a key is checked against the signing time
+		// attested by the TLog or the TSA.
+		if (authority.Key.ValidFrom != nil || authority.Key.ValidUntil != nil) && authority.CTLog == nil && authority.RFC3161Timestamp == nil {
+			errs = errs.Also(apis.ErrGeneric("validFrom and validUntil require a ctlog or rfc3161timestamp to establish the signing time", "ctlog", "rfc3161timestamp"))
+		}
 	}
 	if authority.Keyless != nil {
 		errs = errs.Also(authority.Keyless.Validate(ctx).ViaField("keyless"))
 	}
+	if authority.Certificate != nil {
+		errs = errs.Also(authority.Certificate.Validate(ctx).ViaField("certificate"))
+	}
+	if authority.Notation != nil {
+		errs = errs.Also(authority.Notation.Validate(ctx).ViaField("notation"))
+		// Notary Project signatures are neither attestations nor logged
+		// in Rekor, and carry their own signing time.
+		if len(authority.Attestations) > 0 {
+			errs = errs.Also(apis.ErrMultipleOneOf("notation", "attestations"))
+		}
+		if authority.CTLog != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("notation", "ctlog"))
+		}
+		if authority.RFC3161Timestamp != nil {
+			errs = errs.Also(apis.ErrMultipleOneOf("notation", "rfc3161timestamp"))
+		}
+	}
 	if authority.Static != nil {
 		errs = errs.Also(authority.Static.Validate(ctx).ViaField("static"))
 		// Attestations, Sources, RFC3161Timestamp, or CTLog do not make sense with static policy.
@@ -145,6 +234,20 @@ func (authority *Authority) Validate(ctx context.Context) *apis.FieldError {
 	return errs
 }
 
+// authorityTypes are the fields of an Authority that are mutually exclusive.
+var authorityTypes = []string{"certificate", "key", "keyless", "notation", "static"}
+
+// countTypes returns how many of the authorityTypes are set.
+func (authority *Authority) countTypes() int {
+	count := 0
+	for _, set := range []bool{authority.Certificate != nil, authority.Key != nil, authority.Keyless != nil, authority.Notation != nil, authority.Static != nil} {
+		if set {
+			count++
+		}
+	}
+	return count
+}
+
 func (s *StaticRef) Validate(_ context.Context) *apis.FieldError {
 	var errs *apis.FieldError
 
@@ -187,6 +290,9 @@ func (key *KeyRef) Validate(_ context.Context) *apis.FieldError {
 	if key.SecretRef != nil && key.SecretRef.Namespace != "" && key.SecretRef.Namespace != system.Namespace() {
 		errs = errs.Also(apis.ErrInvalidValue(key.SecretRef.Namespace, "secretref.namespace", "secretref.namespace is invalid. If set, it should use the same namespace where the policy-controller was deployed"))
 	}
+	if key.ValidFrom != nil && key.ValidUntil != nil && !key.ValidUntil.After(key.ValidFrom.Time) {
+		errs = errs.Also(apis.ErrInvalidValue(key.ValidUntil.UTC().Format(time.RFC3339), "validUntil", "validUntil must be after validFrom"))
+	}
 	return errs
 }
 
@@ -214,6 +320,101 @@ func (keyless *KeylessRef) Validate(ctx context.Context) *apis.FieldError {
 	return errs
 }
 
+func (cert *CertificateRef) Validate(_ context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	switch {
+	case cert.CABundle == "" && cert.SecretRef == nil && cert.TrustRootRef == "":
+		errs = errs.Also(apis.ErrMissingOneOf("caBundle", "secretRef", "trustRootRef"))
+	case (cert.CABundle != "" && cert.SecretRef != nil) ||
+		(cert.CABundle != "" && cert.TrustRootRef != "") ||
+		(cert.SecretRef != nil && cert.TrustRootRef != ""):
+		errs = errs.Also(apis.ErrMultipleOneOf("caBundle", "secretRef", "trustRootRef"))
+	}
+	if cert.CABundle != "" {
+		if certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(cert.CABundle)); err != nil || len(certs) == 0 {
+			errs = errs.Also(apis.ErrInvalidValue(cert.CABundle, "caBundle", "must contain PEM encoded certificates"))
+		}
+	}
+	errs = errs.Also(validateSecretRefNamespace(cert.SecretRef).ViaField("secretRef"))
+
+	for i, san := range cert.SubjectAlternativeNames {
+		if san == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(san, "subjectAlternativeNames", i))
+		}
+	}
+	for i, eku := range cert.ExtKeyUsages {
+		if !common.ValidExtKeyUsages.Has(eku) && common.ValidateOID(eku) != nil {
+			errs = errs.Also(apis.ErrInvalidValue(eku, apis.CurrentField, "must be an OID or one of "+strings.Join(common.ValidExtKeyUsages.List(), ", ")).ViaFieldIndex("extKeyUsages", i))
+		}
+	}
+	for i, oid := range cert.PolicyOIDs {
+		if err := common.ValidateOID(oid); err != nil {
+			errs = errs.Also(apis.ErrInvalidArrayValue(oid, "policyOIDs", i))
+		}
+	}
+	if cert.CRL != nil {
+		errs = errs.Also(cert.CRL.Validate().ViaField("crl"))
+	}
+	return errs
+}
+
+func (crl *CRLRef) Validate() *apis.FieldError {
+	var errs *apis.FieldError
+	switch {
+	case crl.Data == "" && crl.SecretRef == nil:
+		errs = errs.Also(apis.ErrMissingOneOf("data", "secretRef"))
+	case crl.Data != "" && crl.SecretRef != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("data", "secretRef"))
+	}
+	if crl.Data != "" {
+		if err := common.ValidateCRLs([]byte(crl.Data)); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(crl.Data, "data", err.Error()))
+		}
+	}
+	errs = errs.Also(validateSecretRefNamespace(crl.SecretRef).ViaField("secretRef"))
+	return errs
+}
+
+func (notation *NotationRef) Validate(_ context.Context) *apis.FieldError {
+	var errs *apis.FieldError
+	switch {
+	case notation.CABundle == "" && notation.SecretRef == nil && notation.TrustRootRef == "":
+		errs = errs.Also(apis.ErrMissingOneOf("caBundle", "secretRef", "trustRootRef"))
+	case (notation.CABundle != "" && notation.SecretRef != nil) ||
+		(notation.CABundle != "" && notation.TrustRootRef != "") ||
+		(notation.SecretRef != nil && notation.TrustRootRef != ""):
+		errs = errs.Also(apis.ErrMultipleOneOf("caBundle", "secretRef", "trustRootRef"))
+	}
+	if notation.CABundle != "" {
+		if certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(notation.CABundle)); err != nil || len(certs) == 0 {
+			errs = errs.Also(apis.ErrInvalidValue(notation.CABundle, "caBundle", "must contain PEM encoded certificates"))
+		}
+	}
+	errs = errs.Also(validateSecretRefNamespace(notation.SecretRef).ViaField("secretRef"))
+
+	if notation.TrustStoreType != "" && !common.ValidNotationTrustStoreTypes.Has(notation.TrustStoreType) {
+		errs = errs.Also(apis.ErrInvalidValue(notation.TrustStoreType, "trustStoreType", "must be one of "+strings.Join(common.ValidNotationTrustStoreTypes.List(), ", ")))
+	}
+	for i, identity := range notation.TrustedIdentities {
+		if _, err := common.ParseTrustedIdentity(identity); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(identity, apis.CurrentField, err.Error()).ViaFieldIndex("trustedIdentities", i))
+		}
+		if identity == "*" && len(notation.TrustedIdentities) > 1 {
+			errs = errs.Also(apis.ErrInvalidValue(identity, apis.CurrentField, "* must be the only trusted identity").ViaFieldIndex("trustedIdentities", i))
+		}
+	}
+	return errs
+}
+
+// validateSecretRefNamespace checks that the secret, if any, is in the
+// namespace where the policy-controller was deployed.
+func validateSecretRefNamespace(ref *v1.SecretReference) *apis.FieldError {
+	if ref != nil && ref.Namespace != "" && ref.Namespace != system.Namespace() {
+		return apis.ErrInvalidValue(ref.Namespace, "namespace", "If set, it should use the same namespace where the policy-controller was deployed")
+	}
+	return nil
+}
+
 func (source *Source) Validate(_ context.Context) *apis.FieldError {
 	var errs *apis.FieldError
 	if source.OCI != "" {
@@ -239,8 +440,11 @@ func (a *Attestation) Validate(ctx context.Context) *apis.FieldError {
 	}
 	switch {
 	case a.PredicateType == "":
-		// This is just straight up missing, so error out.
-		errs = errs.Also(apis.ErrMissingField("predicateType"))
+		// This is just straight up missing, so error out, unless it is a
+		// SLSA check, which looks at all the SLSA provenance versions.
+		if a.SLSA == nil {
+			errs = errs.Also(apis.ErrMissingField("predicateType"))
+		}
 	case common.ValidPredicateTypes.Has(a.PredicateType):
 		// Ok, it's a valid, deprecated short form. It's fine for now, but
 		// should remove it soon because it is very error prone, so warn.
@@ -254,6 +458,63 @@ func (a *Attestation) Validate(ctx context.Context) *apis.FieldError {
 		}
 	}
 	errs = errs.Also(a.Policy.Validate(ctx).ViaField("policy"))
+	if a.SLSA != nil && a.PredicateType != "" && !common.ValidSLSAPredicateTypes.Has(a.PredicateType) {
+		errs = errs.Also(apis.ErrInvalidValue(a.PredicateType, "predicateType", "must be a SLSA provenance predicate type when slsa is specified"))
+	}
+	errs = errs.Also(a.SLSA.Validate(ctx).ViaField("slsa"))
+	if a.Vulnerability != nil && a.PredicateType != "" && !common.ValidVulnerabilityPredicateTypes.Has(a.PredicateType) {
+		errs = errs.Also(apis.ErrInvalidValue(a.PredicateType, "predicateType", "must be a vulnerability scan predicate type when vulnerability is specified"))
+	}
+	errs = errs.Also(a.Vulnerability.Validate(ctx).ViaField("vulnerability"))
+	return errs
+}
+
+func (s *SLSA) Validate(_ context.Context) *apis.FieldError {
+	if s == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	if len(s.Builders) == 0 {
+		errs = errs.Also(apis.ErrMissingField("builders"))
+	}
+	for i, builder := range s.Builders {
+		if builder.ID == "" {
+			errs = errs.Also(apis.ErrMissingField("id").ViaFieldIndex("builders", i))
+		}
+		if builder.BuildLevel < 0 || builder.BuildLevel > common.MaxSLSABuildLevel {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(builder.BuildLevel, 0, common.MaxSLSABuildLevel, "buildLevel").ViaFieldIndex("builders", i))
+		}
+	}
+	if s.MinBuildLevel < 0 || s.MinBuildLevel > common.MaxSLSABuildLevel {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(s.MinBuildLevel, 0, common.MaxSLSABuildLevel, "minBuildLevel"))
+	}
+	return errs
+}
+
+func (v *Vulnerability) Validate(_ context.Context) *apis.FieldError {
+	if v == nil {
+		return nil
+	}
+	var errs *apis.FieldError
+	if len(v.MaxSeverityCounts) == 0 && v.MaxScanAge == nil {
+		errs = errs.Also(apis.ErrGeneric("expected at least one, got neither", "maxScanAge", "maxSeverityCounts"))
+	}
+	for severity, count := range v.MaxSeverityCounts {
+		if !common.ValidVulnerabilitySeverities.Has(severity) {
+			errs = errs.Also(apis.ErrInvalidKeyName(severity, "maxSeverityCounts", "must be one of CRITICAL, HIGH, MEDIUM, LOW or UNKNOWN"))
+		}
+		if count < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(count, severity, "must not be negative").ViaField("maxSeverityCounts"))
+		}
+	}
+	for i, cve := range v.IgnoredCVEs {
+		if cve == "" {
+			errs = errs.Also(apis.ErrInvalidArrayValue(cve, "ignoredCVEs", i))
+		}
+	}
+	if v.MaxScanAge != nil && v.MaxScanAge.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(v.MaxScanAge.Duration.String(), "maxScanAge", "must be positive"))
+	}
 	return errs
 }
 
@@ -286,8 +547,16 @@ func (p *Policy) Validate(ctx context.Context) *apis.FieldError {
 		return nil
 	}
 	var errs *apis.FieldError
-	if p.Type != "cue" && p.Type != "rego" {
-		errs = errs.Also(apis.ErrInvalidValue(p.Type, "type", "only [cue,rego] are supported at the moment"))
+	if p.Type != "cue" && p.Type != "rego" && p.Type != policycel.PolicyType {
+		errs = errs.Also(apis.ErrInvalidValue(p.Type, "type", "only [cue,rego,cel] are supported at the moment"))
+	}
+	// Unlike cue and rego, CEL expressions can be checked before they are
+	// evaluated. The ones in a ConfigMap or remote are checked when the
+	// policy is reconciled.
+	if p.Type == policycel.PolicyType && p.Data != "" {
+		if _, err := policycel.Compile(p.Data); err != nil {
+			errs = errs.Also(apis.ErrInvalidValue(p.Data, "data", err.Error()))
+		}
 	}
 	if p.Data == "" && p.ConfigMapRef == nil && p.Remote == nil {
 		errs = errs.Also(apis.ErrMissingField("data", "configMapRef", "remote"))
@@ -318,6 +587,9 @@ func (p *Policy) Validate(ctx context.Context) *apis.FieldError {
 	if !apis.IsInSpec(ctx) && p.IncludeTypeMeta != nil {
 		errs = errs.Also(apis.ErrDisallowedFields("includeTypeMeta"))
 	}
+	if !apis.IsInSpec(ctx) && p.IncludeAttestationPayloads != nil {
+		errs = errs.Also(apis.ErrDisallowedFields("includeAttestationPayloads"))
+	}
 	// TODO(vaikas): How to validate the cue / rego bytes here (data).
 	return errs
 }
@@ -342,6 +614,20 @@ func (identity *Identity) Validate(_ context.Context) *apis.FieldError {
 	if identity.IssuerRegExp == "" && identity.Issuer == "" {
 		errs = errs.Also(apis.ErrMissingField("issuer", "issuerRegExp"))
 	}
+	for name := range identity.Extensions {
+		if !common.ValidCertificateExtensions.Has(name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, "extensions", "unsupported certificate extension"))
+		}
+		if _, ok := identity.ExtensionsRegExp[name]; ok {
+			errs = errs.Also(apis.ErrMultipleOneOf(fmt.Sprintf("extensions[%s]", name), fmt.Sprintf("extensionsRegExp[%s]", name)))
+		}
+	}
+	for name, regex := range identity.ExtensionsRegExp {
+		if !common.ValidCertificateExtensions.Has(name) {
+			errs = errs.Also(apis.ErrInvalidKeyName(name, "extensionsRegExp", "unsupported certificate extension"))
+		}
+		errs = errs.Also(ValidateRegex(regex).ViaKey(name).ViaField("extensionsRegExp"))
+	}
 	return errs
 }
 
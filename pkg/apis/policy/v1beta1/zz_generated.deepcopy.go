@@ -34,6 +34,16 @@ func (in *Attestation) DeepCopyInto(out *Attestation) {
 		*out = new(Policy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SLSA != nil {
+		in, out := &in.SLSA, &out.SLSA
+		*out = new(SLSA)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vulnerability != nil {
+		in, out := &in.Vulnerability, &out.Vulnerability
+		*out = new(Vulnerability)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -60,6 +70,16 @@ func (in *Authority) DeepCopyInto(out *Authority) {
 		*out = new(KeylessRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Certificate != nil {
+		in, out := &in.Certificate, &out.Certificate
+		*out = new(CertificateRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Notation != nil {
+		in, out := &in.Notation, &out.Notation
+		*out = new(NotationRef)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Static != nil {
 		in, out := &in.Static, &out.Static
 		*out = new(StaticRef)
@@ -102,6 +122,68 @@ func (in *Authority) DeepCopy() *Authority {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRLRef) DeepCopyInto(out *CRLRef) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRLRef.
+func (in *CRLRef) DeepCopy() *CRLRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CRLRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRef) DeepCopyInto(out *CertificateRef) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.SubjectAlternativeNames != nil {
+		in, out := &in.SubjectAlternativeNames, &out.SubjectAlternativeNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtKeyUsages != nil {
+		in, out := &in.ExtKeyUsages, &out.ExtKeyUsages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyOIDs != nil {
+		in, out := &in.PolicyOIDs, &out.PolicyOIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CRL != nil {
+		in, out := &in.CRL, &out.CRL
+		*out = new(CRLRef)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRef.
+func (in *CertificateRef) DeepCopy() *CertificateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterImagePolicy) DeepCopyInto(out *ClusterImagePolicy) {
 	*out = *in
@@ -169,7 +251,9 @@ func (in *ClusterImagePolicySpec) DeepCopyInto(out *ClusterImagePolicySpec) {
 	if in.Images != nil {
 		in, out := &in.Images, &out.Images
 		*out = make([]ImagePattern, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Authorities != nil {
 		in, out := &in.Authorities, &out.Authorities
@@ -190,6 +274,16 @@ func (in *ClusterImagePolicySpec) DeepCopyInto(out *ClusterImagePolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.AuthorityThreshold != nil {
+		in, out := &in.AuthorityThreshold, &out.AuthorityThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VerifyPlatforms != nil {
+		in, out := &in.VerifyPlatforms, &out.VerifyPlatforms
+		*out = new(PlatformVerification)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -239,6 +333,20 @@ func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Identity) DeepCopyInto(out *Identity) {
 	*out = *in
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExtensionsRegExp != nil {
+		in, out := &in.ExtensionsRegExp, &out.ExtensionsRegExp
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -255,6 +363,11 @@ func (in *Identity) DeepCopy() *Identity {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ImagePattern) DeepCopyInto(out *ImagePattern) {
 	*out = *in
+	if in.ExcludeGlob != nil {
+		in, out := &in.ExcludeGlob, &out.ExcludeGlob
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -276,6 +389,14 @@ func (in *KeyRef) DeepCopyInto(out *KeyRef) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.ValidFrom != nil {
+		in, out := &in.ValidFrom, &out.ValidFrom
+		*out = (*in).DeepCopy()
+	}
+	if in.ValidUntil != nil {
+		in, out := &in.ValidUntil, &out.ValidUntil
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -300,7 +421,9 @@ func (in *KeylessRef) DeepCopyInto(out *KeylessRef) {
 	if in.Identities != nil {
 		in, out := &in.Identities, &out.Identities
 		*out = make([]Identity, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.CACert != nil {
 		in, out := &in.CACert, &out.CACert
@@ -334,6 +457,16 @@ func (in *MatchResource) DeepCopyInto(out *MatchResource) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]Subject, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -347,6 +480,53 @@ func (in *MatchResource) DeepCopy() *MatchResource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotationRef) DeepCopyInto(out *NotationRef) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+	if in.TrustedIdentities != nil {
+		in, out := &in.TrustedIdentities, &out.TrustedIdentities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotationRef.
+func (in *NotationRef) DeepCopy() *NotationRef {
+	if in == nil {
+		return nil
+	}
+	out := new(NotationRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlatformVerification) DeepCopyInto(out *PlatformVerification) {
+	*out = *in
+	if in.Platforms != nil {
+		in, out := &in.Platforms, &out.Platforms
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformVerification.
+func (in *PlatformVerification) DeepCopy() *PlatformVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(PlatformVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Policy) DeepCopyInto(out *Policy) {
 	*out = *in
@@ -380,6 +560,11 @@ func (in *Policy) DeepCopyInto(out *Policy) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IncludeAttestationPayloads != nil {
+		in, out := &in.IncludeAttestationPayloads, &out.IncludeAttestationPayloads
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -426,6 +611,58 @@ func (in *RemotePolicy) DeepCopy() *RemotePolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLSA) DeepCopyInto(out *SLSA) {
+	*out = *in
+	if in.Builders != nil {
+		in, out := &in.Builders, &out.Builders
+		*out = make([]SLSABuilder, len(*in))
+		copy(*out, *in)
+	}
+	if in.BuildTypes != nil {
+		in, out := &in.BuildTypes, &out.BuildTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceURIs != nil {
+		in, out := &in.SourceURIs, &out.SourceURIs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceRefs != nil {
+		in, out := &in.SourceRefs, &out.SourceRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSA.
+func (in *SLSA) DeepCopy() *SLSA {
+	if in == nil {
+		return nil
+	}
+	out := new(SLSA)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SLSABuilder) DeepCopyInto(out *SLSABuilder) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SLSABuilder.
+func (in *SLSABuilder) DeepCopy() *SLSABuilder {
+	if in == nil {
+		return nil
+	}
+	out := new(SLSABuilder)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
@@ -468,6 +705,22 @@ func (in *StaticRef) DeepCopy() *StaticRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Subject) DeepCopyInto(out *Subject) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Subject.
+func (in *Subject) DeepCopy() *Subject {
+	if in == nil {
+		return nil
+	}
+	out := new(Subject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TLog) DeepCopyInto(out *TLog) {
 	*out = *in
@@ -488,3 +741,36 @@ func (in *TLog) DeepCopy() *TLog {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Vulnerability) DeepCopyInto(out *Vulnerability) {
+	*out = *in
+	if in.MaxSeverityCounts != nil {
+		in, out := &in.MaxSeverityCounts, &out.MaxSeverityCounts
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.IgnoredCVEs != nil {
+		in, out := &in.IgnoredCVEs, &out.IgnoredCVEs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxScanAge != nil {
+		in, out := &in.MaxScanAge, &out.MaxScanAge
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Vulnerability.
+func (in *Vulnerability) DeepCopy() *Vulnerability {
+	if in == nil {
+		return nil
+	}
+	out := new(Vulnerability)
+	in.DeepCopyInto(out)
+	return out
+}
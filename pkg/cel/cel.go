@@ -0,0 +1,140 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cel implements policies written as CEL expressions, as an
+// alternative to cue and rego. The expression is evaluated against the same
+// JSON document the other policy types are, which is available as the
+// `input` variable, and must evaluate to true for the policy to pass.
+package cel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	lru "github.com/hashicorp/golang-lru"
+)
+
+const (
+	// PolicyType is the policy type of CEL policies.
+	PolicyType = "cel"
+
+	// Variable is the name of the variable holding the document that the
+	// expression is evaluated against.
+	Variable = "input"
+
+	// costLimit bounds how expensive evaluating an expression can be, so
+	// that a policy can not hog the webhook.
+	costLimit = 1000000
+
+	// programCacheSize is how many compiled expressions are kept.
+	programCacheSize = 1000
+)
+
+// programs caches the compiled programs by expression, so that expressions
+// are compiled once rather than on every evaluation. Programs are safe for
+// concurrent use.
+var programs = func() *lru.Cache {
+	c, err := lru.New(programCacheSize)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}()
+
+var newEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(Variable, cel.DynType),
+		ext.Strings(),
+		ext.Encoders(),
+		ext.Lists(),
+		ext.Sets(),
+		ext.Math(),
+	)
+})
+
+// Compile parses and type checks expression, which must evaluate to a bool.
+func Compile(expression string) (cel.Program, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, fmt.Errorf("creating CEL environment: %w", err)
+	}
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, iss.Err()
+	}
+	// The document is dynamically typed, so most expressions are only
+	// known to be a bool at runtime.
+	if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, not %s", t)
+	}
+	return env.Program(ast,
+		cel.CostLimit(costLimit),
+		cel.InterruptCheckFrequency(100),
+	)
+}
+
+// Program returns the compiled expression, compiling and caching it if it
+// was not compiled before.
+func Program(expression string) (cel.Program, error) {
+	if program, ok := programs.Get(expression); ok {
+		return program.(cel.Program), nil
+	}
+	program, err := Compile(expression)
+	if err != nil {
+		return nil, err
+	}
+	programs.Add(expression, program)
+	return program, nil
+}
+
+// Check compiles the policy data if it is of the CEL policy type. Unlike cue
+// and rego policies, CEL expressions can be checked before they are
+// evaluated. Checking an expression also caches the program for Evaluate.
+func Check(policyType, data string) error {
+	if policyType != PolicyType {
+		return nil
+	}
+	_, err := Program(data)
+	return err
+}
+
+// Evaluate evaluates expression against the JSON document, and returns an
+// error if it does not evaluate to true.
+func Evaluate(ctx context.Context, name, expression string, document []byte) error {
+	program, err := Program(expression)
+	if err != nil {
+		return fmt.Errorf("failed compiling cel policy for %s: %w", name, err)
+	}
+	var input interface{}
+	if err := json.Unmarshal(document, &input); err != nil {
+		return fmt.Errorf("failed parsing document for cel policy %s: %w", name, err)
+	}
+	out, _, err := program.ContextEval(ctx, map[string]interface{}{Variable: input})
+	if err != nil {
+		return fmt.Errorf("failed evaluating cel policy for %s: %w", name, err)
+	}
+	pass, ok := out.Value().(bool)
+	if !ok {
+		return fmt.Errorf("cel policy for %s evaluated to %v, not a bool", name, out.Value())
+	}
+	if !pass {
+		return fmt.Errorf("failed evaluating cel policy for %s: expression evaluated to false", name)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cel
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCompile(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		wantErr    string
+	}{{
+		name:       "bool",
+		expression: `input.predicateType == "https://slsa.dev/provenance/v1"`,
+	}, {
+		name:       "dyn",
+		expression: `input.authorityMatches["keyless"].signatures.size() > 0 && input.ok`,
+	}, {
+		name:       "extensions",
+		expression: `input.image.lowerAscii().startsWith("gcr.io/") && sets.contains([1, 2], [1])`,
+	}, {
+		name:       "syntax error",
+		expression: `input.foo ==`,
+		wantErr:    "Syntax error",
+	}, {
+		name:       "undeclared variable",
+		expression: `object.foo == "bar"`,
+		wantErr:    "undeclared reference to 'object'",
+	}, {
+		name:       "not a bool",
+		expression: `"foo"`,
+		wantErr:    "expression must evaluate to a bool, not string",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Compile(tc.expression)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("Compile() = %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("Compile() = %v, wanted error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	document := []byte(`{"predicateType": "https://slsa.dev/provenance/v1", "predicate": {"buildDefinition": {"buildType": "https://actions.github.io/buildtypes/workflow/v1"}, "count": 2}}`)
+	tests := []struct {
+		name       string
+		expression string
+		document   []byte
+		wantErr    string
+	}{{
+		name:       "pass",
+		expression: `input.predicate.buildDefinition.buildType.startsWith("https://actions.github.io/") && input.predicate.count == 2`,
+		document:   document,
+	}, {
+		name:       "fail",
+		expression: `input.predicateType == "https://spdx.dev/Document"`,
+		document:   document,
+		wantErr:    "failed evaluating cel policy for test: expression evaluated to false",
+	}, {
+		name:       "missing field",
+		expression: `input.predicate.materials.size() > 0`,
+		document:   document,
+		wantErr:    "no such key: materials",
+	}, {
+		name:       "not a bool at runtime",
+		expression: `input.predicateType`,
+		document:   document,
+		wantErr:    "not a bool",
+	}, {
+		name:       "invalid document",
+		expression: `true`,
+		document:   []byte("not json"),
+		wantErr:    "failed parsing document",
+	}, {
+		name:       "invalid expression",
+		expression: `input.`,
+		document:   document,
+		wantErr:    "failed compiling cel policy for test",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Evaluate(context.Background(), "test", tc.expression, tc.document)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("Evaluate() = %v", err)
+			case tc.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tc.wantErr)):
+				t.Errorf("Evaluate() = %v, wanted error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestProgramCached(t *testing.T) {
+	expression := `input.cached == true`
+	first, err := Program(expression)
+	if err != nil {
+		t.Fatalf("Program() = %v", err)
+	}
+	if _, ok := programs.Get(expression); !ok {
+		t.Error("Program() did not cache the compiled expression")
+	}
+	second, err := Program(expression)
+	if err != nil {
+		t.Fatalf("Program() = %v", err)
+	}
+	if first != second {
+		t.Error("Program() compiled the expression again")
+	}
+
+	if _, err := Program(`input.`); err == nil {
+		t.Error("Program() succeeded for an invalid expression")
+	}
+	if _, ok := programs.Get(`input.`); ok {
+		t.Error("Program() cached an invalid expression")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	for _, tc := range []struct {
+		policyType string
+		data       string
+		wantErr    bool
+	}{
+		{policyType: PolicyType, data: `input.ok`},
+		{policyType: PolicyType, data: `input.`, wantErr: true},
+		// Only CEL policies are checked.
+		{policyType: "cue", data: `input.`},
+	} {
+		if err := Check(tc.policyType, tc.data); (err != nil) != tc.wantErr {
+			t.Errorf("Check(%q, %q) = %v, wanted error %t", tc.policyType, tc.data, err, tc.wantErr)
+		}
+	}
+}
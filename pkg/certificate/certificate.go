@@ -0,0 +1,242 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package certificate checks signing certificates that were issued by an
+// X.509 certificate authority, such as an enterprise PKI, against the
+// constraints of a certificate authority: subject alternative names,
+// extended key usages, issuer, certificate policies and revocation.
+package certificate
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+)
+
+// extKeyUsageOIDs maps the names of the extended key usages that can be
+// required to their OIDs.
+var extKeyUsageOIDs = map[string]string{
+	"any":             "2.5.29.37.0",
+	"serverAuth":      "1.3.6.1.5.5.7.3.1",
+	"clientAuth":      "1.3.6.1.5.5.7.3.2",
+	"codeSigning":     "1.3.6.1.5.5.7.3.3",
+	"emailProtection": "1.3.6.1.5.5.7.3.4",
+	"timeStamping":    "1.3.6.1.5.5.7.3.8",
+	"ocspSigning":     "1.3.6.1.5.5.7.3.9",
+}
+
+// extKeyUsageNames maps the extended key usages that crypto/x509 parses to
+// the names in extKeyUsageOIDs.
+var extKeyUsageNames = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:             "any",
+	x509.ExtKeyUsageServerAuth:      "serverAuth",
+	x509.ExtKeyUsageClientAuth:      "clientAuth",
+	x509.ExtKeyUsageCodeSigning:     "codeSigning",
+	x509.ExtKeyUsageEmailProtection: "emailProtection",
+	x509.ExtKeyUsageTimeStamping:    "timeStamping",
+	x509.ExtKeyUsageOCSPSigning:     "ocspSigning",
+}
+
+// Pools splits the certificates of a certificate authority into the root
+// certificates, which are self-signed, and the intermediate certificates.
+// intermediates is nil if there are none, so that the intermediates that
+// come with a signature can be used instead.
+func Pools(certs []*x509.Certificate) (roots, intermediates *x509.CertPool) {
+	roots = x509.NewCertPool()
+	for _, cert := range certs {
+		if bytes.Equal(cert.RawSubject, cert.RawIssuer) {
+			roots.AddCert(cert)
+			continue
+		}
+		if intermediates == nil {
+			intermediates = x509.NewCertPool()
+		}
+		intermediates.AddCert(cert)
+	}
+	return roots, intermediates
+}
+
+// Issuers returns the certificates that cert was issued by, in the chains
+// that verify cert up to one of the root certificates of cas. chain holds
+// any further intermediate certificates, such as those that come with a
+// signature. Only these issuers can be trusted to sign revocation lists.
+func Issuers(cert *x509.Certificate, cas, chain []*x509.Certificate) []*x509.Certificate {
+	roots, intermediates := Pools(cas)
+	if intermediates == nil {
+		intermediates = x509.NewCertPool()
+	}
+	for _, c := range chain {
+		intermediates.AddCert(c)
+	}
+	chains, err := cert.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		// The certificate may have expired since, which is fine as long as
+		// it was valid when it was used to sign.
+		CurrentTime: cert.NotBefore,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return nil
+	}
+	var ret []*x509.Certificate
+	for _, c := range chains {
+		if len(c) > 1 && !slices.ContainsFunc(ret, c[1].Equal) {
+			ret = append(ret, c[1])
+		}
+	}
+	return ret
+}
+
+// ParseCRLs parses PEM encoded certificate revocation lists.
+func ParseCRLs(data []byte) ([]*x509.RevocationList, error) {
+	var ret []*x509.RevocationList
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "X509 CRL" {
+			return nil, fmt.Errorf("unexpected PEM block of type %q", block.Type)
+		}
+		crl, err := x509.ParseRevocationList(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate revocation list: %w", err)
+		}
+		ret = append(ret, crl)
+	}
+	if len(ret) == 0 {
+		return nil, errors.New("no PEM encoded certificate revocation lists found")
+	}
+	return ret, nil
+}
+
+// Verify checks that cert meets the constraints of ref. The chain of cert
+// must already have been verified, this only checks the constraints on
+// top of that. issuers are the verified issuers of cert, see Issuers, that
+// the revocation lists can be signed by, and now is the time that they must
+// be current at.
+func Verify(cert *x509.Certificate, issuers []*x509.Certificate, ref *v1alpha1.CertificateRef, now time.Time) error {
+	var errs []error
+	if len(ref.SubjectAlternativeNames) > 0 {
+		sans := subjectAlternativeNames(cert)
+		if !slices.ContainsFunc(ref.SubjectAlternativeNames, func(san string) bool { return slices.Contains(sans, san) }) {
+			errs = append(errs, fmt.Errorf("certificate has none of the subject alternative names %v, got %v", ref.SubjectAlternativeNames, sans))
+		}
+	}
+	usages := extKeyUsages(cert)
+	for _, want := range ref.ExtKeyUsages {
+		oid := want
+		if o, ok := extKeyUsageOIDs[want]; ok {
+			oid = o
+		}
+		if !slices.Contains(usages, oid) {
+			errs = append(errs, fmt.Errorf("certificate does not have the extended key usage %s", want))
+		}
+	}
+	if ref.Issuer != "" && cert.Issuer.String() != ref.Issuer {
+		errs = append(errs, fmt.Errorf("certificate was issued by %q, wanted %q", cert.Issuer.String(), ref.Issuer))
+	}
+	for _, want := range ref.PolicyOIDs {
+		oid, err := x509.ParseOID(want)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid certificate policy %q: %w", want, err))
+			continue
+		}
+		if !slices.ContainsFunc(cert.Policies, oid.Equal) {
+			errs = append(errs, fmt.Errorf("certificate does not have the certificate policy %s", want))
+		}
+	}
+	if ref.CRL != nil {
+		if err := checkRevocation(cert, issuers, []byte(ref.CRL.Data), now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkRevocation checks that cert is not revoked by any of the revocation
+// lists in data. Only the lists that are signed by the issuer of cert count,
+// and at least one of them must be current.
+func checkRevocation(cert *x509.Certificate, issuers []*x509.Certificate, data []byte, now time.Time) error {
+	crls, err := ParseCRLs(data)
+	if err != nil {
+		return err
+	}
+	current := false
+	for _, crl := range crls {
+		if !bytes.Equal(crl.RawIssuer, cert.RawIssuer) || !signedByOneOf(crl, issuers) {
+			continue
+		}
+		for _, revoked := range crl.RevokedCertificateEntries {
+			if revoked.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return fmt.Errorf("certificate with serial number %s was revoked at %s", cert.SerialNumber, revoked.RevocationTime.UTC().Format(time.RFC3339))
+			}
+		}
+		if crl.NextUpdate.IsZero() || now.Before(crl.NextUpdate) {
+			current = true
+		}
+	}
+	if !current {
+		return fmt.Errorf("no current certificate revocation list of %q was found", cert.Issuer.String())
+	}
+	return nil
+}
+
+// signedByOneOf returns whether crl was signed by one of issuers.
+func signedByOneOf(crl *x509.RevocationList, issuers []*x509.Certificate) bool {
+	for _, ca := range issuers {
+		if bytes.Equal(ca.RawSubject, crl.RawIssuer) && crl.CheckSignatureFrom(ca) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// subjectAlternativeNames returns the email addresses, URIs, DNS names and
+// IP addresses of cert.
+func subjectAlternativeNames(cert *x509.Certificate) []string {
+	var ret []string
+	ret = append(ret, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		ret = append(ret, uri.String())
+	}
+	ret = append(ret, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		ret = append(ret, ip.String())
+	}
+	return ret
+}
+
+// extKeyUsages returns the OIDs of the extended key usages of cert.
+func extKeyUsages(cert *x509.Certificate) []string {
+	var ret []string
+	for _, usage := range cert.ExtKeyUsage {
+		if name, ok := extKeyUsageNames[usage]; ok {
+			ret = append(ret, extKeyUsageOIDs[name])
+		}
+	}
+	for _, oid := range cert.UnknownExtKeyUsage {
+		ret = append(ret, oid.String())
+	}
+	return ret
+}
@@ -0,0 +1,286 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+)
+
+var now = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() = %v", err)
+	}
+	return key
+}
+
+func newCA(t *testing.T, name string) testCA {
+	t.Helper()
+	key := newKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name, Organization: []string{"Example Corp"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() = %v", err)
+	}
+	return testCA{cert: cert, key: key}
+}
+
+func (ca testCA) issue(t *testing.T, serial int64) *x509.Certificate {
+	t.Helper()
+	policy, err := x509.ParseOID("1.3.6.1.4.1.99999.1")
+	if err != nil {
+		t.Fatalf("x509.ParseOID() = %v", err)
+	}
+	key := newKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber:       big.NewInt(serial),
+		NotBefore:          now.Add(-time.Hour),
+		NotAfter:           now.Add(time.Hour),
+		EmailAddresses:     []string{"builds@example.com"},
+		DNSNames:           []string{"ci.example.com"},
+		KeyUsage:           x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:        []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		UnknownExtKeyUsage: []asn1.ObjectIdentifier{{1, 3, 6, 1, 4, 1, 99999, 2}},
+		Policies:           []x509.OID{policy},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() = %v", err)
+	}
+	return cert
+}
+
+func (ca testCA) issueCA(t *testing.T, name string) testCA {
+	t.Helper()
+	key := newKey(t)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: name, Organization: []string{"Example Corp"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() = %v", err)
+	}
+	return testCA{cert: cert, key: key}
+}
+
+func (ca testCA) crl(t *testing.T, nextUpdate time.Time, revoked ...int64) string {
+	t.Helper()
+	tmpl := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: now.Add(-time.Hour),
+		NextUpdate: nextUpdate,
+	}
+	for _, serial := range revoked {
+		tmpl.RevokedCertificateEntries = append(tmpl.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   big.NewInt(serial),
+			RevocationTime: now.Add(-time.Minute),
+		})
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, tmpl, ca.cert, ca.key)
+	if err != nil {
+		t.Fatalf("x509.CreateRevocationList() = %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der}))
+}
+
+func TestVerify(t *testing.T) {
+	ca := newCA(t, "Example Code Signing CA")
+	other := newCA(t, "Other CA")
+	cert := ca.issue(t, 42)
+	current := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		ref     v1alpha1.CertificateRef
+		wantErr string
+	}{{
+		name: "no constraints",
+	}, {
+		name: "all constraints",
+		ref: v1alpha1.CertificateRef{
+			SubjectAlternativeNames: []string{"someone@example.com", "builds@example.com"},
+			ExtKeyUsages:            []string{"codeSigning", "1.3.6.1.5.5.7.3.3", "1.3.6.1.4.1.99999.2"},
+			Issuer:                  "CN=Example Code Signing CA,O=Example Corp",
+			PolicyOIDs:              []string{"1.3.6.1.4.1.99999.1"},
+			CRL:                     &v1alpha1.CRLRef{Data: ca.crl(t, current, 7)},
+		},
+	}, {
+		name:    "subject alternative name mismatch",
+		ref:     v1alpha1.CertificateRef{SubjectAlternativeNames: []string{"someone@example.com"}},
+		wantErr: "certificate has none of the subject alternative names [someone@example.com], got [builds@example.com ci.example.com]",
+	}, {
+		name:    "missing extended key usage",
+		ref:     v1alpha1.CertificateRef{ExtKeyUsages: []string{"serverAuth", "1.3.6.1.4.1.99999.3"}},
+		wantErr: "certificate does not have the extended key usage serverAuth\ncertificate does not have the extended key usage 1.3.6.1.4.1.99999.3",
+	}, {
+		name:    "issuer mismatch",
+		ref:     v1alpha1.CertificateRef{Issuer: "CN=Other CA,O=Example Corp"},
+		wantErr: `certificate was issued by "CN=Example Code Signing CA,O=Example Corp", wanted "CN=Other CA,O=Example Corp"`,
+	}, {
+		name:    "missing certificate policy",
+		ref:     v1alpha1.CertificateRef{PolicyOIDs: []string{"1.3.6.1.4.1.99999.3"}},
+		wantErr: "certificate does not have the certificate policy 1.3.6.1.4.1.99999.3",
+	}, {
+		name:    "revoked",
+		ref:     v1alpha1.CertificateRef{CRL: &v1alpha1.CRLRef{Data: ca.crl(t, current, 7, 42)}},
+		wantErr: "certificate with serial number 42 was revoked at 2026-05-31T23:59:00Z",
+	}, {
+		name:    "revoked in an expired list",
+		ref:     v1alpha1.CertificateRef{CRL: &v1alpha1.CRLRef{Data: ca.crl(t, now.Add(-time.Minute), 42) + ca.crl(t, current)}},
+		wantErr: "certificate with serial number 42 was revoked at 2026-05-31T23:59:00Z",
+	}, {
+		name:    "expired list",
+		ref:     v1alpha1.CertificateRef{CRL: &v1alpha1.CRLRef{Data: ca.crl(t, now.Add(-time.Minute))}},
+		wantErr: `no current certificate revocation list of "CN=Example Code Signing CA,O=Example Corp" was found`,
+	}, {
+		name:    "list of another issuer",
+		ref:     v1alpha1.CertificateRef{CRL: &v1alpha1.CRLRef{Data: other.crl(t, current)}},
+		wantErr: `no current certificate revocation list of "CN=Example Code Signing CA,O=Example Corp" was found`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Verify(cert, []*x509.Certificate{ca.cert, other.cert}, &tc.ref, now)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("Verify() = %v, wanted nil", err)
+			case tc.wantErr != "" && err == nil:
+				t.Errorf("Verify() = nil, wanted %q", tc.wantErr)
+			case tc.wantErr != "" && err.Error() != tc.wantErr:
+				t.Errorf("Verify() = %q, wanted %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyUnsignedCRL(t *testing.T) {
+	ca := newCA(t, "Example Code Signing CA")
+	// A list with the same issuer name but signed by another key must not
+	// be trusted.
+	impostor := newCA(t, "Example Code Signing CA")
+	cert := ca.issue(t, 42)
+	ref := &v1alpha1.CertificateRef{CRL: &v1alpha1.CRLRef{Data: impostor.crl(t, now.Add(time.Hour))}}
+	if err := Verify(cert, []*x509.Certificate{ca.cert}, ref, now); err == nil || !strings.HasPrefix(err.Error(), "no current certificate revocation list") {
+		t.Errorf("Verify() = %v, wanted no current certificate revocation list", err)
+	}
+}
+
+func TestPools(t *testing.T) {
+	root := newCA(t, "Root CA")
+	intermediate := root.issue(t, 2)
+
+	_, intermediates := Pools([]*x509.Certificate{root.cert})
+	if intermediates != nil {
+		t.Errorf("Pools() intermediates = %v, wanted nil", intermediates)
+	}
+	roots, intermediates := Pools([]*x509.Certificate{root.cert, intermediate})
+	if !roots.Equal(poolOf(root.cert)) {
+		t.Error("Pools() roots did not contain only the root")
+	}
+	if !intermediates.Equal(poolOf(intermediate)) {
+		t.Error("Pools() intermediates did not contain only the intermediate")
+	}
+}
+
+func TestIssuers(t *testing.T) {
+	root := newCA(t, "Root CA")
+	intermediate := root.issueCA(t, "Intermediate CA")
+	cert := intermediate.issue(t, 42)
+	other := newCA(t, "Other CA")
+
+	if got := Issuers(cert, []*x509.Certificate{root.cert, intermediate.cert}, nil); len(got) != 1 || !got[0].Equal(intermediate.cert) {
+		t.Errorf("Issuers() = %v, wanted the intermediate", got)
+	}
+	// The intermediate can come with the signature.
+	if got := Issuers(cert, []*x509.Certificate{root.cert}, []*x509.Certificate{intermediate.cert}); len(got) != 1 || !got[0].Equal(intermediate.cert) {
+		t.Errorf("Issuers() with chain = %v, wanted the intermediate", got)
+	}
+	// Certificates that do not chain up to the roots are not issuers.
+	if got := Issuers(cert, []*x509.Certificate{other.cert}, []*x509.Certificate{intermediate.cert}); len(got) != 0 {
+		t.Errorf("Issuers() for another root = %v, wanted none", got)
+	}
+}
+
+func TestParseCRLs(t *testing.T) {
+	ca := newCA(t, "Example Code Signing CA")
+	crls, err := ParseCRLs([]byte(ca.crl(t, now) + ca.crl(t, now, 1)))
+	if err != nil {
+		t.Fatalf("ParseCRLs() = %v", err)
+	}
+	if len(crls) != 2 {
+		t.Errorf("ParseCRLs() returned %d lists, wanted 2", len(crls))
+	}
+	if _, err := ParseCRLs([]byte("garbage")); err == nil {
+		t.Error("ParseCRLs() = nil, wanted error")
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+	if _, err := ParseCRLs(certPEM); err == nil {
+		t.Error("ParseCRLs() of a certificate = nil, wanted error")
+	}
+}
+
+func poolOf(certs ...*x509.Certificate) *x509.CertPool {
+	pool := x509.NewCertPool()
+	for _, cert := range certs {
+		pool.AddCert(cert)
+	}
+	return pool
+}
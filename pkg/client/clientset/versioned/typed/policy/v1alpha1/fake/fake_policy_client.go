@@ -30,6 +30,18 @@ func (c *FakePolicyV1alpha1) ClusterImagePolicies() v1alpha1.ClusterImagePolicyI
 	return &FakeClusterImagePolicies{c}
 }
 
+func (c *FakePolicyV1alpha1) ImagePolicies(namespace string) v1alpha1.ImagePolicyInterface {
+	return &FakeImagePolicies{c, namespace}
+}
+
+func (c *FakePolicyV1alpha1) PolicyExceptions() v1alpha1.PolicyExceptionInterface {
+	return &FakePolicyExceptions{c}
+}
+
+func (c *FakePolicyV1alpha1) Revocations() v1alpha1.RevocationInterface {
+	return &FakeRevocations{c}
+}
+
 func (c *FakePolicyV1alpha1) TrustRoots() v1alpha1.TrustRootInterface {
 	return &FakeTrustRoots{c}
 }
@@ -18,4 +18,10 @@ package v1alpha1
 
 type ClusterImagePolicyExpansion interface{}
 
+type ImagePolicyExpansion interface{}
+
+type PolicyExceptionExpansion interface{}
+
+type RevocationExpansion interface{}
+
 type TrustRootExpansion interface{}
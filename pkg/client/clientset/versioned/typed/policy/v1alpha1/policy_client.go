@@ -27,6 +27,9 @@ import (
 type PolicyV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	ClusterImagePoliciesGetter
+	ImagePoliciesGetter
+	PolicyExceptionsGetter
+	RevocationsGetter
 	TrustRootsGetter
 }
 
@@ -39,6 +42,18 @@ func (c *PolicyV1alpha1Client) ClusterImagePolicies() ClusterImagePolicyInterfac
 	return newClusterImagePolicies(c)
 }
 
+func (c *PolicyV1alpha1Client) ImagePolicies(namespace string) ImagePolicyInterface {
+	return newImagePolicies(c, namespace)
+}
+
+func (c *PolicyV1alpha1Client) PolicyExceptions() PolicyExceptionInterface {
+	return newPolicyExceptions(c)
+}
+
+func (c *PolicyV1alpha1Client) Revocations() RevocationInterface {
+	return newRevocations(c)
+}
+
 func (c *PolicyV1alpha1Client) TrustRoots() TrustRootInterface {
 	return newTrustRoots(c)
 }
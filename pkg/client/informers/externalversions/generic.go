@@ -54,6 +54,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=policy.sigstore.dev, Version=v1alpha1
 	case v1alpha1.SchemeGroupVersion.WithResource("clusterimagepolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().ClusterImagePolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("imagepolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().ImagePolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("policyexceptions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().PolicyExceptions().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("revocations"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().Revocations().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("trustroots"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Policy().V1alpha1().TrustRoots().Informer()}, nil
 
@@ -24,6 +24,12 @@ import (
 type Interface interface {
 	// ClusterImagePolicies returns a ClusterImagePolicyInformer.
 	ClusterImagePolicies() ClusterImagePolicyInformer
+	// ImagePolicies returns a ImagePolicyInformer.
+	ImagePolicies() ImagePolicyInformer
+	// PolicyExceptions returns a PolicyExceptionInformer.
+	PolicyExceptions() PolicyExceptionInformer
+	// Revocations returns a RevocationInformer.
+	Revocations() RevocationInformer
 	// TrustRoots returns a TrustRootInformer.
 	TrustRoots() TrustRootInformer
 }
@@ -44,6 +50,21 @@ func (v *version) ClusterImagePolicies() ClusterImagePolicyInformer {
 	return &clusterImagePolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ImagePolicies returns a ImagePolicyInformer.
+func (v *version) ImagePolicies() ImagePolicyInformer {
+	return &imagePolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// PolicyExceptions returns a PolicyExceptionInformer.
+func (v *version) PolicyExceptions() PolicyExceptionInformer {
+	return &policyExceptionInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// Revocations returns a RevocationInformer.
+func (v *version) Revocations() RevocationInformer {
+	return &revocationInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // TrustRoots returns a TrustRootInformer.
 func (v *version) TrustRoots() TrustRootInformer {
 	return &trustRootInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -20,6 +20,22 @@ package v1alpha1
 // ClusterImagePolicyLister.
 type ClusterImagePolicyListerExpansion interface{}
 
+// ImagePolicyListerExpansion allows custom methods to be added to
+// ImagePolicyLister.
+type ImagePolicyListerExpansion interface{}
+
+// ImagePolicyNamespaceListerExpansion allows custom methods to be added to
+// ImagePolicyNamespaceLister.
+type ImagePolicyNamespaceListerExpansion interface{}
+
+// PolicyExceptionListerExpansion allows custom methods to be added to
+// PolicyExceptionLister.
+type PolicyExceptionListerExpansion interface{}
+
+// RevocationListerExpansion allows custom methods to be added to
+// RevocationLister.
+type RevocationListerExpansion interface{}
+
 // TrustRootListerExpansion allows custom methods to be added to
 // TrustRootLister.
 type TrustRootListerExpansion interface{}
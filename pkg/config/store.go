@@ -17,6 +17,8 @@ package config
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
@@ -45,6 +47,14 @@ const (
 	FailOnEmptyAuthorities = "fail-on-empty-authorities"
 
 	EnableOCI11 = "enable-oci11"
+
+	// DecisionLogKey configures where the admission decisions are logged to,
+	// one of "stdout", a "file://" URL or an "http://" or "https://"
+	// endpoint. Decisions are not logged if empty.
+	DecisionLogKey = "decision-log"
+
+	// DecisionLogStdout logs the admission decisions to stdout.
+	DecisionLogStdout = "stdout"
 )
 
 // PolicyControllerConfig controls the behaviour of policy-controller that needs
@@ -60,6 +70,9 @@ type PolicyControllerConfig struct {
 	FailOnEmptyAuthorities bool `json:"fail-on-empty-authorities"`
 	// EnableOCI11 enables experimental OCI 1.1 referrers API for attestation discovery
 	EnableOCI11 bool `json:"enable-oci11"`
+	// DecisionLog is where the admission decisions are logged to, see
+	// DecisionLogKey.
+	DecisionLog string `json:"decision-log,omitempty"`
 }
 
 func NewPolicyControllerConfigFromMap(data map[string]string) (*PolicyControllerConfig, error) {
@@ -88,9 +101,38 @@ func NewPolicyControllerConfigFromMap(data map[string]string) (*PolicyController
 			return ret, err
 		}
 	}
+	if val, ok := data[DecisionLogKey]; ok {
+		if err := validateDecisionLog(val); err != nil {
+			return ret, err
+		}
+		ret.DecisionLog = val
+	}
 	return ret, nil
 }
 
+func validateDecisionLog(val string) error {
+	if val == "" || val == DecisionLogStdout {
+		return nil
+	}
+	u, err := url.Parse(val)
+	if err != nil {
+		return fmt.Errorf("invalid %s %q: %w", DecisionLogKey, val, err)
+	}
+	switch u.Scheme {
+	case "file":
+		if u.Path == "" {
+			return fmt.Errorf("invalid %s %q: missing file path", DecisionLogKey, val)
+		}
+	case "http", "https":
+		if u.Host == "" {
+			return fmt.Errorf("invalid %s %q: missing host", DecisionLogKey, val)
+		}
+	default:
+		return fmt.Errorf("invalid %s %q: must be %q, a file:// URL or an http(s):// URL", DecisionLogKey, val, DecisionLogStdout)
+	}
+	return nil
+}
+
 func NewPolicyControllerConfigFromConfigMap(config *corev1.ConfigMap) (*PolicyControllerConfig, error) {
 	return NewPolicyControllerConfigFromMap(config.Data)
 }
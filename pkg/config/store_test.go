@@ -135,6 +135,53 @@ func TestEnableOCI11Config(t *testing.T) {
 	}
 }
 
+func TestDecisionLogConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    string
+		wantErr bool
+	}{{
+		name: "not set",
+		data: map[string]string{},
+	}, {
+		name: "stdout",
+		data: map[string]string{"decision-log": "stdout"},
+		want: "stdout",
+	}, {
+		name: "file",
+		data: map[string]string{"decision-log": "file:///var/log/decisions.log"},
+		want: "file:///var/log/decisions.log",
+	}, {
+		name: "http endpoint",
+		data: map[string]string{"decision-log": "https://audit.example.com/decisions"},
+		want: "https://audit.example.com/decisions",
+	}, {
+		name:    "file without path",
+		data:    map[string]string{"decision-log": "file://"},
+		wantErr: true,
+	}, {
+		name:    "http without host",
+		data:    map[string]string{"decision-log": "http:///decisions"},
+		wantErr: true,
+	}, {
+		name:    "unknown sink",
+		data:    map[string]string{"decision-log": "syslog"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := NewPolicyControllerConfigFromMap(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPolicyControllerConfigFromMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.DecisionLog != tt.want {
+				t.Errorf("DecisionLog = %q, want %q", cfg.DecisionLog, tt.want)
+			}
+		})
+	}
+}
+
 func TestFromContextOrDefaultsWithOCI11(t *testing.T) {
 	// Test default returns EnableOCI11 = false
 	cfg := FromContextOrDefaults(context.Background())
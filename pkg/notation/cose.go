@@ -0,0 +1,198 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notation
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// COSE labels of the headers that a COSE envelope uses.
+// See also: https://github.com/notaryproject/specifications/blob/main/specs/signature-envelope-cose.md
+const (
+	coseSign1Tag     = 18
+	coseAlgorithm    = int64(1)
+	coseCritical     = int64(2)
+	coseContentType  = int64(3)
+	coseX5Chain      = int64(33)
+	coseSignature1   = "Signature1"
+	coseMaxChainSize = 10
+)
+
+// coseAlgorithms maps the COSE algorithm identifiers to the names of the JWS
+// algorithms.
+var coseAlgorithms = map[int64]string{
+	-7:  "ES256",
+	-35: "ES384",
+	-36: "ES512",
+	-37: "PS256",
+	-38: "PS384",
+	-39: "PS512",
+}
+
+// coseSign1 is a COSE_Sign1 message.
+type coseSign1 struct {
+	_           struct{} `cbor:",toarray"`
+	Protected   []byte
+	Unprotected map[interface{}]cbor.RawMessage
+	Payload     []byte
+	Signature   []byte
+}
+
+// parseCOSE parses a COSE envelope and verifies its signature.
+func parseCOSE(envelope []byte) (*header, []byte, []*x509.Certificate, error) {
+	var tag cbor.RawTag
+	if err := cbor.Unmarshal(envelope, &tag); err != nil {
+		return nil, nil, nil, fmt.Errorf("unmarshaling COSE envelope: %w", err)
+	}
+	if tag.Number != coseSign1Tag {
+		return nil, nil, nil, fmt.Errorf("COSE envelope has tag %d, not a COSE_Sign1 message", tag.Number)
+	}
+	var msg coseSign1
+	if err := cbor.Unmarshal(tag.Content, &msg); err != nil {
+		return nil, nil, nil, fmt.Errorf("unmarshaling COSE_Sign1 message: %w", err)
+	}
+	if msg.Payload == nil {
+		return nil, nil, nil, errors.New("COSE_Sign1 message has a detached payload")
+	}
+
+	ders, err := coseX5ChainOf(msg.Unprotected)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	chain, err := parseCertificateChain(ders)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var rawProtected map[interface{}]cbor.RawMessage
+	if err := cbor.Unmarshal(msg.Protected, &rawProtected); err != nil {
+		return nil, nil, nil, fmt.Errorf("unmarshaling COSE protected headers: %w", err)
+	}
+	protected := coseHeaders(rawProtected)
+	var algorithm int64
+	if err := unmarshalCOSEHeader(protected, coseAlgorithm, &algorithm); err != nil {
+		return nil, nil, nil, err
+	}
+	alg, ok := coseAlgorithms[algorithm]
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("unsupported COSE algorithm %d", algorithm)
+	}
+	signed, err := cbor.Marshal([]interface{}{coseSignature1, msg.Protected, []byte{}, msg.Payload})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("marshaling COSE Sig_structure: %w", err)
+	}
+	if err := verifySignature(alg, chain[0], signed, msg.Signature); err != nil {
+		return nil, nil, nil, err
+	}
+
+	h := &header{}
+	var critical []interface{}
+	for _, err := range []error{
+		unmarshalCOSEHeader(protected, coseContentType, &h.contentType),
+		unmarshalCOSEHeader(protected, coseCritical, &critical),
+		unmarshalCOSEHeader(protected, headerSigningScheme, &h.signingScheme),
+		unmarshalOptionalCOSEHeader(protected, headerSigningTime, &h.signingTime),
+		unmarshalOptionalCOSEHeader(protected, headerAuthenticSigningTime, &h.authenticSigningTime),
+		unmarshalOptionalCOSEHeader(protected, headerExpiry, &h.expiry),
+	} {
+		if err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	for _, label := range critical {
+		name, ok := label.(string)
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("unsupported critical header %v", label)
+		}
+		h.critical = append(h.critical, name)
+	}
+	return h, msg.Payload, chain, nil
+}
+
+// coseX5ChainOf returns the DER encoded certificates of the x5chain header.
+func coseX5ChainOf(unprotected map[interface{}]cbor.RawMessage) ([][]byte, error) {
+	raw, ok := coseHeaders(unprotected)[coseX5Chain]
+	if !ok {
+		return nil, nil
+	}
+	var x5chain interface{}
+	if err := cbor.Unmarshal(raw, &x5chain); err != nil {
+		return nil, fmt.Errorf("unmarshaling COSE x5chain: %w", err)
+	}
+	switch x5chain := x5chain.(type) {
+	case []byte:
+		// A chain of a single certificate is not wrapped in an array.
+		return [][]byte{x5chain}, nil
+	case []interface{}:
+		if len(x5chain) > coseMaxChainSize {
+			return nil, fmt.Errorf("COSE x5chain has more than %d certificates", coseMaxChainSize)
+		}
+		ders := make([][]byte, 0, len(x5chain))
+		for _, cert := range x5chain {
+			der, ok := cert.([]byte)
+			if !ok {
+				return nil, errors.New("COSE x5chain must contain byte strings")
+			}
+			ders = append(ders, der)
+		}
+		return ders, nil
+	}
+	return nil, errors.New("COSE x5chain must be a byte string or an array of byte strings")
+}
+
+// coseHeaders normalizes the integer labels of COSE headers to int64, so
+// that they can be looked up regardless of their sign.
+func coseHeaders(headers map[interface{}]cbor.RawMessage) map[interface{}]cbor.RawMessage {
+	ret := make(map[interface{}]cbor.RawMessage, len(headers))
+	for label, value := range headers {
+		if u, ok := label.(uint64); ok && u <= math.MaxInt64 {
+			label = int64(u)
+		}
+		ret[label] = value
+	}
+	return ret
+}
+
+// unmarshalCOSEHeader unmarshals the required header with the given label
+// into v.
+func unmarshalCOSEHeader(headers map[interface{}]cbor.RawMessage, label interface{}, v interface{}) error {
+	if _, ok := headers[label]; !ok {
+		return fmt.Errorf("COSE protected header %v is missing", label)
+	}
+	return unmarshalOptionalCOSEHeader(headers, label, v)
+}
+
+// unmarshalOptionalCOSEHeader unmarshals the header with the given label, if
+// any, into v.
+func unmarshalOptionalCOSEHeader(headers map[interface{}]cbor.RawMessage, label interface{}, v interface{}) error {
+	raw, ok := headers[label]
+	if !ok {
+		return nil
+	}
+	if err := cbor.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("unmarshaling COSE protected header %v: %w", label, err)
+	}
+	if t, ok := v.(*time.Time); ok && t.IsZero() {
+		return fmt.Errorf("COSE protected header %v is not a time", label)
+	}
+	return nil
+}
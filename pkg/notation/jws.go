@@ -0,0 +1,94 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notation
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// jwsEnvelope is the flattened JSON serialization of a JWS envelope.
+// See also: https://github.com/notaryproject/specifications/blob/main/specs/signature-envelope-jws.md
+type jwsEnvelope struct {
+	Payload   string `json:"payload"`
+	Protected string `json:"protected"`
+	Header    struct {
+		CertificateChain [][]byte `json:"x5c"`
+	} `json:"header"`
+	Signature string `json:"signature"`
+}
+
+// jwsProtectedHeader are the protected headers of a JWS envelope.
+type jwsProtectedHeader struct {
+	Algorithm            string     `json:"alg"`
+	ContentType          string     `json:"cty"`
+	Critical             []string   `json:"crit"`
+	SigningScheme        string     `json:"io.cncf.notary.signingScheme"`
+	SigningTime          *time.Time `json:"io.cncf.notary.signingTime"`
+	AuthenticSigningTime *time.Time `json:"io.cncf.notary.authenticSigningTime"`
+	Expiry               *time.Time `json:"io.cncf.notary.expiry"`
+}
+
+// parseJWS parses a JWS envelope and verifies its signature.
+func parseJWS(envelope []byte) (*header, []byte, []*x509.Certificate, error) {
+	var env jwsEnvelope
+	if err := json.Unmarshal(envelope, &env); err != nil {
+		return nil, nil, nil, fmt.Errorf("unmarshaling JWS envelope: %w", err)
+	}
+	chain, err := parseCertificateChain(env.Header.CertificateChain)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	protected, err := base64.RawURLEncoding.DecodeString(env.Protected)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding JWS protected headers: %w", err)
+	}
+	var ph jwsProtectedHeader
+	if err := json.Unmarshal(protected, &ph); err != nil {
+		return nil, nil, nil, fmt.Errorf("unmarshaling JWS protected headers: %w", err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(env.Signature)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding JWS signature: %w", err)
+	}
+	if err := verifySignature(ph.Algorithm, chain[0], []byte(env.Protected+"."+env.Payload), signature); err != nil {
+		return nil, nil, nil, err
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("decoding JWS payload: %w", err)
+	}
+
+	h := &header{
+		contentType:   ph.ContentType,
+		critical:      ph.Critical,
+		signingScheme: ph.SigningScheme,
+	}
+	if ph.SigningTime != nil {
+		h.signingTime = *ph.SigningTime
+	}
+	if ph.AuthenticSigningTime != nil {
+		h.authenticSigningTime = *ph.AuthenticSigningTime
+	}
+	if ph.Expiry != nil {
+		h.expiry = *ph.Expiry
+	}
+	return h, payload, chain, nil
+}
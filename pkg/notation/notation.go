@@ -0,0 +1,354 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notation verifies Notary Project signatures, in JWS or COSE
+// envelopes, against a trust store and the trusted identities of a trust
+// policy.
+// See also: https://github.com/notaryproject/specifications
+package notation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/common"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+)
+
+const (
+	// ArtifactType is the artifact type of the OCI manifests that store
+	// Notary Project signatures as referrers of the signed image.
+	ArtifactType = "application/vnd.cncf.notary.signature"
+
+	// MediaTypeJWS is the media type of JWS signature envelopes.
+	MediaTypeJWS = "application/jose+json"
+	// MediaTypeCOSE is the media type of COSE signature envelopes.
+	MediaTypeCOSE = "application/cose"
+	// MediaTypePayload is the content type of the signed payload.
+	MediaTypePayload = "application/vnd.cncf.notary.payload.v1+json"
+
+	// SigningSchemeX509 is the signing scheme of signatures whose signing
+	// time is claimed by the signer.
+	SigningSchemeX509 = "notary.x509"
+	// SigningSchemeX509SigningAuthority is the signing scheme of signatures
+	// whose signing time is asserted by a signing authority.
+	SigningSchemeX509SigningAuthority = "notary.x509.signingAuthority"
+
+	headerSigningScheme        = "io.cncf.notary.signingScheme"
+	headerSigningTime          = "io.cncf.notary.signingTime"
+	headerAuthenticSigningTime = "io.cncf.notary.authenticSigningTime"
+	headerExpiry               = "io.cncf.notary.expiry"
+)
+
+// signingSchemes maps the types of trust stores to the signing scheme of
+// the signatures that they can verify.
+var signingSchemes = map[string]string{
+	"ca":               SigningSchemeX509,
+	"signingAuthority": SigningSchemeX509SigningAuthority,
+}
+
+// criticalHeaders are the protected headers that may be marked critical.
+var criticalHeaders = map[string]bool{
+	headerSigningScheme:        true,
+	headerAuthenticSigningTime: true,
+	headerExpiry:               true,
+}
+
+// Descriptor describes the artifact that a signature was made for.
+type Descriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Signature is the content of a Notary Project signature envelope whose
+// signature has been verified with the key of its signing certificate.
+type Signature struct {
+	// TargetArtifact is the artifact that was signed.
+	TargetArtifact Descriptor
+	// SigningScheme is either SigningSchemeX509 or
+	// SigningSchemeX509SigningAuthority.
+	SigningScheme string
+	// SigningTime is the time of signing, as claimed by the signer for
+	// SigningSchemeX509 or asserted by the signing authority for
+	// SigningSchemeX509SigningAuthority.
+	SigningTime time.Time
+	// Expiry is the time the signature expires at, if any.
+	Expiry time.Time
+	// CertificateChain is the signing certificate followed by the
+	// intermediate certificates, and optionally the root certificate.
+	CertificateChain []*x509.Certificate
+}
+
+// header holds the protected headers of either envelope format.
+type header struct {
+	contentType          string
+	critical             []string
+	signingScheme        string
+	signingTime          time.Time
+	authenticSigningTime time.Time
+	expiry               time.Time
+}
+
+// ParseEnvelope parses a Notary Project signature envelope of the given
+// media type and verifies its signature with the key of the signing
+// certificate. The certificate chain is checked by Verify.
+func ParseEnvelope(mediaType string, envelope []byte) (*Signature, error) {
+	var (
+		h       *header
+		payload []byte
+		chain   []*x509.Certificate
+		err     error
+	)
+	switch mediaType {
+	case MediaTypeJWS:
+		h, payload, chain, err = parseJWS(envelope)
+	case MediaTypeCOSE:
+		h, payload, chain, err = parseCOSE(envelope)
+	default:
+		return nil, fmt.Errorf("unsupported signature envelope media type %q", mediaType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newSignature(h, payload, chain)
+}
+
+// newSignature checks the protected headers and the payload of a verified
+// envelope.
+func newSignature(h *header, payload []byte, chain []*x509.Certificate) (*Signature, error) {
+	if h.contentType != MediaTypePayload {
+		return nil, fmt.Errorf("unsupported payload content type %q", h.contentType)
+	}
+	for _, name := range h.critical {
+		if !criticalHeaders[name] {
+			return nil, fmt.Errorf("unsupported critical header %q", name)
+		}
+	}
+	mustBeCritical := []string{headerSigningScheme}
+	if !h.expiry.IsZero() {
+		mustBeCritical = append(mustBeCritical, headerExpiry)
+	}
+	sig := &Signature{
+		SigningScheme:    h.signingScheme,
+		Expiry:           h.expiry,
+		CertificateChain: chain,
+	}
+	switch h.signingScheme {
+	case SigningSchemeX509:
+		sig.SigningTime = h.signingTime
+	case SigningSchemeX509SigningAuthority:
+		sig.SigningTime = h.authenticSigningTime
+		mustBeCritical = append(mustBeCritical, headerAuthenticSigningTime)
+	default:
+		return nil, fmt.Errorf("unsupported signing scheme %q", h.signingScheme)
+	}
+	if sig.SigningTime.IsZero() {
+		return nil, fmt.Errorf("signature of signing scheme %s has no signing time", h.signingScheme)
+	}
+	for _, name := range mustBeCritical {
+		if !slices.Contains(h.critical, name) {
+			return nil, fmt.Errorf("header %q must be critical", name)
+		}
+	}
+
+	var p struct {
+		TargetArtifact Descriptor `json:"targetArtifact"`
+	}
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, fmt.Errorf("unmarshaling payload: %w", err)
+	}
+	if p.TargetArtifact.Digest == "" {
+		return nil, errors.New("payload has no target artifact digest")
+	}
+	sig.TargetArtifact = p.TargetArtifact
+	return sig, nil
+}
+
+// Verify checks that sig was made for the artifact with the given digest,
+// that the signing certificate chains up to roots and is one of the trusted
+// identities of ref, and that neither the signature nor the certificates
+// had expired.
+// Signatures of the notary.x509 scheme are checked at now, as their signing
+// time is only claimed by the signer, while signatures of the
+// notary.x509.signingAuthority scheme are checked at their signing time.
+func Verify(sig *Signature, digest string, roots []*x509.Certificate, ref *v1alpha1.NotationRef, now time.Time) error {
+	if sig.TargetArtifact.Digest != digest {
+		return fmt.Errorf("signature was made for %s, not %s", sig.TargetArtifact.Digest, digest)
+	}
+	trustStoreType := ref.TrustStoreType
+	if trustStoreType == "" {
+		trustStoreType = "ca"
+	}
+	if want := signingSchemes[trustStoreType]; sig.SigningScheme != want {
+		return fmt.Errorf("signature of signing scheme %s cannot be verified with a trust store of type %s", sig.SigningScheme, trustStoreType)
+	}
+	if !sig.Expiry.IsZero() && now.After(sig.Expiry) {
+		return fmt.Errorf("signature expired at %s", sig.Expiry.Format(time.RFC3339))
+	}
+
+	leaf := sig.CertificateChain[0]
+	if leaf.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+		return errors.New("signing certificate does not have the digitalSignature key usage")
+	}
+	verifyAt := now
+	if sig.SigningScheme == SigningSchemeX509SigningAuthority {
+		verifyAt = sig.SigningTime
+	}
+	opts := x509.VerifyOptions{
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   verifyAt,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}
+	for _, root := range roots {
+		opts.Roots.AddCert(root)
+	}
+	for _, cert := range sig.CertificateChain[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(opts); err != nil {
+		return fmt.Errorf("verifying certificate chain: %w", err)
+	}
+	return verifyTrustedIdentity(leaf, ref.TrustedIdentities)
+}
+
+// verifyTrustedIdentity checks that the subject of the signing certificate
+// contains all the attributes of one of the trusted identities.
+func verifyTrustedIdentity(leaf *x509.Certificate, trustedIdentities []string) error {
+	if len(trustedIdentities) == 0 || slices.Contains(trustedIdentities, "*") {
+		return nil
+	}
+	subject, err := common.ParseDistinguishedName(leaf.Subject.String())
+	if err != nil {
+		return fmt.Errorf("parsing signing certificate subject: %w", err)
+	}
+	for _, identity := range trustedIdentities {
+		attributes, err := common.ParseTrustedIdentity(identity)
+		if err != nil {
+			return err
+		}
+		if isSubset(attributes, subject) {
+			return nil
+		}
+	}
+	return fmt.Errorf("signing certificate subject %q is not a trusted identity", leaf.Subject.String())
+}
+
+// verifySignature verifies signature over signed with the key of cert,
+// which must match the signature algorithm alg.
+func verifySignature(alg string, cert *x509.Certificate, signed, signature []byte) error {
+	want, err := signatureAlgorithm(cert.PublicKey)
+	if err != nil {
+		return err
+	}
+	if alg != want {
+		return fmt.Errorf("signature algorithm %s does not match the %s key of the signing certificate", alg, want)
+	}
+	var hash crypto.Hash
+	switch alg {
+	case "PS256", "ES256":
+		hash = crypto.SHA256
+	case "PS384", "ES384":
+		hash = crypto.SHA384
+	default:
+		hash = crypto.SHA512
+	}
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPSS(pub, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}); err != nil {
+			return fmt.Errorf("invalid signature: %w", err)
+		}
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid signature: unexpected length")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("invalid signature")
+		}
+	}
+	return nil
+}
+
+// signatureAlgorithm returns the signature algorithm that the Notary Project
+// requires for a key.
+func signatureAlgorithm(pub crypto.PublicKey) (string, error) {
+	switch pub := pub.(type) {
+	case *rsa.PublicKey:
+		switch pub.Size() * 8 {
+		case 2048:
+			return "PS256", nil
+		case 3072:
+			return "PS384", nil
+		case 4096:
+			return "PS512", nil
+		}
+		return "", fmt.Errorf("unsupported RSA key size %d", pub.Size()*8)
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256():
+			return "ES256", nil
+		case elliptic.P384():
+			return "ES384", nil
+		case elliptic.P521():
+			return "ES512", nil
+		}
+		return "", fmt.Errorf("unsupported elliptic curve %s", pub.Curve.Params().Name)
+	}
+	return "", fmt.Errorf("unsupported key type %T", pub)
+}
+
+// parseCertificateChain parses the DER encoded certificate chain of an
+// envelope, starting with the signing certificate.
+func parseCertificateChain(ders [][]byte) ([]*x509.Certificate, error) {
+	if len(ders) == 0 {
+		return nil, errors.New("signature has no certificate chain")
+	}
+	chain := make([]*x509.Certificate, 0, len(ders))
+	for _, der := range ders {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, fmt.Errorf("parsing certificate chain: %w", err)
+		}
+		chain = append(chain, cert)
+	}
+	return chain, nil
+}
+
+func isSubset(attributes, of map[string]string) bool {
+	for typ, value := range attributes {
+		if of[typ] != value {
+			return false
+		}
+	}
+	return true
+}
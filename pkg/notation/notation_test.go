@@ -0,0 +1,525 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notation
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+)
+
+const testDigest = "sha256:4e6a6c8ae0c0d3fa0b9b1e8c8d0bd6a3b5b87b0cc8a4ec6cbf08fdf0e3a0e0a1"
+
+var (
+	now      = time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	leafName = pkix.Name{
+		Country:      []string{"US"},
+		Province:     []string{"WA"},
+		Locality:     []string{"Seattle"},
+		Organization: []string{"Example Corp"},
+		CommonName:   "Example Signer",
+	}
+)
+
+// testPKI is a root certificate authority and a signing certificate that it
+// issued.
+type testPKI struct {
+	root    *x509.Certificate
+	leaf    *x509.Certificate
+	leafKey crypto.Signer
+}
+
+func newPKI(t *testing.T, leafKey crypto.Signer, template *x509.Certificate) *testPKI {
+	t.Helper()
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Example Root CA"},
+		NotBefore:             now.Add(-24 * time.Hour),
+		NotAfter:              now.Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	root := createCertificate(t, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	if template == nil {
+		template = &x509.Certificate{}
+	}
+	template.SerialNumber = big.NewInt(2)
+	if template.Subject.String() == "" {
+		template.Subject = leafName
+	}
+	if template.NotBefore.IsZero() {
+		template.NotBefore = now.Add(-time.Hour)
+		template.NotAfter = now.Add(time.Hour)
+	}
+	if template.KeyUsage == 0 {
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+	}
+	if template.ExtKeyUsage == nil {
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning}
+	}
+	leaf := createCertificate(t, template, root, leafKey.Public(), rootKey)
+	return &testPKI{root: root, leaf: leaf, leafKey: leafKey}
+}
+
+func createCertificate(t *testing.T, template, parent *x509.Certificate, pub crypto.PublicKey, key crypto.Signer) *x509.Certificate {
+	t.Helper()
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func ecdsaKey(t *testing.T) crypto.Signer {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func rsaKey(t *testing.T) crypto.Signer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+// sign signs with the key as the Notary Project requires, encoding ECDSA
+// signatures as r || s.
+func sign(t *testing.T, key crypto.Signer, signed []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(signed)
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		sig, err := rsa.SignPSS(rand.Reader, key, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig := make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+		return sig
+	}
+	t.Fatalf("unsupported key %T", key)
+	return nil
+}
+
+func algorithm(key crypto.Signer) string {
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		return "PS256"
+	}
+	return "ES256"
+}
+
+func payload(t *testing.T, digest string) []byte {
+	t.Helper()
+	b, err := json.Marshal(map[string]Descriptor{"targetArtifact": {
+		MediaType: "application/vnd.oci.image.manifest.v1+json",
+		Digest:    digest,
+		Size:      1234,
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// jwsHeaders returns the protected headers of a notary.x509 signature,
+// which modify can change.
+func jwsHeaders(alg string) map[string]interface{} {
+	return map[string]interface{}{
+		"alg":                 alg,
+		"cty":                 MediaTypePayload,
+		"crit":                []string{headerSigningScheme},
+		headerSigningScheme:   SigningSchemeX509,
+		headerSigningTime:     now.Add(-time.Minute).Format(time.RFC3339),
+		"io.cncf.notary.note": "not critical",
+	}
+}
+
+func signJWS(t *testing.T, pki *testPKI, headers map[string]interface{}, digest string) []byte {
+	t.Helper()
+	h, err := json.Marshal(headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	protected := base64.RawURLEncoding.EncodeToString(h)
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload(t, digest))
+	env := map[string]interface{}{
+		"payload":   encodedPayload,
+		"protected": protected,
+		"header": map[string]interface{}{
+			"x5c":                         [][]byte{pki.leaf.Raw, pki.root.Raw},
+			"io.cncf.notary.signingAgent": "notation-go/1.3.0",
+		},
+		"signature": base64.RawURLEncoding.EncodeToString(sign(t, pki.leafKey, []byte(protected+"."+encodedPayload))),
+	}
+	b, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// coseHeadersFor returns the protected headers of a notary.x509 signature,
+// which modify can change.
+func coseHeadersFor(alg int64) map[interface{}]interface{} {
+	return map[interface{}]interface{}{
+		coseAlgorithm:       alg,
+		coseCritical:        []interface{}{headerSigningScheme},
+		coseContentType:     MediaTypePayload,
+		headerSigningScheme: SigningSchemeX509,
+		headerSigningTime:   cbor.Tag{Number: 1, Content: now.Add(-time.Minute).Unix()},
+	}
+}
+
+func signCOSE(t *testing.T, pki *testPKI, headers map[interface{}]interface{}, digest string, x5chain interface{}) []byte {
+	t.Helper()
+	protected, err := cbor.Marshal(headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := payload(t, digest)
+	signed, err := cbor.Marshal([]interface{}{coseSignature1, protected, []byte{}, p})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []interface{}{
+		protected,
+		map[interface{}]interface{}{coseX5Chain: x5chain, "io.cncf.notary.signingAgent": "notation-go/1.3.0"},
+		p,
+		sign(t, pki.leafKey, signed),
+	}
+	b, err := cbor.Marshal(cbor.Tag{Number: coseSign1Tag, Content: msg})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestParseEnvelope(t *testing.T) {
+	ecPKI := newPKI(t, ecdsaKey(t), nil)
+	rsaPKI := newPKI(t, rsaKey(t), nil)
+	otherPKI := newPKI(t, ecdsaKey(t), nil)
+
+	tests := []struct {
+		name      string
+		mediaType string
+		envelope  func() []byte
+		wantErr   string
+	}{{
+		name:      "JWS with ECDSA",
+		mediaType: MediaTypeJWS,
+		envelope:  func() []byte { return signJWS(t, ecPKI, jwsHeaders("ES256"), testDigest) },
+	}, {
+		name:      "JWS with RSA",
+		mediaType: MediaTypeJWS,
+		envelope:  func() []byte { return signJWS(t, rsaPKI, jwsHeaders("PS256"), testDigest) },
+	}, {
+		name:      "JWS signing authority",
+		mediaType: MediaTypeJWS,
+		envelope: func() []byte {
+			h := jwsHeaders("ES256")
+			delete(h, headerSigningTime)
+			h[headerSigningScheme] = SigningSchemeX509SigningAuthority
+			h[headerAuthenticSigningTime] = now.Format(time.RFC3339)
+			h["crit"] = []string{headerSigningScheme, headerAuthenticSigningTime}
+			return signJWS(t, ecPKI, h, testDigest)
+		},
+	}, {
+		name:      "JWS algorithm does not match the key",
+		mediaType: MediaTypeJWS,
+		envelope:  func() []byte { return signJWS(t, ecPKI, jwsHeaders("PS256"), testDigest) },
+		wantErr:   "signature algorithm PS256 does not match the ES256 key of the signing certificate",
+	}, {
+		name:      "JWS signed by another key",
+		mediaType: MediaTypeJWS,
+		envelope: func() []byte {
+			env := signJWS(t, ecPKI, jwsHeaders("ES256"), testDigest)
+			other := signJWS(t, otherPKI, jwsHeaders("ES256"), testDigest)
+			var e, o map[string]interface{}
+			_ = json.Unmarshal(env, &e)
+			_ = json.Unmarshal(other, &o)
+			e["header"] = o["header"]
+			b, _ := json.Marshal(e)
+			return b
+		},
+		wantErr: "invalid signature",
+	}, {
+		name:      "JWS with an unsupported critical header",
+		mediaType: MediaTypeJWS,
+		envelope: func() []byte {
+			h := jwsHeaders("ES256")
+			h["crit"] = []string{headerSigningScheme, "io.cncf.notary.unknown"}
+			return signJWS(t, ecPKI, h, testDigest)
+		},
+		wantErr: `unsupported critical header "io.cncf.notary.unknown"`,
+	}, {
+		name:      "JWS expiry is not critical",
+		mediaType: MediaTypeJWS,
+		envelope: func() []byte {
+			h := jwsHeaders("ES256")
+			h[headerExpiry] = now.Add(time.Hour).Format(time.RFC3339)
+			return signJWS(t, ecPKI, h, testDigest)
+		},
+		wantErr: `header "io.cncf.notary.expiry" must be critical`,
+	}, {
+		name:      "JWS without signing time",
+		mediaType: MediaTypeJWS,
+		envelope: func() []byte {
+			h := jwsHeaders("ES256")
+			delete(h, headerSigningTime)
+			return signJWS(t, ecPKI, h, testDigest)
+		},
+		wantErr: "signature of signing scheme notary.x509 has no signing time",
+	}, {
+		name:      "JWS with an unsupported content type",
+		mediaType: MediaTypeJWS,
+		envelope: func() []byte {
+			h := jwsHeaders("ES256")
+			h["cty"] = "application/vnd.in-toto+json"
+			return signJWS(t, ecPKI, h, testDigest)
+		},
+		wantErr: `unsupported payload content type "application/vnd.in-toto+json"`,
+	}, {
+		name:      "COSE with ECDSA",
+		mediaType: MediaTypeCOSE,
+		envelope: func() []byte {
+			return signCOSE(t, ecPKI, coseHeadersFor(-7), testDigest, [][]byte{ecPKI.leaf.Raw, ecPKI.root.Raw})
+		},
+	}, {
+		name:      "COSE with RSA and a single certificate",
+		mediaType: MediaTypeCOSE,
+		envelope: func() []byte {
+			return signCOSE(t, rsaPKI, coseHeadersFor(-37), testDigest, rsaPKI.leaf.Raw)
+		},
+	}, {
+		name:      "COSE signed by another key",
+		mediaType: MediaTypeCOSE,
+		envelope: func() []byte {
+			return signCOSE(t, ecPKI, coseHeadersFor(-7), testDigest, [][]byte{otherPKI.leaf.Raw})
+		},
+		wantErr: "invalid signature",
+	}, {
+		name:      "COSE with an unsupported algorithm",
+		mediaType: MediaTypeCOSE,
+		envelope: func() []byte {
+			return signCOSE(t, ecPKI, coseHeadersFor(-8), testDigest, ecPKI.leaf.Raw)
+		},
+		wantErr: "unsupported COSE algorithm -8",
+	}, {
+		name:      "COSE without content type",
+		mediaType: MediaTypeCOSE,
+		envelope: func() []byte {
+			h := coseHeadersFor(-7)
+			delete(h, coseContentType)
+			return signCOSE(t, ecPKI, h, testDigest, ecPKI.leaf.Raw)
+		},
+		wantErr: "COSE protected header 3 is missing",
+	}, {
+		name:      "COSE without certificates",
+		mediaType: MediaTypeCOSE,
+		envelope: func() []byte {
+			return signCOSE(t, ecPKI, coseHeadersFor(-7), testDigest, [][]byte{})
+		},
+		wantErr: "signature has no certificate chain",
+	}, {
+		name:      "unsupported media type",
+		mediaType: "application/vnd.dev.cosign.simplesigning.v1+json",
+		envelope:  func() []byte { return signJWS(t, ecPKI, jwsHeaders("ES256"), testDigest) },
+		wantErr:   `unsupported signature envelope media type "application/vnd.dev.cosign.simplesigning.v1+json"`,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sig, err := ParseEnvelope(test.mediaType, test.envelope())
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("ParseEnvelope() = %v, wanted error containing %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseEnvelope() = %v", err)
+			}
+			if sig.TargetArtifact.Digest != testDigest {
+				t.Errorf("TargetArtifact.Digest = %s, wanted %s", sig.TargetArtifact.Digest, testDigest)
+			}
+			if sig.SigningTime.IsZero() {
+				t.Error("SigningTime is zero")
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	pki := newPKI(t, ecdsaKey(t), nil)
+	noCodeSigning := newPKI(t, ecdsaKey(t), &x509.Certificate{ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}})
+	expired := newPKI(t, ecdsaKey(t), &x509.Certificate{NotBefore: now.Add(-2 * time.Hour), NotAfter: now.Add(-time.Hour)})
+	otherRoot := newPKI(t, ecdsaKey(t), nil).root
+
+	signature := func(pki *testPKI, scheme string, signingTime, expiry time.Time) *Signature {
+		return &Signature{
+			TargetArtifact:   Descriptor{Digest: testDigest},
+			SigningScheme:    scheme,
+			SigningTime:      signingTime,
+			Expiry:           expiry,
+			CertificateChain: []*x509.Certificate{pki.leaf},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		sig     *Signature
+		digest  string
+		roots   []*x509.Certificate
+		ref     v1alpha1.NotationRef
+		wantErr string
+	}{{
+		name:   "trusted by the ca trust store",
+		sig:    signature(pki, SigningSchemeX509, now, time.Time{}),
+		digest: testDigest,
+		roots:  []*x509.Certificate{pki.root},
+	}, {
+		name:   "trusted identity",
+		sig:    signature(pki, SigningSchemeX509, now, time.Time{}),
+		digest: testDigest,
+		roots:  []*x509.Certificate{otherRoot, pki.root},
+		ref: v1alpha1.NotationRef{TrustedIdentities: []string{
+			"x509.subject: C=US, ST=CA, O=Example Corp",
+			"x509.subject: C=US, ST=WA, O=Example Corp, CN=Example Signer",
+		}},
+	}, {
+		name:    "untrusted identity",
+		sig:     signature(pki, SigningSchemeX509, now, time.Time{}),
+		digest:  testDigest,
+		roots:   []*x509.Certificate{pki.root},
+		ref:     v1alpha1.NotationRef{TrustedIdentities: []string{"x509.subject: C=US, ST=WA, O=Other Corp"}},
+		wantErr: "is not a trusted identity",
+	}, {
+		name:   "wildcard identity",
+		sig:    signature(pki, SigningSchemeX509, now, time.Time{}),
+		digest: testDigest,
+		roots:  []*x509.Certificate{pki.root},
+		ref:    v1alpha1.NotationRef{TrustedIdentities: []string{"*"}},
+	}, {
+		name:    "signed for another artifact",
+		sig:     signature(pki, SigningSchemeX509, now, time.Time{}),
+		digest:  "sha256:0000000000000000000000000000000000000000000000000000000000000000",
+		roots:   []*x509.Certificate{pki.root},
+		wantErr: "signature was made for " + testDigest,
+	}, {
+		name:    "untrusted root",
+		sig:     signature(pki, SigningSchemeX509, now, time.Time{}),
+		digest:  testDigest,
+		roots:   []*x509.Certificate{otherRoot},
+		wantErr: "verifying certificate chain",
+	}, {
+		name:    "not a code signing certificate",
+		sig:     signature(noCodeSigning, SigningSchemeX509, now, time.Time{}),
+		digest:  testDigest,
+		roots:   []*x509.Certificate{noCodeSigning.root},
+		wantErr: "verifying certificate chain",
+	}, {
+		name:    "expired signature",
+		sig:     signature(pki, SigningSchemeX509, now.Add(-time.Hour), now.Add(-time.Minute)),
+		digest:  testDigest,
+		roots:   []*x509.Certificate{pki.root},
+		wantErr: "signature expired at",
+	}, {
+		name:    "expired certificate with a claimed signing time",
+		sig:     signature(expired, SigningSchemeX509, now.Add(-90*time.Minute), time.Time{}),
+		digest:  testDigest,
+		roots:   []*x509.Certificate{expired.root},
+		wantErr: "verifying certificate chain",
+	}, {
+		name:   "expired certificate with an authentic signing time",
+		sig:    signature(expired, SigningSchemeX509SigningAuthority, now.Add(-90*time.Minute), time.Time{}),
+		digest: testDigest,
+		roots:  []*x509.Certificate{expired.root},
+		ref:    v1alpha1.NotationRef{TrustStoreType: "signingAuthority"},
+	}, {
+		name:    "signing scheme does not match the trust store",
+		sig:     signature(pki, SigningSchemeX509, now, time.Time{}),
+		digest:  testDigest,
+		roots:   []*x509.Certificate{pki.root},
+		ref:     v1alpha1.NotationRef{TrustStoreType: "signingAuthority"},
+		wantErr: "signature of signing scheme notary.x509 cannot be verified with a trust store of type signingAuthority",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Verify(test.sig, test.digest, test.roots, &test.ref, now)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("Verify() = %v, wanted error containing %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Verify() = %v", err)
+			}
+		})
+	}
+}
+
+func TestParseAndVerify(t *testing.T) {
+	pki := newPKI(t, ecdsaKey(t), nil)
+	ref := &v1alpha1.NotationRef{TrustedIdentities: []string{"x509.subject: C=US, ST=WA, O=Example Corp"}}
+	for mediaType, envelope := range map[string][]byte{
+		MediaTypeJWS:  signJWS(t, pki, jwsHeaders("ES256"), testDigest),
+		MediaTypeCOSE: signCOSE(t, pki, coseHeadersFor(-7), testDigest, [][]byte{pki.leaf.Raw, pki.root.Raw}),
+	} {
+		sig, err := ParseEnvelope(mediaType, envelope)
+		if err != nil {
+			t.Fatalf("ParseEnvelope(%s) = %v", mediaType, err)
+		}
+		if err := Verify(sig, testDigest, []*x509.Certificate{pki.root}, ref, now); err != nil {
+			t.Errorf("Verify(%s) = %v", mediaType, err)
+		}
+	}
+}
@@ -42,6 +42,11 @@ type Verifier interface {
 	// For policies specifying `match:` criteria with label selectors, the
 	// ObjectMeta should be associated with `ctx` here using:
 	//    webhook.GetIncludeObjectMeta(ctx)
+	//
+	// There is no namespace here, so a `namespaceSelector` is matched as if
+	// the namespace had no labels: only selectors that select every namespace
+	// (like `{}`) match. Policies with `subjects` never match, and a warning
+	// says they were skipped.
 	Verify(context.Context, name.Reference, authn.Keychain, ...ociremote.Option) error
 }
 
@@ -134,10 +139,20 @@ var _ Verifier = (*impl)(nil)
 func (i *impl) Verify(ctx context.Context, ref name.Reference, kc authn.Keychain, opts ...ociremote.Option) error {
 	tm := getTypeMeta(ctx)
 	om := getObjectMeta(ctx)
-	matches, err := i.ipc.GetMatchingPolicies(ref.Name(), tm.Kind, tm.APIVersion, om.Labels)
+	// There is no namespace to match a NamespaceSelector against, nor a user
+	// to match Subjects against here. NamespaceSelectors are matched against
+	// no labels, so only the ones selecting every namespace match.
+	matches, err := i.ipc.GetMatchingPolicies(ref.Name(), tm.Kind, tm.APIVersion, om.Labels, nil, nil)
 	if err != nil {
 		return err
 	}
+	skipped, err := i.ipc.GetSubjectPolicies("", ref.Name(), tm.Kind, tm.APIVersion, om.Labels, nil)
+	if err != nil {
+		return err
+	}
+	for _, name := range skipped {
+		i.ww("policy %s skipped, its subjects can only be matched on admission", name)
+	}
 
 	if len(matches) == 0 {
 		switch i.verification.NoMatchPolicy {
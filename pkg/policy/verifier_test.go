@@ -32,6 +32,26 @@ const (
 	// This is the digest of cgr.dev/chainguard/static as of 2023/01/03.
 	// It is not verifiable with goodPolicy.
 	ancientDigest = "sha256:a9650a15060275287ebf4530b34020b8d998bd2de9aea00d113c332d8c41eb0b"
+
+	// subjectsPolicy only applies to requests from a service account, so it
+	// is never matched here.
+	subjectsPolicy = `
+apiVersion: policy.sigstore.dev/v1beta1
+kind: ClusterImagePolicy
+metadata:
+  name: ci-only
+spec:
+  images:
+  - glob: cgr.dev/chainguard/static*
+  match:
+  - subjects:
+    - kind: ServiceAccount
+      namespace: ci
+      name: deployer
+  authorities:
+  - static:
+      action: fail
+`
 )
 
 func TestVerifierDeny(t *testing.T) {
@@ -137,6 +157,16 @@ func TestVerifierWarn(t *testing.T) {
 		},
 		d:       name.MustParseReference("cgr.dev/chainguard/static@" + staticDigest).(name.Digest),
 		wantErr: errors.New(`duplicate policy named "ko-default-base-image-policy", skipping`),
+	}, {
+		name: "policy for subjects",
+		v: Verification{
+			NoMatchPolicy: "allow",
+			Policies: &[]Source{{
+				Data: subjectsPolicy,
+			}},
+		},
+		d:       name.MustParseReference("cgr.dev/chainguard/static@" + staticDigest).(name.Digest),
+		wantErr: errors.New("policy ci-only skipped, its subjects can only be matched on admission"),
 	}}
 
 	for _, test := range tests {
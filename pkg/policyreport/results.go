@@ -0,0 +1,93 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyreport
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/webhook"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// ResultsFor converts the outcome of evaluating the policies matching an
+// image into PolicyReport results. A policy that was satisfied results in a
+// pass for each of the authorities that matched. A policy that was not
+// results in a fail (or warn for policies in warn mode) for each of the
+// authorities that did not match, along with the reason. A policy that the
+// image was exempted from by a PolicyException results in a skip.
+func ResultsFor(evaluation *webhook.ImageEvaluation, now time.Time) []Result {
+	ts := Timestamp{Seconds: now.Unix(), Nanos: int32(now.Nanosecond())}
+	newResult := func(policy, mode, rule string, status Status, message string) Result {
+		return Result{
+			Source:    Source,
+			Policy:    policy,
+			Rule:      rule,
+			Result:    status,
+			Message:   message,
+			Timestamp: ts,
+			Resources: []corev1.ObjectReference{evaluation.Resource},
+			Properties: map[string]string{
+				ImageProperty: evaluation.Image,
+				ModeProperty:  mode,
+			},
+		}
+	}
+
+	results := []Result{}
+	for name, policy := range evaluation.Policies {
+		if policy.Exception != "" {
+			results = append(results, newResult(name, policy.Mode, "", StatusSkip, fmt.Sprintf("exempted by PolicyException %s", policy.Exception)))
+			continue
+		}
+		if policy.Result != nil {
+			if len(policy.Result.AuthorityMatches) == 0 {
+				results = append(results, newResult(name, policy.Mode, "", StatusPass, ""))
+			}
+			for authority := range policy.Result.AuthorityMatches {
+				results = append(results, newResult(name, policy.Mode, authority, StatusPass, ""))
+			}
+			continue
+		}
+		for _, err := range policy.Errors {
+			results = append(results, newResult(name, policy.Mode, webhook.AuthorityOf(err), statusOf(err), err.Error()))
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Policy != results[j].Policy {
+			return results[i].Policy < results[j].Policy
+		}
+		return results[i].Rule < results[j].Rule
+	})
+	return results
+}
+
+// statusOf returns the Status for a policy error. Policy failures are
+// FieldErrors, at the warning level for policies in warn mode, whereas
+// anything else means we were unable to evaluate the policy.
+func statusOf(err error) Status {
+	var fe *apis.FieldError
+	if !errors.As(err, &fe) {
+		return StatusError
+	}
+	if fe.Filter(apis.ErrorLevel) != nil {
+		return StatusFail
+	}
+	return StatusWarn
+}
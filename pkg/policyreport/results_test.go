@@ -0,0 +1,93 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyreport
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigstore/policy-controller/pkg/webhook"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+const testImage = "gcr.io/distroless/static@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"
+
+var testResource = corev1.ObjectReference{
+	APIVersion: "apps/v1",
+	Kind:       "Deployment",
+	Namespace:  "default",
+	Name:       "test",
+}
+
+func TestResultsFor(t *testing.T) {
+	now := time.Unix(1700000000, 5)
+	ts := Timestamp{Seconds: 1700000000, Nanos: 5}
+	result := func(policy, mode, rule string, status Status, message string) Result {
+		return Result{
+			Source:     Source,
+			Policy:     policy,
+			Rule:       rule,
+			Result:     status,
+			Message:    message,
+			Timestamp:  ts,
+			Resources:  []corev1.ObjectReference{testResource},
+			Properties: map[string]string{ImageProperty: testImage, ModeProperty: mode},
+		}
+	}
+
+	evaluation := &webhook.ImageEvaluation{
+		Resource: testResource,
+		Image:    testImage,
+		Policies: map[string]webhook.PolicyEvaluation{
+			"passing": {
+				Mode: "enforce",
+				Result: &webhook.PolicyResult{AuthorityMatches: map[string]webhook.AuthorityMatch{
+					"authority-0": {Static: true},
+				}},
+			},
+			"failing": {
+				Mode: "enforce",
+				Errors: []error{
+					&webhook.AuthorityError{Authority: "authority-0", Err: (&apis.FieldError{Message: "bad signature"}).At(apis.ErrorLevel)},
+					errors.New("failed to process authority: authority-1"),
+				},
+			},
+			"warning": {
+				Mode: "warn",
+				Errors: []error{
+					&webhook.AuthorityError{Authority: "authority-0", Err: (&apis.FieldError{Message: "no signatures found"}).At(apis.WarningLevel)},
+				},
+			},
+			"exempted": {
+				Mode:      "enforce",
+				Exception: "vendor-exception",
+			},
+		},
+	}
+	want := []Result{
+		result("exempted", "enforce", "", StatusSkip, "exempted by PolicyException vendor-exception"),
+		result("failing", "enforce", "", StatusError, "failed to process authority: authority-1"),
+		result("failing", "enforce", "authority-0", StatusFail, "bad signature"),
+		result("passing", "enforce", "authority-0", StatusPass, ""),
+		result("warning", "warn", "authority-0", StatusWarn, "no signatures found"),
+	}
+	if diff := cmp.Diff(want, ResultsFor(evaluation, now)); diff != "" {
+		t.Errorf("ResultsFor() -want,+got: %s", diff)
+	}
+}
@@ -0,0 +1,116 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyreport
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The subset of the wgpolicyk8s.io/v1alpha2 PolicyReport API that we write.
+// See https://github.com/kubernetes-sigs/wg-policy-prototypes/tree/master/policy-report
+
+// GroupVersionResource of the PolicyReport resource.
+var GroupVersionResource = schema.GroupVersionResource{
+	Group:    "wgpolicyk8s.io",
+	Version:  "v1alpha2",
+	Resource: "policyreports",
+}
+
+const (
+	// ReportName is the name of the PolicyReport we maintain in each
+	// namespace.
+	ReportName = "policy-controller"
+	// Source is the value of the source field of every result we write.
+	Source = "policy-controller"
+
+	// ImageProperty is the key of the results property holding the image.
+	ImageProperty = "image"
+	// ModeProperty is the key of the results property holding the mode of
+	// the policy.
+	ModeProperty = "mode"
+)
+
+// Status of a single result.
+type Status string
+
+const (
+	StatusPass  Status = "pass"
+	StatusFail  Status = "fail"
+	StatusWarn  Status = "warn"
+	StatusError Status = "error"
+	StatusSkip  Status = "skip"
+)
+
+// PolicyReport holds the results of evaluating the policies against the
+// resources of a namespace.
+type PolicyReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Summary Summary  `json:"summary,omitempty"`
+	Results []Result `json:"results,omitempty"`
+}
+
+// Summary holds the number of results with each Status.
+type Summary struct {
+	Pass  int `json:"pass"`
+	Fail  int `json:"fail"`
+	Warn  int `json:"warn"`
+	Error int `json:"error"`
+	Skip  int `json:"skip"`
+}
+
+// Result is the outcome of evaluating a single policy rule, for us an
+// authority of a ClusterImagePolicy, against an image of a resource.
+type Result struct {
+	Source     string                   `json:"source"`
+	Policy     string                   `json:"policy"`
+	Rule       string                   `json:"rule,omitempty"`
+	Result     Status                   `json:"result"`
+	Message    string                   `json:"message,omitempty"`
+	Timestamp  Timestamp                `json:"timestamp"`
+	Resources  []corev1.ObjectReference `json:"resources,omitempty"`
+	Properties map[string]string        `json:"properties,omitempty"`
+}
+
+// Timestamp is when a Result was recorded, in the protobuf style used by the
+// PolicyReport API.
+type Timestamp struct {
+	Seconds int64 `json:"seconds"`
+	Nanos   int32 `json:"nanos"`
+}
+
+// summarize computes the Summary of the given results.
+func summarize(results []Result) Summary {
+	var s Summary
+	for _, r := range results {
+		switch r.Result {
+		case StatusPass:
+			s.Pass++
+		case StatusFail:
+			s.Fail++
+		case StatusWarn:
+			s.Warn++
+		case StatusError:
+			s.Error++
+		case StatusSkip:
+			s.Skip++
+		}
+	}
+	return s
+}
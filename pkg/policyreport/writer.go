@@ -0,0 +1,247 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyreport
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/webhook"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// DefaultFlushPeriod is how often the results are written out by default.
+	DefaultFlushPeriod = 10 * time.Second
+	// DefaultMaxResults is the default maximum number of results kept in
+	// the PolicyReport of a namespace. The oldest results are dropped first.
+	DefaultMaxResults = 1000
+)
+
+// Writer is a webhook.Reporter that records the results in a PolicyReport
+// for each namespace. Results are batched in memory and written out
+// periodically by Run, since admission requests come in far faster than we
+// would want to update the reports.
+type Writer struct {
+	client     dynamic.Interface
+	maxResults int
+
+	m sync.Mutex
+	// pending holds the results not written yet, keyed by namespace.
+	pending map[string][]Result
+
+	// For testing.
+	now func() time.Time
+}
+
+var _ webhook.Reporter = (*Writer)(nil)
+
+// NewWriter creates a new Writer that writes at most maxResults results to
+// each PolicyReport.
+func NewWriter(client dynamic.Interface, maxResults int) *Writer {
+	return &Writer{
+		client:     client,
+		maxResults: maxResults,
+		pending:    map[string][]Result{},
+		now:        time.Now,
+	}
+}
+
+// Report implements webhook.Reporter.
+func (w *Writer) Report(_ context.Context, evaluation *webhook.ImageEvaluation) {
+	// Resources without a namespace have nowhere to report to, and ones
+	// without a name (for example Pods created from a ReplicaSet that only
+	// have a generateName on admission) can't be told apart from each other.
+	// The latter get reported through their owners instead.
+	if evaluation.Resource.Namespace == "" || evaluation.Resource.Name == "" {
+		return
+	}
+	results := ResultsFor(evaluation, w.now())
+	w.m.Lock()
+	defer w.m.Unlock()
+	ns := evaluation.Resource.Namespace
+	w.pending[ns] = append(w.pending[ns], results...)
+}
+
+// Run writes out the pending results every period until the context is
+// cancelled.
+func (w *Writer) Run(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Flush(ctx)
+		}
+	}
+}
+
+// Flush writes out the pending results. Results that fail to be written are
+// kept, and retried on the next Flush. At most maxResults of them are kept
+// for each namespace, dropping the oldest first, since no more would make it
+// into the PolicyReport anyway.
+func (w *Writer) Flush(ctx context.Context) {
+	w.m.Lock()
+	pending := w.pending
+	w.pending = map[string][]Result{}
+	w.m.Unlock()
+
+	for ns, results := range pending {
+		if err := w.write(ctx, ns, results); err != nil {
+			logging.FromContext(ctx).Warnf("Failed to write PolicyReport for namespace %s: %v", ns, err)
+			w.m.Lock()
+			// Newer results go after the ones we failed to write so that
+			// they take precedence on merge.
+			requeued := append(results, w.pending[ns]...)
+			if w.maxResults > 0 && len(requeued) > w.maxResults {
+				requeued = requeued[len(requeued)-w.maxResults:]
+			}
+			w.pending[ns] = requeued
+			w.m.Unlock()
+		}
+	}
+}
+
+// write merges the results into the PolicyReport of the namespace, creating
+// it if needed.
+func (w *Writer) write(ctx context.Context, namespace string, results []Result) error {
+	client := w.client.Resource(GroupVersionResource).Namespace(namespace)
+	report := &PolicyReport{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: GroupVersionResource.GroupVersion().String(),
+			Kind:       "PolicyReport",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ReportName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": Source,
+			},
+		},
+	}
+	existing, err := client.Get(ctx, ReportName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		existing = nil
+	case err != nil:
+		return fmt.Errorf("getting PolicyReport: %w", err)
+	default:
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(existing.Object, report); err != nil {
+			return fmt.Errorf("converting PolicyReport: %w", err)
+		}
+	}
+
+	report.Results = merge(report.Results, results, w.maxResults)
+	report.Summary = summarize(report.Results)
+
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(report)
+	if err != nil {
+		return fmt.Errorf("converting PolicyReport: %w", err)
+	}
+	u := &unstructured.Unstructured{Object: obj}
+	if existing == nil {
+		_, err = client.Create(ctx, u, metav1.CreateOptions{})
+	} else {
+		_, err = client.Update(ctx, u, metav1.UpdateOptions{})
+	}
+	return err
+}
+
+// evaluationKey identifies the results of evaluating a policy against an
+// image of a resource. New results replace all the previous ones with the
+// same key, since which authorities failed may have changed.
+type evaluationKey struct {
+	kind, name, image, policy string
+}
+
+func keyOf(r Result) evaluationKey {
+	key := evaluationKey{
+		image:  r.Properties[ImageProperty],
+		policy: r.Policy,
+	}
+	if len(r.Resources) > 0 {
+		key.kind = r.Resources[0].Kind
+		key.name = r.Resources[0].Name
+	}
+	return key
+}
+
+// merge returns the existing results updated with the newer ones, keeping
+// at most maxResults of the most recent results.
+func merge(existing, newer []Result, maxResults int) []Result {
+	replaced := make(map[evaluationKey]struct{}, len(newer))
+	latest := make(map[evaluationKey][]Result, len(newer))
+	for _, r := range newer {
+		key := keyOf(r)
+		if prev, ok := latest[key]; ok && prev[0].Timestamp != r.Timestamp {
+			// A more recent evaluation of the same thing, drop the
+			// previous one.
+			delete(latest, key)
+		}
+		latest[key] = append(latest[key], r)
+		replaced[key] = struct{}{}
+	}
+
+	merged := make([]Result, 0, len(existing)+len(newer))
+	for _, r := range existing {
+		if _, ok := replaced[keyOf(r)]; !ok {
+			merged = append(merged, r)
+		}
+	}
+	for _, rs := range latest {
+		merged = append(merged, rs...)
+	}
+
+	if maxResults > 0 && len(merged) > maxResults {
+		sort.SliceStable(merged, func(i, j int) bool {
+			return before(merged[j].Timestamp, merged[i].Timestamp)
+		})
+		merged = merged[:maxResults]
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		ki, kj := keyOf(merged[i]), keyOf(merged[j])
+		switch {
+		case ki.kind != kj.kind:
+			return ki.kind < kj.kind
+		case ki.name != kj.name:
+			return ki.name < kj.name
+		case ki.image != kj.image:
+			return ki.image < kj.image
+		case ki.policy != kj.policy:
+			return ki.policy < kj.policy
+		default:
+			return merged[i].Rule < merged[j].Rule
+		}
+	})
+	return merged
+}
+
+func before(a, b Timestamp) bool {
+	if a.Seconds != b.Seconds {
+		return a.Seconds < b.Seconds
+	}
+	return a.Nanos < b.Nanos
+}
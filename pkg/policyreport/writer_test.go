@@ -0,0 +1,159 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyreport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sigstore/policy-controller/pkg/webhook"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	clientgotesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+)
+
+func newTestWriter(maxResults int) (*Writer, *fakedynamic.FakeDynamicClient) {
+	client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{GroupVersionResource: "PolicyReportList"})
+	return NewWriter(client, maxResults), client
+}
+
+func getReport(t *testing.T, client *fakedynamic.FakeDynamicClient, namespace string) *PolicyReport {
+	t.Helper()
+	u, err := client.Resource(GroupVersionResource).Namespace(namespace).Get(context.Background(), ReportName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Failed to get PolicyReport: %v", err)
+	}
+	report := &PolicyReport{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, report); err != nil {
+		t.Fatalf("Failed to convert PolicyReport: %v", err)
+	}
+	return report
+}
+
+func evaluationOf(name string, policy webhook.PolicyEvaluation) *webhook.ImageEvaluation {
+	resource := testResource
+	resource.Name = name
+	return &webhook.ImageEvaluation{
+		Resource: resource,
+		Image:    testImage,
+		Policies: map[string]webhook.PolicyEvaluation{"cip": policy},
+	}
+}
+
+var (
+	passing = webhook.PolicyEvaluation{
+		Mode: "enforce",
+		Result: &webhook.PolicyResult{AuthorityMatches: map[string]webhook.AuthorityMatch{
+			"authority-0": {Static: true},
+		}},
+	}
+	failing = webhook.PolicyEvaluation{
+		Mode: "enforce",
+		Errors: []error{
+			&webhook.AuthorityError{Authority: "authority-0", Err: (&apis.FieldError{Message: "bad signature"}).At(apis.ErrorLevel)},
+			&webhook.AuthorityError{Authority: "authority-1", Err: (&apis.FieldError{Message: "no signatures found"}).At(apis.ErrorLevel)},
+		},
+	}
+)
+
+func TestWriter(t *testing.T) {
+	ctx := context.Background()
+	w, client := newTestWriter(DefaultMaxResults)
+	now := time.Unix(1700000000, 0)
+	w.now = func() time.Time { return now }
+
+	// Creates the report.
+	w.Report(ctx, evaluationOf("a", failing))
+	w.Report(ctx, evaluationOf("b", passing))
+	// Not reported, no name.
+	w.Report(ctx, evaluationOf("", passing))
+	w.Flush(ctx)
+
+	report := getReport(t, client, "default")
+	if diff := cmp.Diff(Summary{Pass: 1, Fail: 2}, report.Summary); diff != "" {
+		t.Errorf("Summary -want,+got: %s", diff)
+	}
+	if len(report.Results) != 3 {
+		t.Fatalf("Wanted 3 results, got %d", len(report.Results))
+	}
+
+	// Updates the report, replacing the results for a.
+	now = now.Add(time.Minute)
+	w.Report(ctx, evaluationOf("a", passing))
+	w.Flush(ctx)
+
+	report = getReport(t, client, "default")
+	if diff := cmp.Diff(Summary{Pass: 2}, report.Summary); diff != "" {
+		t.Errorf("Summary -want,+got: %s", diff)
+	}
+	for _, r := range report.Results {
+		if r.Resources[0].Name == "a" && r.Timestamp.Seconds != now.Unix() {
+			t.Errorf("Result for a was not updated: %+v", r)
+		}
+	}
+}
+
+func TestFlushRequeueMaxResults(t *testing.T) {
+	ctx := context.Background()
+	w, client := newTestWriter(2)
+	client.PrependReactor("*", GroupVersionResource.Resource, func(clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("unavailable")
+	})
+	now := time.Unix(1700000000, 0)
+	w.now = func() time.Time { return now }
+
+	// The results that fail to be written are kept, up to the limit.
+	for _, name := range []string{"a", "b", "c"} {
+		now = now.Add(time.Second)
+		w.Report(ctx, evaluationOf(name, passing))
+		w.Flush(ctx)
+	}
+
+	var names []string
+	for _, r := range w.pending["default"] {
+		names = append(names, r.Resources[0].Name)
+	}
+	if diff := cmp.Diff([]string{"b", "c"}, names); diff != "" {
+		t.Errorf("pending -want,+got: %s", diff)
+	}
+}
+
+func TestMergeMaxResults(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	var existing []Result
+	for _, name := range []string{"a", "b", "c"} {
+		now = now.Add(time.Second)
+		existing = append(existing, ResultsFor(evaluationOf(name, passing), now)...)
+	}
+	now = now.Add(time.Second)
+	newer := ResultsFor(evaluationOf("d", passing), now)
+
+	got := merge(existing, newer, 3)
+	var names []string
+	for _, r := range got {
+		names = append(names, r.Resources[0].Name)
+	}
+	if diff := cmp.Diff([]string{"b", "c", "d"}, names); diff != "" {
+		t.Errorf("merge() -want,+got: %s", diff)
+	}
+}
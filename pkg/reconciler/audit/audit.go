@@ -0,0 +1,147 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/webhook"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// contextDecorator attaches configuration to contexts, for example the
+// current compiled policies from a config.Store.
+type contextDecorator interface {
+	ToContext(ctx context.Context) context.Context
+}
+
+// Reconciler evaluates running Pods against the current policies, the same
+// way the admission webhook would if the Pod was created now.
+type Reconciler struct {
+	podlister corev1listers.PodLister
+	validator *webhook.Validator
+	// configStores attach the current policies and policy-controller
+	// configuration to the context used for validation.
+	configStores []contextDecorator
+	results      *Results
+	// reporter, if set, is notified of the outcome of evaluating each of
+	// the images, for example to write PolicyReports.
+	reporter webhook.Reporter
+
+	// For testing.
+	now func() time.Time
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile implements controller.Reconciler.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	namespace, name, err := controller.SplitNamespaceName(key)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Invalid resource key: %s", key)
+		return nil
+	}
+	pod, err := r.podlister.Pods(namespace).Get(name)
+	if apierrs.IsNotFound(err) {
+		r.results.Delete(types.NamespacedName{Namespace: namespace, Name: name})
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if !isRunning(pod) {
+		r.results.Delete(types.NamespacedName{Namespace: namespace, Name: name})
+		return nil
+	}
+
+	result := r.audit(ctx, pod)
+	if !result.Compliant {
+		logging.FromContext(ctx).Warnf("%s %s/%s is not compliant with the current policies (pod %s): %s",
+			result.Workload.Kind, result.Namespace, result.Workload.Name, result.Pod, result.Error)
+	}
+	r.results.Set(result)
+	return nil
+}
+
+// audit validates the Pod with the current configuration and returns the
+// outcome.
+func (r *Reconciler) audit(ctx context.Context, pod *corev1.Pod) Result {
+	for _, store := range r.configStores {
+		ctx = store.ToContext(ctx)
+	}
+	if r.reporter != nil {
+		ctx = webhook.WithReporter(ctx, r.reporter)
+	}
+	// Pods from the informer do not have TypeMeta set, and it's needed to
+	// evaluate the match criteria of the policies.
+	errs := r.validator.ValidatePod(ctx, &duckv1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+		ObjectMeta: pod.ObjectMeta,
+		Spec:       pod.Spec,
+	})
+
+	result := Result{
+		Namespace: pod.Namespace,
+		Pod:       pod.Name,
+		Workload:  workloadOf(pod),
+		Compliant: true,
+		Timestamp: r.now(),
+	}
+	if fe := errs.Filter(apis.ErrorLevel); fe != nil {
+		result.Compliant = false
+		result.Error = fe.Error()
+	}
+	if fe := errs.Filter(apis.WarningLevel); fe != nil {
+		result.Warning = fe.Error()
+	}
+	return result
+}
+
+// workloadOf returns the controller of the Pod, or the Pod itself if it does
+// not have one.
+func workloadOf(pod *corev1.Pod) WorkloadReference {
+	if owner := metav1.GetControllerOf(pod); owner != nil {
+		return WorkloadReference{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Name:       owner.Name,
+		}
+	}
+	return WorkloadReference{
+		APIVersion: corev1.SchemeGroupVersion.String(),
+		Kind:       "Pod",
+		Name:       pod.Name,
+	}
+}
+
+// isRunning returns true for Pods that have not terminated yet, since only
+// those need auditing.
+func isRunning(pod *corev1.Pod) bool {
+	return pod.DeletionTimestamp == nil &&
+		pod.Status.Phase != corev1.PodSucceeded &&
+		pod.Status.Phase != corev1.PodFailed
+}
@@ -0,0 +1,203 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	policycontrollerconfig "github.com/sigstore/policy-controller/pkg/config"
+	"github.com/sigstore/policy-controller/pkg/webhook"
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/ptr"
+	rtesting "knative.dev/pkg/reconciler/testing"
+)
+
+const (
+	testNamespace = "default"
+	testImage     = "gcr.io/distroless/static@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"
+)
+
+// staticConfig attaches a fixed configuration to the context.
+type staticConfig struct {
+	policies      map[string]webhookcip.ClusterImagePolicy
+	noMatchPolicy string
+}
+
+func (s *staticConfig) ToContext(ctx context.Context) context.Context {
+	ctx = config.ToContext(ctx, &config.Config{
+		ImagePolicyConfig: &config.ImagePolicyConfig{Policies: s.policies},
+	})
+	return policycontrollerconfig.ToContext(ctx, &policycontrollerconfig.PolicyControllerConfig{
+		NoMatchPolicy: s.noMatchPolicy,
+	})
+}
+
+func makePod(name string, owner *metav1.OwnerReference, phase corev1.PodPhase) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      name,
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "user-container",
+				Image: testImage,
+			}},
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+	if owner != nil {
+		pod.OwnerReferences = []metav1.OwnerReference{*owner}
+	}
+	return pod
+}
+
+func TestReconcile(t *testing.T) {
+	staticPolicy := func(action string) map[string]webhookcip.ClusterImagePolicy {
+		return map[string]webhookcip.ClusterImagePolicy{
+			"cip": {
+				Images: []v1alpha1.ImagePattern{{Glob: "gcr.io/distroless/*"}},
+				Authorities: []webhookcip.Authority{{
+					Name:   "authority-0",
+					Static: &webhookcip.StaticRef{Action: action, Message: "not allowed"},
+				}},
+			},
+		}
+	}
+	replicaSet := &metav1.OwnerReference{
+		APIVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Name:       "test-rs",
+		Controller: ptr.Bool(true),
+	}
+
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		config        *staticConfig
+		wantResult    bool
+		wantCompliant bool
+		wantWorkload  WorkloadReference
+	}{{
+		name:          "passing policy",
+		pod:           makePod("pass", replicaSet, corev1.PodRunning),
+		config:        &staticConfig{policies: staticPolicy("pass"), noMatchPolicy: policycontrollerconfig.DenyAll},
+		wantResult:    true,
+		wantCompliant: true,
+		wantWorkload:  WorkloadReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "test-rs"},
+	}, {
+		name:          "failing policy",
+		pod:           makePod("fail", replicaSet, corev1.PodRunning),
+		config:        &staticConfig{policies: staticPolicy("fail"), noMatchPolicy: policycontrollerconfig.DenyAll},
+		wantResult:    true,
+		wantCompliant: false,
+		wantWorkload:  WorkloadReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "test-rs"},
+	}, {
+		name:          "no matching policy, denied",
+		pod:           makePod("nomatch", nil, corev1.PodPending),
+		config:        &staticConfig{noMatchPolicy: policycontrollerconfig.DenyAll},
+		wantResult:    true,
+		wantCompliant: false,
+		wantWorkload:  WorkloadReference{APIVersion: "v1", Kind: "Pod", Name: "nomatch"},
+	}, {
+		name:          "no matching policy, allowed",
+		pod:           makePod("nomatch", nil, corev1.PodRunning),
+		config:        &staticConfig{noMatchPolicy: policycontrollerconfig.AllowAll},
+		wantResult:    true,
+		wantCompliant: true,
+		wantWorkload:  WorkloadReference{APIVersion: "v1", Kind: "Pod", Name: "nomatch"},
+	}, {
+		name:   "completed pods are not audited",
+		pod:    makePod("done", nil, corev1.PodSucceeded),
+		config: &staticConfig{policies: staticPolicy("fail"), noMatchPolicy: policycontrollerconfig.DenyAll},
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, _ := rtesting.SetupFakeContext(t)
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if err := indexer.Add(test.pod); err != nil {
+				t.Fatalf("Failed to add pod: %v", err)
+			}
+			now := time.Now()
+			r := &Reconciler{
+				podlister:    corev1listers.NewPodLister(indexer),
+				validator:    webhook.NewValidator(ctx),
+				configStores: []contextDecorator{test.config},
+				results:      NewResults(),
+				now:          func() time.Time { return now },
+			}
+
+			key := types.NamespacedName{Namespace: test.pod.Namespace, Name: test.pod.Name}
+			if err := r.Reconcile(ctx, key.String()); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			got, ok := r.results.Get(key)
+			if ok != test.wantResult {
+				t.Fatalf("Get() found = %t, wanted %t", ok, test.wantResult)
+			}
+			if !ok {
+				return
+			}
+			if got.Compliant != test.wantCompliant {
+				t.Errorf("Compliant = %t, wanted %t (error: %s)", got.Compliant, test.wantCompliant, got.Error)
+			}
+			if !test.wantCompliant && got.Error == "" {
+				t.Error("Error is empty for a non-compliant pod")
+			}
+			if got.Workload != test.wantWorkload {
+				t.Errorf("Workload = %+v, wanted %+v", got.Workload, test.wantWorkload)
+			}
+			if !got.Timestamp.Equal(now) {
+				t.Errorf("Timestamp = %v, wanted %v", got.Timestamp, now)
+			}
+
+			// Once the pod goes away, so does the result.
+			if err := indexer.Delete(test.pod); err != nil {
+				t.Fatalf("Failed to delete pod: %v", err)
+			}
+			if err := r.Reconcile(ctx, key.String()); err != nil {
+				t.Fatalf("Reconcile() = %v", err)
+			}
+			if _, ok := r.results.Get(key); ok {
+				t.Error("Result was not removed for a deleted pod")
+			}
+		})
+	}
+}
+
+func TestImagesChanged(t *testing.T) {
+	oldPod := makePod("pod", nil, corev1.PodRunning)
+	newPod := oldPod.DeepCopy()
+	newPod.Status.Phase = corev1.PodFailed
+	if imagesChanged(oldPod, newPod) {
+		t.Error("imagesChanged() = true for a status update")
+	}
+	newPod.Spec.EphemeralContainers = []corev1.EphemeralContainer{{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug", Image: "busybox"},
+	}}
+	if !imagesChanged(oldPod, newPod) {
+		t.Error("imagesChanged() = false for a new ephemeral container")
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	policycontrollerconfig "github.com/sigstore/policy-controller/pkg/config"
+	"github.com/sigstore/policy-controller/pkg/webhook"
+	namespaceinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace"
+	kubeinformerfactory "knative.dev/pkg/client/injection/kube/informers/factory"
+)
+
+// DefaultResyncPeriod is how often all running Pods are re-evaluated, even
+// if neither they nor the policies have changed. This catches for example
+// signatures that have since been removed from the registry.
+const DefaultResyncPeriod = time.Hour
+
+type auditResyncPeriodKey struct{}
+
+// NewController creates a Reconciler for auditing running Pods and returns
+// the result of NewContext. The number of compliant and non-compliant
+// workloads in each namespace is recorded in the audit_workloads metric, and
+// if there is a webhook.Reporter in the context, the outcome of evaluating
+// each image is reported to it.
+func NewController(
+	ctx context.Context,
+	cmw configmap.Watcher,
+) *controller.Impl {
+	logger := logging.FromContext(ctx)
+	// Get the Pod informer from the factory rather than registering one with
+	// injection, since those get started (and cache every Pod in the
+	// cluster) whether the audit controller is enabled or not.
+	podInformer := kubeinformerfactory.Get(ctx).Core().V1().Pods()
+
+	results := NewResults()
+	go reportCompliance(ctx, results, complianceReportPeriod)
+
+	r := &Reconciler{
+		podlister: podInformer.Lister(),
+		validator: webhook.NewValidator(ctx),
+		results:   results,
+		reporter:  webhook.ReporterFromContext(ctx),
+		now:       time.Now,
+	}
+	impl := controller.NewContext(ctx, r, controller.ControllerOptions{
+		WorkQueueName: "Audit",
+		Logger:        logger,
+	})
+
+	// Re-evaluate all the Pods when the compiled policies, exceptions or
+	// revocations change, or when a TrustRoot changes, since TrustRoots get
+	// compiled into the config-sigstore-keys ConfigMap.
+	resync := func(name string, _ interface{}) {
+		if name != config.ImagePoliciesConfigName && name != config.SigstoreKeysConfigName &&
+			name != config.PolicyExceptionsConfigName && name != config.RevocationsConfigName {
+			return
+		}
+		logger.Infof("Doing a global resync on Pods due to %s changing.", name)
+		impl.GlobalResync(podInformer.Informer())
+	}
+	store := config.NewStore(logger.Named("config-store"), resync)
+	store.WatchConfigs(cmw)
+	policyControllerConfigStore := policycontrollerconfig.NewStore(logger.Named("config-policy-controller"))
+	policyControllerConfigStore.WatchConfigs(cmw)
+	// The labels of the namespaces are matched against the namespaceSelector
+	// of the policies. Unlike the Pod informer, this one is shared with the
+	// webhook through injection, so sharedmain starts it and waits for it to
+	// sync.
+	r.configStores = []contextDecorator{store, policyControllerConfigStore, namespaceListerDecorator{lister: namespaceinformer.Get(ctx).Lister()}}
+
+	if _, err := podInformer.Informer().AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
+		AddFunc: impl.Enqueue,
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// Pods get frequent status updates, only re-evaluate them
+			// when the images change or on the periodic resync.
+			oldPod, ok1 := oldObj.(*corev1.Pod)
+			newPod, ok2 := newObj.(*corev1.Pod)
+			if !ok1 || !ok2 || oldPod.ResourceVersion == newPod.ResourceVersion || imagesChanged(oldPod, newPod) {
+				impl.Enqueue(newObj)
+			}
+		},
+		DeleteFunc: impl.Enqueue,
+	}, ResyncPeriodFromContextOrDefaults(ctx)); err != nil {
+		logger.Warnf("Failed podInformer AddEventHandlerWithResyncPeriod() %v", err)
+	}
+	// The Pod informer is not registered with injection, so sharedmain does
+	// not start it. Start it here and wait for it to sync, so that Pods are
+	// never reconciled or resynced against a partial cache.
+	if err := controller.StartInformers(ctx.Done(), podInformer.Informer()); err != nil {
+		logger.Fatalw("Failed to start the Pod informer", zap.Error(err))
+	}
+
+	return impl
+}
+
+// namespaceListerDecorator attaches a NamespaceLister to the context used for
+// validation.
+type namespaceListerDecorator struct {
+	lister corev1listers.NamespaceLister
+}
+
+// ToContext implements contextDecorator.
+func (d namespaceListerDecorator) ToContext(ctx context.Context) context.Context {
+	return webhook.WithNamespaceLister(ctx, d.lister)
+}
+
+// imagesChanged returns true if any of the images used by the Pods differ.
+func imagesChanged(oldPod, newPod *corev1.Pod) bool {
+	return !equalStrings(podImages(oldPod), podImages(newPod))
+}
+
+func podImages(pod *corev1.Pod) []string {
+	ret := make([]string, 0, len(pod.Spec.InitContainers)+len(pod.Spec.Containers)+len(pod.Spec.EphemeralContainers))
+	for _, c := range pod.Spec.InitContainers {
+		ret = append(ret, c.Image)
+	}
+	for _, c := range pod.Spec.Containers {
+		ret = append(ret, c.Image)
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		ret = append(ret, c.Image)
+	}
+	for _, v := range pod.Spec.Volumes {
+		if v.Image != nil {
+			ret = append(ret, v.Image.Reference)
+		}
+	}
+	return ret
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// ToContext attaches the period at which all the Pods are re-evaluated.
+func ToContext(ctx context.Context, duration time.Duration) context.Context {
+	return context.WithValue(ctx, auditResyncPeriodKey{}, duration)
+}
+
+// ResyncPeriodFromContextOrDefaults returns a stored audit resync period if
+// attached. If not found, it returns DefaultResyncPeriod.
+func ResyncPeriodFromContextOrDefaults(ctx context.Context) time.Duration {
+	x, ok := ctx.Value(auditResyncPeriodKey{}).(time.Duration)
+	if ok {
+		return x
+	}
+	return DefaultResyncPeriod
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"knative.dev/pkg/configmap"
+	rtesting "knative.dev/pkg/reconciler/testing"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	policycontrollerconfig "github.com/sigstore/policy-controller/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/system"
+
+	// Fake injection informers
+	_ "knative.dev/pkg/client/injection/kube/informers/core/v1/namespace/fake"
+	_ "knative.dev/pkg/client/injection/kube/informers/factory/fake"
+	_ "knative.dev/pkg/system/testing"
+)
+
+func TestNew(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+
+	c := NewController(ctx, configmap.NewStaticWatcher(
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.ImagePoliciesConfigName}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.SigstoreKeysConfigName}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.PolicyExceptionsConfigName}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.RevocationsConfigName}},
+		&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: policycontrollerconfig.PolicyControllerConfigName}},
+	))
+
+	if c == nil {
+		t.Fatal("Expected NewController to return a non-nil value")
+	}
+}
+
+func TestContextDuration(t *testing.T) {
+	ctx, _ := rtesting.SetupFakeContext(t)
+
+	if got := ResyncPeriodFromContextOrDefaults(ctx); got != DefaultResyncPeriod {
+		t.Errorf("ResyncPeriodFromContextOrDefaults() = %v, wanted %v", got, DefaultResyncPeriod)
+	}
+	ctx = ToContext(ctx, time.Minute)
+	if got := ResyncPeriodFromContextOrDefaults(ctx); got != time.Minute {
+		t.Errorf("ResyncPeriodFromContextOrDefaults() = %v, wanted %v", got, time.Minute)
+	}
+}
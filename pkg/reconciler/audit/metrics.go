@@ -0,0 +1,102 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+// complianceReportPeriod is how often the audit_workloads metric is updated
+// from the latest audit results.
+const complianceReportPeriod = 30 * time.Second
+
+var (
+	auditWorkloadsM = stats.Int64(
+		"audit_workloads",
+		"Number of workloads with running Pods, the compliant tag tells whether all of their Pods would be admitted with the current policies",
+		stats.UnitDimensionless)
+
+	namespaceKey    = tag.MustNewKey("namespace")
+	workloadKindKey = tag.MustNewKey("workload_kind")
+	compliantKey    = tag.MustNewKey("compliant")
+)
+
+func init() {
+	if err := metrics.RegisterResourceView(
+		&view.View{
+			Description: auditWorkloadsM.Description(),
+			Measure:     auditWorkloadsM,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{namespaceKey, workloadKindKey, compliantKey},
+		},
+	); err != nil {
+		panic(err)
+	}
+}
+
+// complianceKey holds the tags of the audit_workloads metric.
+type complianceKey struct {
+	namespace string
+	kind      string
+	compliant bool
+}
+
+// countWorkloads returns the number of workloads for each set of tags. The
+// tags in previous that have no workloads anymore are counted as zero, so
+// that the metric does not keep their last value.
+func countWorkloads(workloads []WorkloadResult, previous map[complianceKey]int64) map[complianceKey]int64 {
+	counts := make(map[complianceKey]int64, len(previous))
+	for k := range previous {
+		counts[k] = 0
+	}
+	for _, w := range workloads {
+		counts[complianceKey{namespace: w.Namespace, kind: w.Workload.Kind, compliant: w.Compliant}]++
+	}
+	return counts
+}
+
+// reportCompliance records the audit_workloads metric from the results every
+// period, until the context is done.
+func reportCompliance(ctx context.Context, results *Results, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	previous := map[complianceKey]int64{}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		counts := countWorkloads(results.Workloads(), previous)
+		previous = make(map[complianceKey]int64, len(counts))
+		for k, n := range counts {
+			metrics.Record(ctx, auditWorkloadsM.M(n), stats.WithTags(
+				tag.Upsert(namespaceKey, k.namespace),
+				tag.Upsert(workloadKindKey, k.kind),
+				tag.Upsert(compliantKey, strconv.FormatBool(k.compliant)),
+			))
+			if n > 0 {
+				previous[k] = n
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCountWorkloads(t *testing.T) {
+	rs := WorkloadReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs"}
+	other := WorkloadReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "other"}
+	sts := WorkloadReference{APIVersion: "apps/v1", Kind: "StatefulSet", Name: "sts"}
+
+	got := countWorkloads([]WorkloadResult{
+		{Namespace: "ns", Workload: rs, Compliant: true},
+		{Namespace: "ns", Workload: other, Compliant: true},
+		{Namespace: "ns", Workload: sts, Compliant: false},
+		{Namespace: "other-ns", Workload: rs, Compliant: false},
+	}, nil)
+	want := map[complianceKey]int64{
+		{namespace: "ns", kind: "ReplicaSet", compliant: true}:        2,
+		{namespace: "ns", kind: "StatefulSet", compliant: false}:      1,
+		{namespace: "other-ns", kind: "ReplicaSet", compliant: false}: 1,
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(complianceKey{})); diff != "" {
+		t.Errorf("countWorkloads() (-want, +got): %s", diff)
+	}
+
+	// Once the StatefulSet is fixed, there are no non-compliant
+	// StatefulSets left, which has to be recorded.
+	got = countWorkloads([]WorkloadResult{
+		{Namespace: "ns", Workload: sts, Compliant: true},
+	}, want)
+	want = map[complianceKey]int64{
+		{namespace: "ns", kind: "ReplicaSet", compliant: true}:        0,
+		{namespace: "ns", kind: "StatefulSet", compliant: false}:      0,
+		{namespace: "ns", kind: "StatefulSet", compliant: true}:       1,
+		{namespace: "other-ns", kind: "ReplicaSet", compliant: false}: 0,
+	}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(complianceKey{})); diff != "" {
+		t.Errorf("countWorkloads() (-want, +got): %s", diff)
+	}
+}
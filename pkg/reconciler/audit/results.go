@@ -0,0 +1,130 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// WorkloadReference identifies the workload that a Pod belongs to. This is
+// the controller (ReplicaSet, Job, StatefulSet, ...) of the Pod, or the Pod
+// itself for bare Pods.
+type WorkloadReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+// Result is the outcome of auditing a single Pod against the policies that
+// were in effect at Timestamp.
+type Result struct {
+	Namespace string            `json:"namespace"`
+	Pod       string            `json:"pod"`
+	Workload  WorkloadReference `json:"workload"`
+	// Compliant is true if the Pod would be admitted with the current
+	// policies. Warnings do not make a Pod non-compliant.
+	Compliant bool `json:"compliant"`
+	// Error holds the reasons why the Pod would be rejected, if any.
+	Error string `json:"error,omitempty"`
+	// Warning holds the warnings that would be returned on admission, if
+	// any.
+	Warning   string    `json:"warning,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// WorkloadResult is the compliance of a workload, aggregated over all of its
+// Pods. A workload is only compliant if all of its Pods are.
+type WorkloadResult struct {
+	Namespace string            `json:"namespace"`
+	Workload  WorkloadReference `json:"workload"`
+	Compliant bool              `json:"compliant"`
+	Pods      []Result          `json:"pods"`
+}
+
+// Results holds the latest audit Result for each Pod. It is safe for
+// concurrent use.
+type Results struct {
+	m       sync.RWMutex
+	results map[types.NamespacedName]Result
+}
+
+// NewResults creates an empty Results.
+func NewResults() *Results {
+	return &Results{results: make(map[types.NamespacedName]Result)}
+}
+
+// Set records the Result for a Pod, replacing any previous one.
+func (r *Results) Set(result Result) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	r.results[types.NamespacedName{Namespace: result.Namespace, Name: result.Pod}] = result
+}
+
+// Delete forgets the Result for a Pod, if any.
+func (r *Results) Delete(key types.NamespacedName) {
+	r.m.Lock()
+	defer r.m.Unlock()
+	delete(r.results, key)
+}
+
+// Get returns the Result for a Pod, if any.
+func (r *Results) Get(key types.NamespacedName) (Result, bool) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	result, ok := r.results[key]
+	return result, ok
+}
+
+// Workloads returns the compliance of each workload, sorted by namespace and
+// workload.
+func (r *Results) Workloads() []WorkloadResult {
+	type workloadKey struct {
+		namespace string
+		workload  WorkloadReference
+	}
+	r.m.RLock()
+	byWorkload := make(map[workloadKey]*WorkloadResult)
+	for _, result := range r.results {
+		k := workloadKey{namespace: result.Namespace, workload: result.Workload}
+		wr, ok := byWorkload[k]
+		if !ok {
+			wr = &WorkloadResult{Namespace: result.Namespace, Workload: result.Workload, Compliant: true}
+			byWorkload[k] = wr
+		}
+		wr.Compliant = wr.Compliant && result.Compliant
+		wr.Pods = append(wr.Pods, result)
+	}
+	r.m.RUnlock()
+
+	ret := make([]WorkloadResult, 0, len(byWorkload))
+	for _, wr := range byWorkload {
+		sort.Slice(wr.Pods, func(i, j int) bool { return wr.Pods[i].Pod < wr.Pods[j].Pod })
+		ret = append(ret, *wr)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Namespace != ret[j].Namespace {
+			return ret[i].Namespace < ret[j].Namespace
+		}
+		if ret[i].Workload.Kind != ret[j].Workload.Kind {
+			return ret[i].Workload.Kind < ret[j].Workload.Kind
+		}
+		return ret[i].Workload.Name < ret[j].Workload.Name
+	})
+	return ret
+}
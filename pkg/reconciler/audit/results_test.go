@@ -0,0 +1,51 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestResultsWorkloads(t *testing.T) {
+	rs := WorkloadReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "rs"}
+	bare := WorkloadReference{APIVersion: "v1", Kind: "Pod", Name: "bare"}
+
+	results := NewResults()
+	results.Set(Result{Namespace: "ns", Pod: "rs-b", Workload: rs, Compliant: false, Error: "failed"})
+	results.Set(Result{Namespace: "ns", Pod: "rs-a", Workload: rs, Compliant: true})
+	results.Set(Result{Namespace: "ns", Pod: "bare", Workload: bare, Compliant: true})
+
+	got := results.Workloads()
+	if len(got) != 2 {
+		t.Fatalf("Workloads() returned %d workloads, wanted 2", len(got))
+	}
+	if got[0].Workload != bare || !got[0].Compliant {
+		t.Errorf("Workloads()[0] = %+v, wanted compliant %+v", got[0], bare)
+	}
+	if got[1].Workload != rs || got[1].Compliant {
+		t.Errorf("Workloads()[1] = %+v, wanted non-compliant %+v", got[1], rs)
+	}
+	if len(got[1].Pods) != 2 || got[1].Pods[0].Pod != "rs-a" {
+		t.Errorf("Workloads()[1].Pods = %+v, wanted rs-a and rs-b", got[1].Pods)
+	}
+
+	results.Delete(types.NamespacedName{Namespace: "ns", Name: "rs-b"})
+	got = results.Workloads()
+	if !got[1].Compliant {
+		t.Errorf("Workloads()[1] = %+v, wanted compliant after removing the failing pod", got[1])
+	}
+}
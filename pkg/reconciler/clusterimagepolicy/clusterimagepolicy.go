@@ -26,6 +26,8 @@ import (
 	"github.com/sigstore/policy-controller/pkg/apis/config"
 	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
 	"github.com/sigstore/policy-controller/pkg/apis/signaturealgo"
+	policycel "github.com/sigstore/policy-controller/pkg/cel"
+	"github.com/sigstore/policy-controller/pkg/certificate"
 	clusterimagepolicyreconciler "github.com/sigstore/policy-controller/pkg/client/injection/reconciler/policy/v1alpha1/clusterimagepolicy"
 	"github.com/sigstore/policy-controller/pkg/reconciler/clusterimagepolicy/resources"
 	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
@@ -80,6 +82,9 @@ func (r *Reconciler) ReconcileKind(ctx context.Context, cip *v1alpha1.ClusterIma
 	cip.Status.MarkInlineKeysOk()
 
 	cipErr = r.inlinePolicies(ctx, cipCopy)
+	if cipErr == nil {
+		cipErr = checkPolicies(cipCopy.Spec.Authorities, cipCopy.Spec.Policy)
+	}
 	if cipErr != nil {
 		r.handleCIPError(ctx, cip.Name)
 		// Update the status to reflect that we were unable to inline policies.
@@ -186,6 +191,16 @@ func (r *Reconciler) inlinePublicKeys(ctx context.Context, cip *v1alpha1.Cluster
 				return nil, err
 			}
 		}
+		if authority.Certificate != nil {
+			if err := r.inlineCertificateSecrets(ctx, ret, authority.Certificate); err != nil {
+				return nil, err
+			}
+		}
+		if authority.Notation != nil && authority.Notation.SecretRef != nil {
+			if err := r.inlineNotationTrustStore(ctx, ret, authority.Notation); err != nil {
+				return nil, err
+			}
+		}
 		if authority.Key != nil && strings.Contains(authority.Key.KMS, "://") {
 			pubKeyString, err := getKMSPublicKey(ctx, authority.Key.KMS, authority.Key.HashAlgorithm)
 			if err != nil {
@@ -231,34 +246,92 @@ func getKMSPublicKey(ctx context.Context, keyID string, hashAlgorithm string) (s
 // for now, just grab one from it. For reference, the discussion is here:
 // TODO(vaikas): https://github.com/sigstore/cosign/issues/1573
 func (r *Reconciler) inlineAndTrackSecret(ctx context.Context, cip *v1alpha1.ClusterImagePolicy, keyref *v1alpha1.KeyRef) error {
+	v, err := r.readAndTrackSecret(ctx, cip, keyref.SecretRef)
+	if err != nil {
+		return err
+	}
+	publicKey, err := cryptoutils.UnmarshalPEMToPublicKey(v)
+	if err != nil || publicKey == nil {
+		return fmt.Errorf("secret %q contains an invalid public key: %w", keyref.SecretRef.Name, err)
+	}
+	keyref.Data = string(v)
+	keyref.SecretRef = nil
+	return nil
+}
+
+// inlineCertificateSecrets reads the CA bundle and the certificate revocation
+// lists of a certificate authority from their Secrets, if any, and inlines
+// them in place of the SecretRefs.
+func (r *Reconciler) inlineCertificateSecrets(ctx context.Context, cip *v1alpha1.ClusterImagePolicy, cert *v1alpha1.CertificateRef) error {
+	if cert.SecretRef != nil {
+		v, err := r.readAndTrackSecret(ctx, cip, cert.SecretRef)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("Failed to read secret %q: %v", cert.SecretRef.Name, err)
+			return err
+		}
+		if certs, err := cryptoutils.UnmarshalCertificatesFromPEM(v); err != nil || len(certs) == 0 {
+			return fmt.Errorf("secret %q contains no valid certificates: %w", cert.SecretRef.Name, err)
+		}
+		cert.CABundle = string(v)
+		cert.SecretRef = nil
+	}
+	if cert.CRL != nil && cert.CRL.SecretRef != nil {
+		v, err := r.readAndTrackSecret(ctx, cip, cert.CRL.SecretRef)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("Failed to read secret %q: %v", cert.CRL.SecretRef.Name, err)
+			return err
+		}
+		if _, err := certificate.ParseCRLs(v); err != nil {
+			return fmt.Errorf("secret %q contains invalid certificate revocation lists: %w", cert.CRL.SecretRef.Name, err)
+		}
+		cert.CRL.Data = string(v)
+		cert.CRL.SecretRef = nil
+	}
+	return nil
+}
+
+// inlineNotationTrustStore reads the trust store of a notation authority
+// from its Secret and inlines it in place of the SecretRef.
+func (r *Reconciler) inlineNotationTrustStore(ctx context.Context, cip *v1alpha1.ClusterImagePolicy, notation *v1alpha1.NotationRef) error {
+	v, err := r.readAndTrackSecret(ctx, cip, notation.SecretRef)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Failed to read secret %q: %v", notation.SecretRef.Name, err)
+		return err
+	}
+	if certs, err := cryptoutils.UnmarshalCertificatesFromPEM(v); err != nil || len(certs) == 0 {
+		return fmt.Errorf("secret %q contains no valid certificates: %w", notation.SecretRef.Name, err)
+	}
+	notation.CABundle = string(v)
+	notation.SecretRef = nil
+	return nil
+}
+
+// readAndTrackSecret returns the only data entry of the referenced Secret
+// and sets up a tracker so we will be notified if the secret is modified.
+func (r *Reconciler) readAndTrackSecret(ctx context.Context, cip *v1alpha1.ClusterImagePolicy, ref *corev1.SecretReference) ([]byte, error) {
 	if err := r.tracker.TrackReference(tracker.Reference{
 		APIVersion: "v1",
 		Kind:       "Secret",
 		Namespace:  system.Namespace(),
-		Name:       keyref.SecretRef.Name,
+		Name:       ref.Name,
 	}, cip); err != nil {
-		return fmt.Errorf("failed to track changes to secret %q : %w", keyref.SecretRef.Name, err)
+		return nil, fmt.Errorf("failed to track changes to secret %q : %w", ref.Name, err)
 	}
-	secret, err := r.secretlister.Secrets(system.Namespace()).Get(keyref.SecretRef.Name)
+	secret, err := r.secretlister.Secrets(system.Namespace()).Get(ref.Name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(secret.Data) == 0 {
-		return fmt.Errorf("secret %q contains no data", keyref.SecretRef.Name)
+		return nil, fmt.Errorf("secret %q contains no data", ref.Name)
 	}
 	if len(secret.Data) > 1 {
-		return fmt.Errorf("secret %q contains multiple data entries, only one is supported", keyref.SecretRef.Name)
+		return nil, fmt.Errorf("secret %q contains multiple data entries, only one is supported", ref.Name)
 	}
 	for k, v := range secret.Data {
-		logging.FromContext(ctx).Infof("inlining secret %q key %q", keyref.SecretRef.Name, k)
-		publicKey, err := cryptoutils.UnmarshalPEMToPublicKey(v)
-		if err != nil || publicKey == nil {
-			return fmt.Errorf("secret %q contains an invalid public key: %w", keyref.SecretRef.Name, err)
-		}
-		keyref.Data = string(v)
-		keyref.SecretRef = nil
+		logging.FromContext(ctx).Infof("inlining secret %q key %q", ref.Name, k)
+		return v, nil
 	}
-	return nil
+	return nil, nil
 }
 
 // inlinePolicies will go through the CIP and try to read the referenced
@@ -299,6 +372,35 @@ func (r *Reconciler) inlinePolicies(ctx context.Context, cip *v1alpha1.ClusterIm
 	return nil
 }
 
+// checkPolicies compiles the CEL policies of the authorities' attestations
+// and the policy that come from a ConfigMap or a remote URL. This must be done
+// once the policies are inlined, inline ones are already checked when the
+// policy is admitted.
+func checkPolicies(authorities []v1alpha1.Authority, policy *v1alpha1.Policy) error {
+	for _, authority := range authorities {
+		for _, att := range authority.Attestations {
+			if !isExternalPolicy(att.Policy) {
+				continue
+			}
+			if err := policycel.Check(att.Policy.Type, att.Policy.Data); err != nil {
+				return fmt.Errorf("failed to compile the cel policy of attestation %s of authority %s: %w", att.Name, authority.Name, err)
+			}
+		}
+	}
+	if isExternalPolicy(policy) {
+		if err := policycel.Check(policy.Type, policy.Data); err != nil {
+			return fmt.Errorf("failed to compile the cel policy: %w", err)
+		}
+	}
+	return nil
+}
+
+// isExternalPolicy returns true for policies that are inlined from a ConfigMap
+// or a remote URL.
+func isExternalPolicy(policy *v1alpha1.Policy) bool {
+	return policy != nil && (policy.ConfigMapRef != nil || policy.Remote != nil)
+}
+
 func (r *Reconciler) inlinePolicyURL(ctx context.Context, policyRef *v1alpha1.Policy) error {
 	logging.FromContext(ctx).Infof("inlining policy url %q", policyRef.Remote.URL.String())
 	resp, err := http.Get(policyRef.Remote.URL.String())
@@ -1297,6 +1297,70 @@ malformed KMS format, should be prefixed by any of the supported providers: [aws
 				),
 			}},
 
+			PostConditions: []func(*testing.T, *TableRow){
+				AssertTrackingConfigMap(system.Namespace(), policyCMName),
+			},
+		}, {
+			Name: "Static with CIP level cel policy, configmapref exists, does not compile",
+			Key:  testKey,
+
+			SkipNamespaceValidation: true, // Cluster scoped
+			Objects: []runtime.Object{
+				NewClusterImagePolicy(cipName,
+					WithUID(uid),
+					WithResourceVersion(resourceVersion),
+					WithFinalizer,
+					WithImagePattern(v1alpha1.ImagePattern{
+						Glob: glob,
+					}),
+					WithAuthority(v1alpha1.Authority{
+						Static: &v1alpha1.StaticRef{
+							Action: "pass",
+						}}),
+					WithPolicy(&v1alpha1.Policy{
+						Type: "cel",
+						ConfigMapRef: &v1alpha1.ConfigMapReference{
+							Name: policyCMName,
+							Key:  policyCMKey,
+						},
+					}),
+				),
+				makeConfigMap(),
+				makePolicyConfigMap(policyCMName, map[string]string{policyCMKey: `"not a bool"`}),
+			},
+			WantErr: true,
+			WantPatches: []clientgotesting.PatchActionImpl{
+				makePatch(removeDataPatch),
+			},
+			WantEvents: []string{
+				Eventf(corev1.EventTypeWarning, "InternalError", "failed to compile the cel policy: expression must evaluate to a bool, not string"),
+			},
+			WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+				Object: NewClusterImagePolicy(cipName,
+					WithUID(uid),
+					WithResourceVersion(resourceVersion),
+					WithFinalizer,
+					WithImagePattern(v1alpha1.ImagePattern{
+						Glob: glob,
+					}),
+					WithAuthority(v1alpha1.Authority{
+						Static: &v1alpha1.StaticRef{
+							Action: "pass",
+						}}),
+					WithPolicy(&v1alpha1.Policy{
+						Type: "cel",
+						ConfigMapRef: &v1alpha1.ConfigMapReference{
+							Name: policyCMName,
+							Key:  policyCMKey,
+						},
+					}),
+					WithInitConditions,
+					WithObservedGeneration(1),
+					WithMarkInlineKeysOk,
+					WithMarkInlinePoliciesFailed("failed to compile the cel policy: expression must evaluate to a bool, not string"),
+				),
+			}},
+
 			PostConditions: []func(*testing.T, *TableRow){
 				AssertTrackingConfigMap(system.Namespace(), policyCMName),
 			},
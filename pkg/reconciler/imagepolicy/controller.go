@@ -0,0 +1,76 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagepolicy
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	imagepolicyinformer "github.com/sigstore/policy-controller/pkg/client/injection/informers/policy/v1alpha1/imagepolicy"
+	imagepolicyreconciler "github.com/sigstore/policy-controller/pkg/client/injection/reconciler/policy/v1alpha1/imagepolicy"
+	"github.com/sigstore/policy-controller/pkg/reconciler/clusterimagepolicy"
+	cminformer "knative.dev/pkg/injection/clients/namespacedkube/informers/core/v1/configmap"
+)
+
+// This is what the default finalizer name is, but make it explicit so we can
+// use it in tests as well.
+const finalizerName = "imagepolicies.policy.sigstore.dev"
+
+// NewController creates a Reconciler and returns the result of NewImpl.
+func NewController(
+	ctx context.Context,
+	_ configmap.Watcher,
+) *controller.Impl {
+	imagepolicyInformer := imagepolicyinformer.Get(ctx)
+	configMapInformer := cminformer.Get(ctx)
+
+	r := &Reconciler{
+		configmaplister: configMapInformer.Lister(),
+		kubeclient:      kubeclient.Get(ctx),
+	}
+	impl := imagepolicyreconciler.NewImpl(ctx, r, func(_ *controller.Impl) controller.Options {
+		return controller.Options{FinalizerName: finalizerName}
+	})
+
+	if _, err := imagepolicyInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue)); err != nil {
+		logging.FromContext(ctx).Warnf("Failed imagepolicyInformer AddEventHandler() %v", err)
+	}
+
+	// When the underlying ConfigMap changes, perform a global resync on
+	// ImagePolicies to make sure their state is correctly reflected in the
+	// ConfigMap, same as for ClusterImagePolicies.
+	grCb := func(_ interface{}) {
+		logging.FromContext(ctx).Info("Doing a global resync on ImagePolicies due to ConfigMap changing or resync period.")
+		impl.GlobalResync(imagepolicyInformer.Informer())
+	}
+	if _, err := configMapInformer.Informer().AddEventHandlerWithResyncPeriod(cache.FilteringResourceEventHandler{
+		FilterFunc: pkgreconciler.ChainFilterFuncs(
+			pkgreconciler.NamespaceFilterFunc(system.Namespace()),
+			pkgreconciler.NameFilterFunc(config.ImagePoliciesConfigName)),
+		Handler: controller.HandleAll(grCb),
+	}, clusterimagepolicy.FromContextOrDefaults(ctx)); err != nil {
+		logging.FromContext(ctx).Warnf("Failed configMapInformer AddEventHandlerWithResyncPeriod() %v", err)
+	}
+
+	return impl
+}
@@ -0,0 +1,140 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagepolicy
+
+import (
+	"context"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	imagepolicyreconciler "github.com/sigstore/policy-controller/pkg/client/injection/reconciler/policy/v1alpha1/imagepolicy"
+	"github.com/sigstore/policy-controller/pkg/reconciler/clusterimagepolicy/resources"
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+)
+
+// Reconciler implements imagepolicyreconciler.Interface for
+// ImagePolicy resources.
+type Reconciler struct {
+	configmaplister corev1listers.ConfigMapLister
+	kubeclient      kubernetes.Interface
+}
+
+// Check that our Reconciler implements Interface as well as finalizer
+var _ imagepolicyreconciler.Interface = (*Reconciler)(nil)
+var _ imagepolicyreconciler.Finalizer = (*Reconciler)(nil)
+
+// EntryName returns the name of the entry in the config-image-policies
+// ConfigMap for the given ImagePolicy. Since underscores are not allowed in
+// Kubernetes resource names, these never collide with the entries for
+// ClusterImagePolicies, which are keyed by their name.
+func EntryName(namespace, name string) string {
+	return namespace + "_" + name
+}
+
+// ReconcileKind implements Interface.ReconcileKind.
+func (r *Reconciler) ReconcileKind(ctx context.Context, ip *v1alpha1.ImagePolicy) reconciler.Event {
+	ip.Status.InitializeConditions()
+	entryName := EntryName(ip.Namespace, ip.Name)
+
+	// Unlike ClusterImagePolicies there is nothing to inline, validation
+	// rejects the KMS keys, Secrets, ConfigMaps and remote policies that the
+	// controller would have to resolve on behalf of the namespace.
+	ip.Status.MarkInlineKeysOk()
+	ip.Status.MarkInlinePoliciesOk()
+
+	webhookIP := webhookcip.ConvertImagePolicyV1alpha1ToWebhook(ip)
+
+	// See if the CM holding configs exists
+	existing, err := r.configmaplister.ConfigMaps(system.Namespace()).Get(config.ImagePoliciesConfigName)
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			logging.FromContext(ctx).Errorf("Failed to get configmap: %v", err)
+			ip.Status.MarkCMUpdateFailed(err.Error())
+			return err
+		}
+		// Does not exist, create it.
+		cm, err := resources.NewConfigMap(system.Namespace(), config.ImagePoliciesConfigName, entryName, webhookIP)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("Failed to construct configmap: %v", err)
+			ip.Status.MarkCMUpdateFailed(err.Error())
+			return err
+		}
+		_, err = r.kubeclient.CoreV1().ConfigMaps(system.Namespace()).Create(ctx, cm, metav1.CreateOptions{})
+		if err != nil {
+			ip.Status.MarkCMUpdateFailed(err.Error())
+			return err
+		}
+		ip.Status.MarkCMUpdatedOK()
+		return nil
+	}
+
+	// Check if we need to update the configmap or not.
+	patchBytes, err := resources.CreatePatch(system.Namespace(), config.ImagePoliciesConfigName, entryName, existing.DeepCopy(), webhookIP)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Failed to create patch: %v", err)
+		ip.Status.MarkCMUpdateFailed(err.Error())
+		return err
+	}
+	if len(patchBytes) > 0 {
+		_, err = r.kubeclient.CoreV1().ConfigMaps(system.Namespace()).Patch(ctx, config.ImagePoliciesConfigName, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+		if err != nil {
+			ip.Status.MarkCMUpdateFailed(err.Error())
+			return err
+		}
+	}
+	ip.Status.MarkCMUpdatedOK()
+	return nil
+}
+
+// FinalizeKind implements Interface.ReconcileKind.
+func (r *Reconciler) FinalizeKind(ctx context.Context, ip *v1alpha1.ImagePolicy) reconciler.Event {
+	// See if the CM holding configs even exists
+	existing, err := r.configmaplister.ConfigMaps(system.Namespace()).Get(config.ImagePoliciesConfigName)
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			logging.FromContext(ctx).Errorf("Failed to get configmap: %v", err)
+			return err
+		}
+		// Since the CM doesn't exist, there's nothing for us to clean up.
+		return nil
+	}
+	// CM exists, so remove our entry from it.
+	return r.removeIPEntry(ctx, existing, EntryName(ip.Namespace, ip.Name))
+}
+
+// removeIPEntry removes an entry from a CM. If no entry exists, it's a nop.
+func (r *Reconciler) removeIPEntry(ctx context.Context, cm *corev1.ConfigMap, entryName string) error {
+	patchBytes, err := resources.CreateRemovePatch(system.Namespace(), config.ImagePoliciesConfigName, cm.DeepCopy(), entryName)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Failed to create remove patch: %v", err)
+		return err
+	}
+	if len(patchBytes) > 0 {
+		_, err = r.kubeclient.CoreV1().ConfigMaps(system.Namespace()).Patch(ctx, config.ImagePoliciesConfigName, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package imagepolicy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	fakecosignclient "github.com/sigstore/policy-controller/pkg/client/injection/client/fake"
+	"github.com/sigstore/policy-controller/pkg/client/injection/reconciler/policy/v1alpha1/imagepolicy"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+
+	. "github.com/sigstore/policy-controller/pkg/reconciler/testing/v1alpha1"
+	. "knative.dev/pkg/reconciler/testing"
+	_ "knative.dev/pkg/system/testing"
+)
+
+const (
+	ipNamespace = "tenant"
+	ipName      = "test-ip"
+	testKey     = ipNamespace + "/" + ipName
+	glob        = "ghcr.io/example/*"
+
+	resourceVersion = "0123456789"
+	uid             = "test-uid"
+
+	// Just some public key that was laying around, only format matters.
+	validPublicKeyData = `-----BEGIN PUBLIC KEY-----
+MFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAExB6+H6054/W1SJgs5JR6AJr6J35J
+RCTfQ5s1kD+hGMSE1rH7s46hmXEeyhnlRnaGF8eMU/SBJE/2NKPnxE7WzQ==
+-----END PUBLIC KEY-----`
+
+	ipEntry = `{"uid":"test-uid","resourceVersion":"0123456789","images":[{"glob":"ghcr.io/example/*"}],"authorities":[{"name":"authority-0","key":{"data":"-----BEGIN PUBLIC KEY-----\nMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAExB6+H6054/W1SJgs5JR6AJr6J35J\nRCTfQ5s1kD+hGMSE1rH7s46hmXEeyhnlRnaGF8eMU/SBJE/2NKPnxE7WzQ==\n-----END PUBLIC KEY-----","hashAlgorithm":"sha256"}}],"mode":"enforce","namespace":"tenant"}`
+
+	// This is the patch for removing the last entry, leaving just the
+	// configmap objectmeta, no data.
+	removeDataPatch = `[{"op":"remove","path":"/data"}]`
+
+	// This is the patch for removing only the ImagePolicy entry, leaving the
+	// ClusterImagePolicy with the same name in place.
+	removeSingleEntryPatch = `[{"op":"remove","path":"/data/tenant_test-ip"}]`
+)
+
+func TestReconcile(t *testing.T) {
+	privKMSKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating ecdsa private key: %v", err)
+	}
+
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "foo/not-found",
+	}, {
+		Name: "ImagePolicy is being deleted, doesn't exist, no changes",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewImagePolicy(ipNamespace, ipName,
+				WithImagePolicyDeletionTimestamp),
+		},
+	}, {
+		Name: "ImagePolicy with glob and inline key data, added to cm and finalizer",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewImagePolicy(ipNamespace, ipName,
+				WithImagePolicyUID(uid),
+				WithImagePolicyResourceVersion(resourceVersion),
+				WithImagePolicyImagePattern(v1alpha1.ImagePattern{
+					Glob: glob,
+				}),
+				WithImagePolicyAuthority(v1alpha1.Authority{
+					Key: &v1alpha1.KeyRef{
+						Data: validPublicKeyData,
+					}}))},
+		WantCreates: []runtime.Object{
+			makeConfigMap(),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(ipNamespace, ipName),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated "test-ip" finalizers`),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewImagePolicy(ipNamespace, ipName,
+				WithImagePolicyUID(uid),
+				WithImagePolicyResourceVersion(resourceVersion),
+				WithImagePolicyImagePattern(v1alpha1.ImagePattern{
+					Glob: glob,
+				}),
+				WithImagePolicyAuthority(v1alpha1.Authority{
+					Key: &v1alpha1.KeyRef{
+						Data: validPublicKeyData,
+					}}),
+				MarkImagePolicyReady),
+		}},
+	}, {
+		Name: "ImagePolicy with glob and inline key data, already exists, no patch, no status update",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewImagePolicy(ipNamespace, ipName,
+				WithImagePolicyUID(uid),
+				WithImagePolicyResourceVersion(resourceVersion),
+				WithImagePolicyFinalizer,
+				WithImagePolicyImagePattern(v1alpha1.ImagePattern{
+					Glob: glob,
+				}),
+				WithImagePolicyAuthority(v1alpha1.Authority{
+					Key: &v1alpha1.KeyRef{
+						Data: validPublicKeyData,
+					}}),
+				MarkImagePolicyReady),
+			makeConfigMap(),
+		},
+	}, {
+		Name: "ImagePolicy is being deleted, entry removed from cm",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewImagePolicy(ipNamespace, ipName,
+				WithImagePolicyFinalizer,
+				WithImagePolicyUID(uid),
+				WithImagePolicyResourceVersion(resourceVersion),
+				WithImagePolicyDeletionTimestamp),
+			makeConfigMap(),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchRemoveFinalizers(ipNamespace, ipName),
+			makePatch(removeDataPatch),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated "test-ip" finalizers`),
+		},
+	}, {
+		Name: "ImagePolicy is being deleted, ClusterImagePolicy with the same name is kept",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewImagePolicy(ipNamespace, ipName,
+				WithImagePolicyFinalizer,
+				WithImagePolicyUID(uid),
+				WithImagePolicyResourceVersion(resourceVersion),
+				WithImagePolicyDeletionTimestamp),
+			makeConfigMapWithClusterImagePolicy(),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchRemoveFinalizers(ipNamespace, ipName),
+			makePatch(removeSingleEntryPatch),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated "test-ip" finalizers`),
+		},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, _ configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			configmaplister: listers.GetConfigMapLister(),
+			kubeclient:      fakekubeclient.Get(ctx),
+		}
+		return imagepolicy.NewReconciler(ctx, logger,
+			fakecosignclient.Get(ctx), listers.GetImagePolicyLister(),
+			controller.GetEventRecorder(ctx),
+			r)
+	},
+		false,
+		logger,
+		privKMSKey,
+	))
+}
+
+func makeConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: system.Namespace(),
+			Name:      config.ImagePoliciesConfigName,
+		},
+		Data: map[string]string{
+			EntryName(ipNamespace, ipName): ipEntry,
+		},
+	}
+}
+
+// Same as makeConfigMap but with a ClusterImagePolicy entry that has the same
+// name as the ImagePolicy.
+func makeConfigMapWithClusterImagePolicy() *corev1.ConfigMap {
+	cm := makeConfigMap()
+	cm.Data[ipName] = `{"images":[{"glob":"ghcr.io/example/*"}],"authorities":[{"name":"authority-0","static":{"action":"pass"}}],"mode":"enforce"}`
+	return cm
+}
+
+func makePatch(patch string) clientgotesting.PatchActionImpl {
+	return clientgotesting.PatchActionImpl{
+		ActionImpl: clientgotesting.ActionImpl{
+			Namespace: system.Namespace(),
+		},
+		Name:  config.ImagePoliciesConfigName,
+		Patch: []byte(patch),
+	}
+}
+
+func patchFinalizers(namespace, name string) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	action.Namespace = namespace
+	patch := `{"metadata":{"finalizers":["` + finalizerName + `"],"resourceVersion":"` + resourceVersion + `"}}`
+	action.Patch = []byte(patch)
+	return action
+}
+
+func patchRemoveFinalizers(namespace, name string) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	action.Namespace = namespace
+	patch := `{"metadata":{"finalizers":[],"resourceVersion":"` + resourceVersion + `"}}`
+	action.Patch = []byte(patch)
+	return action
+}
@@ -0,0 +1,79 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyexception
+
+import (
+	"context"
+	"time"
+
+	"k8s.io/client-go/tools/cache"
+	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+	pkgreconciler "knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	policyexceptioninformer "github.com/sigstore/policy-controller/pkg/client/injection/informers/policy/v1alpha1/policyexception"
+	policyexceptionreconciler "github.com/sigstore/policy-controller/pkg/client/injection/reconciler/policy/v1alpha1/policyexception"
+	cminformer "knative.dev/pkg/injection/clients/namespacedkube/informers/core/v1/configmap"
+)
+
+// This is what the default finalizer name is, but make it explicit so we can
+// use it in tests as well.
+const finalizerName = "policyexceptions.policy.sigstore.dev"
+
+// NewController creates a Reconciler and returns the result of NewImpl.
+func NewController(
+	ctx context.Context,
+	_ configmap.Watcher,
+) *controller.Impl {
+	policyexceptionInformer := policyexceptioninformer.Get(ctx)
+	configMapInformer := cminformer.Get(ctx)
+
+	r := &Reconciler{
+		configmaplister: configMapInformer.Lister(),
+		kubeclient:      kubeclient.Get(ctx),
+		now:             time.Now,
+	}
+	impl := policyexceptionreconciler.NewImpl(ctx, r, func(_ *controller.Impl) controller.Options {
+		return controller.Options{FinalizerName: finalizerName}
+	})
+	r.enqueueAfter = impl.EnqueueAfter
+
+	if _, err := policyexceptionInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue)); err != nil {
+		logging.FromContext(ctx).Warnf("Failed policyexceptionInformer AddEventHandler() %v", err)
+	}
+
+	// When the underlying ConfigMap changes, perform a global resync on
+	// PolicyExceptions to make sure their state is correctly reflected in
+	// the ConfigMap.
+	grCb := func(_ interface{}) {
+		logging.FromContext(ctx).Info("Doing a global resync on PolicyExceptions due to ConfigMap changing.")
+		impl.GlobalResync(policyexceptionInformer.Informer())
+	}
+	if _, err := configMapInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: pkgreconciler.ChainFilterFuncs(
+			pkgreconciler.NamespaceFilterFunc(system.Namespace()),
+			pkgreconciler.NameFilterFunc(config.PolicyExceptionsConfigName)),
+		Handler: controller.HandleAll(grCb),
+	}); err != nil {
+		logging.FromContext(ctx).Warnf("Failed configMapInformer AddEventHandler() %v", err)
+	}
+
+	return impl
+}
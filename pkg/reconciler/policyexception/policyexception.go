@@ -0,0 +1,154 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyexception
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	policyexceptionreconciler "github.com/sigstore/policy-controller/pkg/client/injection/reconciler/policy/v1alpha1/policyexception"
+	"github.com/sigstore/policy-controller/pkg/reconciler/policyexception/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+)
+
+// Reconciler implements policyexceptionreconciler.Interface for
+// PolicyException resources.
+type Reconciler struct {
+	configmaplister corev1listers.ConfigMapLister
+	kubeclient      kubernetes.Interface
+	// enqueueAfter is used to reconcile PolicyExceptions again once they
+	// expire.
+	enqueueAfter func(obj interface{}, after time.Duration)
+
+	// For testing.
+	now func() time.Time
+}
+
+// Check that our Reconciler implements Interface as well as finalizer
+var _ policyexceptionreconciler.Interface = (*Reconciler)(nil)
+var _ policyexceptionreconciler.Finalizer = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+func (r *Reconciler) ReconcileKind(ctx context.Context, pe *v1alpha1.PolicyException) reconciler.Event {
+	pe.Status.InitializeConditions()
+
+	compiled := config.ConvertPolicyException(pe)
+	// The webhook ignores expired exceptions on its own, but remove them
+	// from the ConfigMap too so that they do not accumulate there.
+	if compiled.Expired(r.now()) {
+		pe.Status.MarkExpired(fmt.Sprintf("expired at %s", pe.Spec.Expires.UTC().Format(time.RFC3339)))
+		existing, err := r.configmaplister.ConfigMaps(system.Namespace()).Get(config.PolicyExceptionsConfigName)
+		if err != nil {
+			if !apierrs.IsNotFound(err) {
+				logging.FromContext(ctx).Errorf("Failed to get configmap: %v", err)
+				pe.Status.MarkCMUpdateFailed(err.Error())
+				return err
+			}
+		} else if err := r.removePEEntry(ctx, existing, pe.Name); err != nil {
+			pe.Status.MarkCMUpdateFailed(err.Error())
+			return err
+		}
+		pe.Status.MarkCMUpdatedOK()
+		return nil
+	}
+	pe.Status.MarkActive()
+
+	if err := r.updateEntry(ctx, pe.Name, compiled); err != nil {
+		pe.Status.MarkCMUpdateFailed(err.Error())
+		return err
+	}
+	pe.Status.MarkCMUpdatedOK()
+
+	// Reconcile again once the exception expires, to reflect that in its
+	// status and clean it up from the ConfigMap.
+	r.enqueueAfter(pe, pe.Spec.Expires.Sub(r.now()))
+	return nil
+}
+
+// FinalizeKind implements Interface.ReconcileKind.
+func (r *Reconciler) FinalizeKind(ctx context.Context, pe *v1alpha1.PolicyException) reconciler.Event {
+	// See if the CM holding configs even exists
+	existing, err := r.configmaplister.ConfigMaps(system.Namespace()).Get(config.PolicyExceptionsConfigName)
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			logging.FromContext(ctx).Errorf("Failed to get configmap: %v", err)
+			return err
+		}
+		// Since the CM doesn't exist, there's nothing for us to clean up.
+		return nil
+	}
+	// CM exists, so remove our entry from it.
+	return r.removePEEntry(ctx, existing, pe.Name)
+}
+
+// updateEntry adds or updates the entry for the PolicyException in the
+// ConfigMap, creating the ConfigMap if needed.
+func (r *Reconciler) updateEntry(ctx context.Context, name string, pe *config.PolicyException) error {
+	existing, err := r.configmaplister.ConfigMaps(system.Namespace()).Get(config.PolicyExceptionsConfigName)
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			logging.FromContext(ctx).Errorf("Failed to get configmap: %v", err)
+			return err
+		}
+		// Does not exist, create it.
+		cm, err := resources.NewConfigMap(system.Namespace(), config.PolicyExceptionsConfigName, name, pe)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("Failed to construct configmap: %v", err)
+			return err
+		}
+		_, err = r.kubeclient.CoreV1().ConfigMaps(system.Namespace()).Create(ctx, cm, metav1.CreateOptions{})
+		return err
+	}
+
+	// Check if we need to update the configmap or not.
+	patchBytes, err := resources.CreatePatch(name, existing.DeepCopy(), pe)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Failed to create patch: %v", err)
+		return err
+	}
+	if len(patchBytes) > 0 {
+		_, err = r.kubeclient.CoreV1().ConfigMaps(system.Namespace()).Patch(ctx, config.PolicyExceptionsConfigName, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+		return err
+	}
+	return nil
+}
+
+// removePEEntry removes an entry from a CM. If no entry exists, it's a nop.
+func (r *Reconciler) removePEEntry(ctx context.Context, cm *corev1.ConfigMap, peName string) error {
+	patchBytes, err := resources.CreateRemovePatch(cm.DeepCopy(), peName)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Failed to create remove patch: %v", err)
+		return err
+	}
+	if len(patchBytes) > 0 {
+		_, err = r.kubeclient.CoreV1().ConfigMaps(system.Namespace()).Patch(ctx, config.PolicyExceptionsConfigName, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policyexception
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	fakecosignclient "github.com/sigstore/policy-controller/pkg/client/injection/client/fake"
+	"github.com/sigstore/policy-controller/pkg/client/injection/reconciler/policy/v1alpha1/policyexception"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+
+	. "github.com/sigstore/policy-controller/pkg/reconciler/testing/v1alpha1"
+	. "knative.dev/pkg/reconciler/testing"
+	_ "knative.dev/pkg/system/testing"
+)
+
+const (
+	peName  = "test-pe"
+	testKey = peName
+
+	resourceVersion = "0123456789"
+	uid             = "test-uid"
+
+	peEntry = `{"uid":"test-uid","resourceVersion":"0123456789","images":[{"glob":"registry.example.com/vendor/*"}],"namespaces":["vendor"],"policies":["signed-images"],"expires":"2030-01-01T00:00:00Z","justification":"TICKET-123"}`
+
+	// This is the patch for removing the last entry, leaving just the
+	// configmap objectmeta, no data.
+	removeDataPatch = `[{"op":"remove","path":"/data"}]`
+)
+
+var (
+	now = time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	activeSpec = v1alpha1.PolicyExceptionSpec{
+		Images:        []v1alpha1.ImagePattern{{Glob: "registry.example.com/vendor/*"}},
+		Namespaces:    []string{"vendor"},
+		Policies:      []string{"signed-images"},
+		Expires:       metav1.NewTime(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		Justification: "TICKET-123",
+	}
+
+	expiredSpec = v1alpha1.PolicyExceptionSpec{
+		Images:        []v1alpha1.ImagePattern{{Glob: "registry.example.com/vendor/*"}},
+		Namespaces:    []string{"vendor"},
+		Policies:      []string{"signed-images"},
+		Expires:       metav1.NewTime(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)),
+		Justification: "TICKET-123",
+	}
+)
+
+func TestReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "not-found",
+	}, {
+		Name: "PolicyException is being deleted, doesn't exist, no changes",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewPolicyException(peName,
+				WithPolicyExceptionDeletionTimestamp),
+		},
+	}, {
+		Name: "PolicyException added to cm and finalizer",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewPolicyException(peName,
+				WithPolicyExceptionUID(uid),
+				WithPolicyExceptionResourceVersion(resourceVersion),
+				WithPolicyExceptionSpec(activeSpec)),
+		},
+		WantCreates: []runtime.Object{
+			makeConfigMap(),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(peName),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated "test-pe" finalizers`),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewPolicyException(peName,
+				WithPolicyExceptionUID(uid),
+				WithPolicyExceptionResourceVersion(resourceVersion),
+				WithPolicyExceptionSpec(activeSpec),
+				MarkPolicyExceptionReady),
+		}},
+	}, {
+		Name: "PolicyException already in cm, no patch, no status update",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewPolicyException(peName,
+				WithPolicyExceptionUID(uid),
+				WithPolicyExceptionResourceVersion(resourceVersion),
+				WithPolicyExceptionFinalizer,
+				WithPolicyExceptionSpec(activeSpec),
+				MarkPolicyExceptionReady),
+			makeConfigMap(),
+		},
+	}, {
+		Name: "PolicyException expired, entry removed from cm",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewPolicyException(peName,
+				WithPolicyExceptionUID(uid),
+				WithPolicyExceptionResourceVersion(resourceVersion),
+				WithPolicyExceptionFinalizer,
+				WithPolicyExceptionSpec(expiredSpec),
+				MarkPolicyExceptionReady),
+			makeConfigMap(),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			makePatch(removeDataPatch),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewPolicyException(peName,
+				WithPolicyExceptionUID(uid),
+				WithPolicyExceptionResourceVersion(resourceVersion),
+				WithPolicyExceptionFinalizer,
+				WithPolicyExceptionSpec(expiredSpec),
+				MarkPolicyExceptionExpired("expired at 2025-01-01T00:00:00Z")),
+		}},
+	}, {
+		Name: "PolicyException is being deleted, entry removed from cm",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewPolicyException(peName,
+				WithPolicyExceptionFinalizer,
+				WithPolicyExceptionUID(uid),
+				WithPolicyExceptionResourceVersion(resourceVersion),
+				WithPolicyExceptionDeletionTimestamp),
+			makeConfigMap(),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchRemoveFinalizers(peName),
+			makePatch(removeDataPatch),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated "test-pe" finalizers`),
+		},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, _ configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			configmaplister: listers.GetConfigMapLister(),
+			kubeclient:      fakekubeclient.Get(ctx),
+			enqueueAfter:    func(interface{}, time.Duration) {},
+			now:             func() time.Time { return now },
+		}
+		return policyexception.NewReconciler(ctx, logger,
+			fakecosignclient.Get(ctx), listers.GetPolicyExceptionLister(),
+			controller.GetEventRecorder(ctx),
+			r)
+	},
+		false,
+		logger,
+		nil,
+	))
+}
+
+func makeConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: system.Namespace(),
+			Name:      config.PolicyExceptionsConfigName,
+		},
+		Data: map[string]string{
+			peName: peEntry,
+		},
+	}
+}
+
+func makePatch(patch string) clientgotesting.PatchActionImpl {
+	return clientgotesting.PatchActionImpl{
+		ActionImpl: clientgotesting.ActionImpl{
+			Namespace: system.Namespace(),
+		},
+		Name:  config.PolicyExceptionsConfigName,
+		Patch: []byte(patch),
+	}
+}
+
+func patchFinalizers(name string) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	patch := `{"metadata":{"finalizers":["` + finalizerName + `"],"resourceVersion":"` + resourceVersion + `"}}`
+	action.Patch = []byte(patch)
+	return action
+}
+
+func patchRemoveFinalizers(name string) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	patch := `{"metadata":{"finalizers":[],"resourceVersion":"` + resourceVersion + `"}}`
+	action.Patch = []byte(patch)
+	return action
+}
@@ -0,0 +1,121 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"context"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	revocationreconciler "github.com/sigstore/policy-controller/pkg/client/injection/reconciler/policy/v1alpha1/revocation"
+	"github.com/sigstore/policy-controller/pkg/reconciler/revocation/resources"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+
+	"knative.dev/pkg/logging"
+	"knative.dev/pkg/reconciler"
+	"knative.dev/pkg/system"
+)
+
+// Reconciler implements revocationreconciler.Interface for
+// Revocation resources.
+type Reconciler struct {
+	configmaplister corev1listers.ConfigMapLister
+	kubeclient      kubernetes.Interface
+}
+
+// Check that our Reconciler implements Interface as well as finalizer
+var _ revocationreconciler.Interface = (*Reconciler)(nil)
+var _ revocationreconciler.Finalizer = (*Reconciler)(nil)
+
+// ReconcileKind implements Interface.ReconcileKind.
+func (r *Reconciler) ReconcileKind(ctx context.Context, rv *v1alpha1.Revocation) reconciler.Event {
+	rv.Status.InitializeConditions()
+
+	if err := r.updateEntry(ctx, rv.Name, config.ConvertRevocation(rv)); err != nil {
+		rv.Status.MarkCMUpdateFailed(err.Error())
+		return err
+	}
+	rv.Status.MarkCMUpdatedOK()
+	return nil
+}
+
+// FinalizeKind implements Interface.ReconcileKind.
+func (r *Reconciler) FinalizeKind(ctx context.Context, rv *v1alpha1.Revocation) reconciler.Event {
+	// See if the CM holding configs even exists
+	existing, err := r.configmaplister.ConfigMaps(system.Namespace()).Get(config.RevocationsConfigName)
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			logging.FromContext(ctx).Errorf("Failed to get configmap: %v", err)
+			return err
+		}
+		// Since the CM doesn't exist, there's nothing for us to clean up.
+		return nil
+	}
+	// CM exists, so remove our entry from it.
+	return r.removeRVEntry(ctx, existing, rv.Name)
+}
+
+// updateEntry adds or updates the entry for the Revocation in the
+// ConfigMap, creating the ConfigMap if needed.
+func (r *Reconciler) updateEntry(ctx context.Context, name string, rv *config.Revocation) error {
+	existing, err := r.configmaplister.ConfigMaps(system.Namespace()).Get(config.RevocationsConfigName)
+	if err != nil {
+		if !apierrs.IsNotFound(err) {
+			logging.FromContext(ctx).Errorf("Failed to get configmap: %v", err)
+			return err
+		}
+		// Does not exist, create it.
+		cm, err := resources.NewConfigMap(system.Namespace(), config.RevocationsConfigName, name, rv)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("Failed to construct configmap: %v", err)
+			return err
+		}
+		_, err = r.kubeclient.CoreV1().ConfigMaps(system.Namespace()).Create(ctx, cm, metav1.CreateOptions{})
+		return err
+	}
+
+	// Check if we need to update the configmap or not.
+	patchBytes, err := resources.CreatePatch(name, existing.DeepCopy(), rv)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Failed to create patch: %v", err)
+		return err
+	}
+	if len(patchBytes) > 0 {
+		_, err = r.kubeclient.CoreV1().ConfigMaps(system.Namespace()).Patch(ctx, config.RevocationsConfigName, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+		return err
+	}
+	return nil
+}
+
+// removeRVEntry removes an entry from a CM. If no entry exists, it's a nop.
+func (r *Reconciler) removeRVEntry(ctx context.Context, cm *corev1.ConfigMap, rvName string) error {
+	patchBytes, err := resources.CreateRemovePatch(cm.DeepCopy(), rvName)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Failed to create remove patch: %v", err)
+		return err
+	}
+	if len(patchBytes) > 0 {
+		_, err = r.kubeclient.CoreV1().ConfigMaps(system.Namespace()).Patch(ctx, config.RevocationsConfigName, types.JSONPatchType, patchBytes, metav1.PatchOptions{})
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	logtesting "knative.dev/pkg/logging/testing"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	fakecosignclient "github.com/sigstore/policy-controller/pkg/client/injection/client/fake"
+	"github.com/sigstore/policy-controller/pkg/client/injection/reconciler/policy/v1alpha1/revocation"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgotesting "k8s.io/client-go/testing"
+	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/system"
+
+	. "github.com/sigstore/policy-controller/pkg/reconciler/testing/v1alpha1"
+	. "knative.dev/pkg/reconciler/testing"
+	_ "knative.dev/pkg/system/testing"
+)
+
+const (
+	rvName  = "test-rv"
+	testKey = rvName
+
+	resourceVersion = "0123456789"
+	uid             = "test-uid"
+
+	rvEntry = `{"uid":"test-uid","resourceVersion":"0123456789","keyFingerprints":["2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"],"compromisedAt":"2026-01-01T00:00:00Z","reason":"INCIDENT-123"}`
+
+	// This is the patch for removing the last entry, leaving just the
+	// configmap objectmeta, no data.
+	removeDataPatch = `[{"op":"remove","path":"/data"}]`
+)
+
+var spec = v1alpha1.RevocationSpec{
+	KeyFingerprints: []string{"2C:F2:4D:BA:5F:B0:A3:0E:26:E8:3B:2A:C5:B9:E2:9E:1B:16:1E:5C:1F:A7:42:5E:73:04:33:62:93:8B:98:24"},
+	CompromisedAt:   &metav1.Time{Time: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	Reason:          "INCIDENT-123",
+}
+
+func TestReconcile(t *testing.T) {
+	table := TableTest{{
+		Name: "bad workqueue key",
+		// Make sure Reconcile handles bad keys.
+		Key: "too/many/parts",
+	}, {
+		Name: "key not found",
+		// Make sure Reconcile handles good keys that don't exist.
+		Key: "not-found",
+	}, {
+		Name: "Revocation is being deleted, doesn't exist, no changes",
+		Key:  testKey,
+		Objects: []runtime.Object{
+			NewRevocation(rvName,
+				WithRevocationDeletionTimestamp),
+		},
+	}, {
+		Name: "Revocation added to cm and finalizer",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewRevocation(rvName,
+				WithRevocationUID(uid),
+				WithRevocationResourceVersion(resourceVersion),
+				WithRevocationSpec(spec)),
+		},
+		WantCreates: []runtime.Object{
+			makeConfigMap(),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchFinalizers(rvName),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated "test-rv" finalizers`),
+		},
+		WantStatusUpdates: []clientgotesting.UpdateActionImpl{{
+			Object: NewRevocation(rvName,
+				WithRevocationUID(uid),
+				WithRevocationResourceVersion(resourceVersion),
+				WithRevocationSpec(spec),
+				MarkRevocationReady),
+		}},
+	}, {
+		Name: "Revocation already in cm, no patch, no status update",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewRevocation(rvName,
+				WithRevocationUID(uid),
+				WithRevocationResourceVersion(resourceVersion),
+				WithRevocationFinalizer,
+				WithRevocationSpec(spec),
+				MarkRevocationReady),
+			makeConfigMap(),
+		},
+	}, {
+		Name: "Revocation is being deleted, entry removed from cm",
+		Key:  testKey,
+
+		SkipNamespaceValidation: true, // The ConfigMap is in the system namespace
+		Objects: []runtime.Object{
+			NewRevocation(rvName,
+				WithRevocationFinalizer,
+				WithRevocationUID(uid),
+				WithRevocationResourceVersion(resourceVersion),
+				WithRevocationDeletionTimestamp),
+			makeConfigMap(),
+		},
+		WantPatches: []clientgotesting.PatchActionImpl{
+			patchRemoveFinalizers(rvName),
+			makePatch(removeDataPatch),
+		},
+		WantEvents: []string{
+			Eventf(corev1.EventTypeNormal, "FinalizerUpdate", `Updated "test-rv" finalizers`),
+		},
+	}}
+
+	logger := logtesting.TestLogger(t)
+	table.Test(t, MakeFactory(func(ctx context.Context, listers *Listers, _ configmap.Watcher) controller.Reconciler {
+		r := &Reconciler{
+			configmaplister: listers.GetConfigMapLister(),
+			kubeclient:      fakekubeclient.Get(ctx),
+		}
+		return revocation.NewReconciler(ctx, logger,
+			fakecosignclient.Get(ctx), listers.GetRevocationLister(),
+			controller.GetEventRecorder(ctx),
+			r)
+	},
+		false,
+		logger,
+		nil,
+	))
+}
+
+func makeConfigMap() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: system.Namespace(),
+			Name:      config.RevocationsConfigName,
+		},
+		Data: map[string]string{
+			rvName: rvEntry,
+		},
+	}
+}
+
+func makePatch(patch string) clientgotesting.PatchActionImpl {
+	return clientgotesting.PatchActionImpl{
+		ActionImpl: clientgotesting.ActionImpl{
+			Namespace: system.Namespace(),
+		},
+		Name:  config.RevocationsConfigName,
+		Patch: []byte(patch),
+	}
+}
+
+func patchFinalizers(name string) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	patch := `{"metadata":{"finalizers":["` + finalizerName + `"],"resourceVersion":"` + resourceVersion + `"}}`
+	action.Patch = []byte(patch)
+	return action
+}
+
+func patchRemoveFinalizers(name string) clientgotesting.PatchActionImpl {
+	action := clientgotesting.PatchActionImpl{}
+	action.Name = name
+	patch := `{"metadata":{"finalizers":[],"resourceVersion":"` + resourceVersion + `"}}`
+	action.Patch = []byte(patch)
+	return action
+}
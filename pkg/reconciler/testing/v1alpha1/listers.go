@@ -86,6 +86,18 @@ func (l *Listers) GetClusterImagePolicyLister() policylisters.ClusterImagePolicy
 	return policylisters.NewClusterImagePolicyLister(l.indexerFor(&v1alpha1.ClusterImagePolicy{}))
 }
 
+func (l *Listers) GetImagePolicyLister() policylisters.ImagePolicyLister {
+	return policylisters.NewImagePolicyLister(l.indexerFor(&v1alpha1.ImagePolicy{}))
+}
+
+func (l *Listers) GetPolicyExceptionLister() policylisters.PolicyExceptionLister {
+	return policylisters.NewPolicyExceptionLister(l.indexerFor(&v1alpha1.PolicyException{}))
+}
+
+func (l *Listers) GetRevocationLister() policylisters.RevocationLister {
+	return policylisters.NewRevocationLister(l.indexerFor(&v1alpha1.Revocation{}))
+}
+
 func (l *Listers) GetTrustRootLister() policylisters.TrustRootLister {
 	return policylisters.NewTrustRootLister(l.indexerFor(&v1alpha1.TrustRoot{}))
 }
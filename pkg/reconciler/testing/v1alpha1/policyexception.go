@@ -0,0 +1,88 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const policyExceptionFinalizerName = "policyexceptions.policy.sigstore.dev"
+
+// PolicyExceptionOption enables further configuration of a PolicyException.
+type PolicyExceptionOption func(*v1alpha1.PolicyException)
+
+// NewPolicyException creates a PolicyException with PolicyExceptionOptions.
+func NewPolicyException(name string, o ...PolicyExceptionOption) *v1alpha1.PolicyException {
+	pe := &v1alpha1.PolicyException{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Generation: 1,
+		},
+	}
+	for _, opt := range o {
+		opt(pe)
+	}
+	pe.SetDefaults(context.Background())
+	return pe
+}
+
+func WithPolicyExceptionUID(uid string) PolicyExceptionOption {
+	return func(pe *v1alpha1.PolicyException) {
+		pe.UID = types.UID(uid)
+	}
+}
+
+func WithPolicyExceptionResourceVersion(resourceVersion string) PolicyExceptionOption {
+	return func(pe *v1alpha1.PolicyException) {
+		pe.ResourceVersion = resourceVersion
+	}
+}
+
+func WithPolicyExceptionDeletionTimestamp(pe *v1alpha1.PolicyException) {
+	t := metav1.NewTime(time.Unix(1e9, 0))
+	pe.SetDeletionTimestamp(&t)
+}
+
+func WithPolicyExceptionSpec(spec v1alpha1.PolicyExceptionSpec) PolicyExceptionOption {
+	return func(pe *v1alpha1.PolicyException) {
+		pe.Spec = spec
+	}
+}
+
+func WithPolicyExceptionFinalizer(pe *v1alpha1.PolicyException) {
+	pe.Finalizers = []string{policyExceptionFinalizerName}
+}
+
+func MarkPolicyExceptionReady(pe *v1alpha1.PolicyException) {
+	pe.Status.InitializeConditions()
+	pe.Status.MarkActive()
+	pe.Status.MarkCMUpdatedOK()
+	pe.Status.ObservedGeneration = pe.Generation
+}
+
+func MarkPolicyExceptionExpired(msg string) PolicyExceptionOption {
+	return func(pe *v1alpha1.PolicyException) {
+		pe.Status.InitializeConditions()
+		pe.Status.MarkExpired(msg)
+		pe.Status.MarkCMUpdatedOK()
+		pe.Status.ObservedGeneration = pe.Generation
+	}
+}
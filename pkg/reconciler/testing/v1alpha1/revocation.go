@@ -0,0 +1,78 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const revocationFinalizerName = "revocations.policy.sigstore.dev"
+
+// RevocationOption enables further configuration of a Revocation.
+type RevocationOption func(*v1alpha1.Revocation)
+
+// NewRevocation creates a Revocation with RevocationOptions.
+func NewRevocation(name string, o ...RevocationOption) *v1alpha1.Revocation {
+	rv := &v1alpha1.Revocation{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Generation: 1,
+		},
+	}
+	for _, opt := range o {
+		opt(rv)
+	}
+	rv.SetDefaults(context.Background())
+	return rv
+}
+
+func WithRevocationUID(uid string) RevocationOption {
+	return func(rv *v1alpha1.Revocation) {
+		rv.UID = types.UID(uid)
+	}
+}
+
+func WithRevocationResourceVersion(resourceVersion string) RevocationOption {
+	return func(rv *v1alpha1.Revocation) {
+		rv.ResourceVersion = resourceVersion
+	}
+}
+
+func WithRevocationDeletionTimestamp(rv *v1alpha1.Revocation) {
+	t := metav1.NewTime(time.Unix(1e9, 0))
+	rv.SetDeletionTimestamp(&t)
+}
+
+func WithRevocationSpec(spec v1alpha1.RevocationSpec) RevocationOption {
+	return func(rv *v1alpha1.Revocation) {
+		rv.Spec = spec
+	}
+}
+
+func WithRevocationFinalizer(rv *v1alpha1.Revocation) {
+	rv.Finalizers = []string{revocationFinalizerName}
+}
+
+func MarkRevocationReady(rv *v1alpha1.Revocation) {
+	rv.Status.InitializeConditions()
+	rv.Status.MarkCMUpdatedOK()
+	rv.Status.ObservedGeneration = rv.Generation
+}
@@ -0,0 +1,218 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package slsa checks SLSA provenance attestations against the builders,
+// build types and sources that a policy allows, so that these common checks
+// do not have to be written as cue or rego. Both the v0.2 and v1 provenance
+// predicate formats are understood.
+package slsa
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+)
+
+const (
+	// PredicateTypeV02 is the predicate type of SLSA v0.2 provenance.
+	PredicateTypeV02 = "https://slsa.dev/provenance/v0.2"
+	// PredicateTypeV1 is the predicate type of SLSA v1 provenance.
+	PredicateTypeV1 = "https://slsa.dev/provenance/v1"
+)
+
+// statement is the subset of an in-toto statement needed to tell the
+// provenance versions apart.
+type statement struct {
+	PredicateType string          `json:"predicateType"`
+	Predicate     json.RawMessage `json:"predicate"`
+}
+
+// provenanceV02 is the subset of the v0.2 provenance predicate that is
+// checked.
+type provenanceV02 struct {
+	Builder struct {
+		ID string `json:"id"`
+	} `json:"builder"`
+	BuildType  string `json:"buildType"`
+	Invocation struct {
+		ConfigSource struct {
+			URI string `json:"uri"`
+		} `json:"configSource"`
+	} `json:"invocation"`
+	Materials []struct {
+		URI string `json:"uri"`
+	} `json:"materials"`
+}
+
+// provenanceV1 is the subset of the v1 provenance predicate that is checked.
+type provenanceV1 struct {
+	BuildDefinition struct {
+		BuildType          string `json:"buildType"`
+		ExternalParameters struct {
+			// Workflow is set by the GitHub Actions build type.
+			Workflow struct {
+				Repository string `json:"repository"`
+				Ref        string `json:"ref"`
+			} `json:"workflow"`
+		} `json:"externalParameters"`
+		ResolvedDependencies []struct {
+			URI string `json:"uri"`
+		} `json:"resolvedDependencies"`
+	} `json:"buildDefinition"`
+	RunDetails struct {
+		Builder struct {
+			ID string `json:"id"`
+		} `json:"builder"`
+	} `json:"runDetails"`
+}
+
+// provenance holds the fields that are checked, independent of the
+// predicate version they came from.
+type provenance struct {
+	builderID string
+	buildType string
+	sourceURI string
+	sourceRef string
+}
+
+// Verify checks that the in-toto statement holding a SLSA provenance
+// predicate satisfies the requirements.
+func Verify(requirements *v1alpha1.SLSA, statementJSON []byte) error {
+	p, err := parse(statementJSON)
+	if err != nil {
+		return err
+	}
+
+	builder := matchBuilder(requirements.Builders, p.builderID)
+	if builder == nil {
+		return fmt.Errorf("builder %q is not one of the allowed builders", p.builderID)
+	}
+	if builder.BuildLevel < requirements.MinBuildLevel {
+		return fmt.Errorf("builder %q is at SLSA build level %d, wanted at least %d", p.builderID, builder.BuildLevel, requirements.MinBuildLevel)
+	}
+	if len(requirements.BuildTypes) > 0 && !contains(requirements.BuildTypes, p.buildType) {
+		return fmt.Errorf("build type %q is not one of the allowed build types", p.buildType)
+	}
+	if len(requirements.SourceURIs) > 0 {
+		allowed := false
+		for _, uri := range requirements.SourceURIs {
+			if normalizeURI(uri) == normalizeURI(p.sourceURI) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("source repository %q is not one of the allowed source repositories", p.sourceURI)
+		}
+	}
+	if len(requirements.SourceRefs) > 0 && !contains(requirements.SourceRefs, p.sourceRef) {
+		return fmt.Errorf("source ref %q is not one of the allowed source refs", p.sourceRef)
+	}
+	return nil
+}
+
+func parse(statementJSON []byte) (*provenance, error) {
+	var s statement
+	if err := json.Unmarshal(statementJSON, &s); err != nil {
+		return nil, fmt.Errorf("unmarshaling statement: %w", err)
+	}
+	switch s.PredicateType {
+	case PredicateTypeV02:
+		var pred provenanceV02
+		if err := json.Unmarshal(s.Predicate, &pred); err != nil {
+			return nil, fmt.Errorf("unmarshaling v0.2 provenance: %w", err)
+		}
+		p := &provenance{
+			builderID: pred.Builder.ID,
+			buildType: pred.BuildType,
+		}
+		source := pred.Invocation.ConfigSource.URI
+		if source == "" && len(pred.Materials) > 0 {
+			source = pred.Materials[0].URI
+		}
+		p.sourceURI, p.sourceRef = splitSource(source)
+		return p, nil
+
+	case PredicateTypeV1:
+		var pred provenanceV1
+		if err := json.Unmarshal(s.Predicate, &pred); err != nil {
+			return nil, fmt.Errorf("unmarshaling v1 provenance: %w", err)
+		}
+		p := &provenance{
+			builderID: pred.RunDetails.Builder.ID,
+			buildType: pred.BuildDefinition.BuildType,
+		}
+		// The source is builder specific in v1, so prefer the workflow of
+		// GitHub Actions builds and fall back to the first resolved
+		// dependency, which is where the other builders record it.
+		workflow := pred.BuildDefinition.ExternalParameters.Workflow
+		switch {
+		case workflow.Repository != "":
+			p.sourceURI, p.sourceRef = workflow.Repository, workflow.Ref
+		case len(pred.BuildDefinition.ResolvedDependencies) > 0:
+			p.sourceURI, p.sourceRef = splitSource(pred.BuildDefinition.ResolvedDependencies[0].URI)
+		}
+		return p, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported SLSA provenance predicate type %q", s.PredicateType)
+	}
+}
+
+// matchBuilder returns the allowed builder with the given id. An allowed
+// builder without a version matches any version of it.
+func matchBuilder(builders []v1alpha1.SLSABuilder, id string) *v1alpha1.SLSABuilder {
+	unversioned, _, _ := strings.Cut(id, "@")
+	for i := range builders {
+		if builders[i].ID == id || (!strings.Contains(builders[i].ID, "@") && builders[i].ID == unversioned) {
+			return &builders[i]
+		}
+	}
+	return nil
+}
+
+// splitSource splits a source like git+https://github.com/org/repo@refs/heads/main
+// into the repository and the ref.
+func splitSource(source string) (string, string) {
+	// Only look for the ref in the path, an @ before it is user info.
+	pathStart := 0
+	if i := strings.Index(source, "://"); i >= 0 {
+		pathStart = i + len("://")
+	}
+	if i := strings.Index(source[pathStart:], "/"); i >= 0 {
+		pathStart += i
+	}
+	if i := strings.LastIndex(source[pathStart:], "@"); i >= 0 {
+		return source[:pathStart+i], source[pathStart+i+1:]
+	}
+	return source, ""
+}
+
+// normalizeURI strips the optional git+ prefix and .git suffix so that
+// repositories can be compared however they are spelled.
+func normalizeURI(uri string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(uri, "git+"), ".git")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slsa
+
+import (
+	"testing"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+)
+
+const (
+	generatorV1 = "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml"
+
+	provenanceV02Statement = `{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "predicateType": "https://slsa.dev/provenance/v0.2",
+  "predicate": {
+    "builder": {"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.9.0"},
+    "buildType": "https://github.com/slsa-framework/slsa-github-generator/container@v1",
+    "invocation": {
+      "configSource": {"uri": "git+https://github.com/sigstore/policy-controller@refs/heads/main"}
+    }
+  }
+}`
+
+	provenanceV1Statement = `{
+  "_type": "https://in-toto.io/Statement/v1",
+  "predicateType": "https://slsa.dev/provenance/v1",
+  "predicate": {
+    "buildDefinition": {
+      "buildType": "https://actions.github.io/buildtypes/workflow/v1",
+      "externalParameters": {
+        "workflow": {"repository": "https://github.com/sigstore/policy-controller", "ref": "refs/tags/v1.0.0"}
+      }
+    },
+    "runDetails": {
+      "builder": {"id": "https://github.com/actions/runner/github-hosted"}
+    }
+  }
+}`
+
+	provenanceV1DependencyStatement = `{
+  "_type": "https://in-toto.io/Statement/v1",
+  "predicateType": "https://slsa.dev/provenance/v1",
+  "predicate": {
+    "buildDefinition": {
+      "buildType": "https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1",
+      "resolvedDependencies": [{"uri": "git+https://github.com/sigstore/policy-controller.git@refs/heads/main"}]
+    },
+    "runDetails": {
+      "builder": {"id": "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v2.0.0"}
+    }
+  }
+}`
+)
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements v1alpha1.SLSA
+		statement    string
+		wantErr      string
+	}{{
+		name: "v0.2, any version of the builder",
+		requirements: v1alpha1.SLSA{
+			Builders:      []v1alpha1.SLSABuilder{{ID: generatorV1, BuildLevel: 3}},
+			BuildTypes:    []string{"https://github.com/slsa-framework/slsa-github-generator/container@v1"},
+			SourceURIs:    []string{"https://github.com/sigstore/policy-controller.git"},
+			SourceRefs:    []string{"refs/heads/main"},
+			MinBuildLevel: 3,
+		},
+		statement: provenanceV02Statement,
+	}, {
+		name: "v0.2, exact version of the builder",
+		requirements: v1alpha1.SLSA{
+			Builders: []v1alpha1.SLSABuilder{{ID: generatorV1 + "@refs/tags/v1.9.0"}},
+		},
+		statement: provenanceV02Statement,
+	}, {
+		name: "v0.2, other version of the builder",
+		requirements: v1alpha1.SLSA{
+			Builders: []v1alpha1.SLSABuilder{{ID: generatorV1 + "@refs/tags/v1.10.0"}},
+		},
+		statement: provenanceV02Statement,
+		wantErr:   `builder "` + generatorV1 + `@refs/tags/v1.9.0" is not one of the allowed builders`,
+	}, {
+		name: "v0.2, build level too low",
+		requirements: v1alpha1.SLSA{
+			Builders:      []v1alpha1.SLSABuilder{{ID: generatorV1, BuildLevel: 2}},
+			MinBuildLevel: 3,
+		},
+		statement: provenanceV02Statement,
+		wantErr:   `builder "` + generatorV1 + `@refs/tags/v1.9.0" is at SLSA build level 2, wanted at least 3`,
+	}, {
+		name: "v0.2, wrong build type",
+		requirements: v1alpha1.SLSA{
+			Builders:   []v1alpha1.SLSABuilder{{ID: generatorV1}},
+			BuildTypes: []string{"https://github.com/slsa-framework/slsa-github-generator/generic@v1"},
+		},
+		statement: provenanceV02Statement,
+		wantErr:   `build type "https://github.com/slsa-framework/slsa-github-generator/container@v1" is not one of the allowed build types`,
+	}, {
+		name: "v0.2, wrong source repository",
+		requirements: v1alpha1.SLSA{
+			Builders:   []v1alpha1.SLSABuilder{{ID: generatorV1}},
+			SourceURIs: []string{"git+https://github.com/sigstore/cosign"},
+		},
+		statement: provenanceV02Statement,
+		wantErr:   `source repository "git+https://github.com/sigstore/policy-controller" is not one of the allowed source repositories`,
+	}, {
+		name: "v1, GitHub Actions workflow",
+		requirements: v1alpha1.SLSA{
+			Builders:   []v1alpha1.SLSABuilder{{ID: "https://github.com/actions/runner/github-hosted", BuildLevel: 2}},
+			SourceURIs: []string{"git+https://github.com/sigstore/policy-controller"},
+			SourceRefs: []string{"refs/tags/v1.0.0"},
+		},
+		statement: provenanceV1Statement,
+	}, {
+		name: "v1, wrong source ref",
+		requirements: v1alpha1.SLSA{
+			Builders:   []v1alpha1.SLSABuilder{{ID: "https://github.com/actions/runner/github-hosted"}},
+			SourceRefs: []string{"refs/heads/main"},
+		},
+		statement: provenanceV1Statement,
+		wantErr:   `source ref "refs/tags/v1.0.0" is not one of the allowed source refs`,
+	}, {
+		name: "v1, source from the resolved dependencies",
+		requirements: v1alpha1.SLSA{
+			Builders:      []v1alpha1.SLSABuilder{{ID: generatorV1, BuildLevel: 3}},
+			SourceURIs:    []string{"https://github.com/sigstore/policy-controller"},
+			SourceRefs:    []string{"refs/heads/main"},
+			MinBuildLevel: 3,
+		},
+		statement: provenanceV1DependencyStatement,
+	}, {
+		name: "unsupported predicate type",
+		requirements: v1alpha1.SLSA{
+			Builders: []v1alpha1.SLSABuilder{{ID: generatorV1}},
+		},
+		statement: `{"predicateType": "https://slsa.dev/provenance/v0.1", "predicate": {}}`,
+		wantErr:   `unsupported SLSA provenance predicate type "https://slsa.dev/provenance/v0.1"`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Verify(&tc.requirements, []byte(tc.statement))
+			switch {
+			case err == nil && tc.wantErr != "":
+				t.Errorf("Verify() succeeded, wanted error %q", tc.wantErr)
+			case err != nil && tc.wantErr == "":
+				t.Errorf("Verify() = %v", err)
+			case err != nil && err.Error() != tc.wantErr:
+				t.Errorf("Verify() = %v, wanted %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSplitSource(t *testing.T) {
+	tests := []struct {
+		source, wantURI, wantRef string
+	}{
+		{"git+https://github.com/org/repo@refs/heads/main", "git+https://github.com/org/repo", "refs/heads/main"},
+		{"git+ssh://git@github.com/org/repo@v1", "git+ssh://git@github.com/org/repo", "v1"},
+		{"git+ssh://git@github.com/org/repo", "git+ssh://git@github.com/org/repo", ""},
+		{"https://github.com/org/repo", "https://github.com/org/repo", ""},
+	}
+	for _, tc := range tests {
+		uri, ref := splitSource(tc.source)
+		if uri != tc.wantURI || ref != tc.wantRef {
+			t.Errorf("splitSource(%q) = %q, %q, wanted %q, %q", tc.source, uri, ref, tc.wantURI, tc.wantRef)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The tracing configuration lives in the config-observability ConfigMap,
+// next to knative's metrics configuration.
+const (
+	// BackendKey is where the traces are exported to, one of BackendNone
+	// (the default) or BackendOTLP.
+	BackendKey = "tracing.backend"
+
+	// EndpointKey is the host:port of the OTLP gRPC collector the traces
+	// are exported to.
+	EndpointKey = "tracing.endpoint"
+
+	// SampleRateKey is the fraction of the admission requests that are
+	// traced, between 0 and 1. Defaults to 1.
+	SampleRateKey = "tracing.sample-rate"
+
+	// InsecureKey disables TLS when talking to the collector.
+	InsecureKey = "tracing.insecure"
+
+	// BackendNone disables tracing.
+	BackendNone = "none"
+
+	// BackendOTLP exports the traces through OTLP over gRPC.
+	BackendOTLP = "otlp"
+)
+
+// Config holds the tracing configuration.
+type Config struct {
+	Backend    string
+	Endpoint   string
+	SampleRate float64
+	Insecure   bool
+}
+
+// Enabled returns true if traces are exported.
+func (c *Config) Enabled() bool {
+	return c != nil && c.Backend != BackendNone
+}
+
+// NewConfigFromMap creates a Config from the data of the
+// config-observability ConfigMap.
+func NewConfigFromMap(data map[string]string) (*Config, error) {
+	ret := &Config{Backend: BackendNone, SampleRate: 1}
+	switch backend := data[BackendKey]; backend {
+	case "", BackendNone:
+	case BackendOTLP:
+		ret.Backend = BackendOTLP
+	default:
+		return nil, fmt.Errorf("invalid %s %q: must be %q or %q", BackendKey, backend, BackendNone, BackendOTLP)
+	}
+	ret.Endpoint = data[EndpointKey]
+	if ret.Backend == BackendOTLP && ret.Endpoint == "" {
+		return nil, fmt.Errorf("%s is required when %s is %q", EndpointKey, BackendKey, BackendOTLP)
+	}
+	if val, ok := data[SampleRateKey]; ok {
+		rate, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", SampleRateKey, val, err)
+		}
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid %s %q: must be between 0 and 1", SampleRateKey, val)
+		}
+		ret.SampleRate = rate
+	}
+	if val, ok := data[InsecureKey]; ok {
+		var err error
+		ret.Insecure, err = strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", InsecureKey, val, err)
+		}
+	}
+	return ret, nil
+}
+
+// NewConfigFromConfigMap creates a Config from the config-observability
+// ConfigMap.
+func NewConfigFromConfigMap(cm *corev1.ConfigMap) (*Config, error) {
+	return NewConfigFromMap(cm.Data)
+}
@@ -0,0 +1,74 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewConfigFromMap(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string]string
+		want    *Config
+		wantErr bool
+	}{{
+		name: "defaults",
+		want: &Config{Backend: BackendNone, SampleRate: 1},
+	}, {
+		name: "otlp",
+		data: map[string]string{
+			BackendKey:    BackendOTLP,
+			EndpointKey:   "otel-collector.observability:4317",
+			SampleRateKey: "0.25",
+			InsecureKey:   "true",
+		},
+		want: &Config{Backend: BackendOTLP, Endpoint: "otel-collector.observability:4317", SampleRate: 0.25, Insecure: true},
+	}, {
+		name:    "unknown backend",
+		data:    map[string]string{BackendKey: "zipkin"},
+		wantErr: true,
+	}, {
+		name:    "otlp without endpoint",
+		data:    map[string]string{BackendKey: BackendOTLP},
+		wantErr: true,
+	}, {
+		name:    "sample rate out of range",
+		data:    map[string]string{SampleRateKey: "1.5"},
+		wantErr: true,
+	}, {
+		name:    "invalid sample rate",
+		data:    map[string]string{SampleRateKey: "all"},
+		wantErr: true,
+	}, {
+		name:    "invalid insecure",
+		data:    map[string]string{InsecureKey: "yes please"},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NewConfigFromMap(tc.data)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("NewConfigFromMap() = %v, wanted error %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewConfigFromMap() (-want,+got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing instruments the policy evaluation with OpenTelemetry
+// spans. Spans are always created through the global TracerProvider, which
+// is a no-op unless a Provider has been configured to export them.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	tracerName  = "github.com/sigstore/policy-controller"
+	serviceName = "policy-controller"
+)
+
+// Attributes attached to the spans.
+const (
+	ImageKey         = attribute.Key("policy_controller.image")
+	PolicyKey        = attribute.Key("policy_controller.policy")
+	AuthorityKey     = attribute.Key("policy_controller.authority")
+	AuthorityTypeKey = attribute.Key("policy_controller.authority_type")
+	NamespaceKey     = attribute.Key("policy_controller.namespace")
+	KindKey          = attribute.Key("policy_controller.kind")
+	PoliciesKey      = attribute.Key("policy_controller.policies")
+	TrustRootKey     = attribute.Key("policy_controller.trust_root")
+	RekorURLKey      = attribute.Key("policy_controller.rekor_url")
+	CacheHitKey      = attribute.Key("policy_controller.cache_hit")
+	SharedKey        = attribute.Key("policy_controller.shared")
+)
+
+// Start creates a span as a child of the span in ctx, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	// Always go through the global provider so that spans are exported to
+	// the currently configured backend.
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err, if any, on the span and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Transport wraps inner so that each registry round trip is recorded as a
+// span, a child of the span in the context of the request.
+func Transport(inner http.RoundTripper) http.RoundTripper {
+	return &transport{inner: inner}
+}
+
+type transport struct {
+	inner http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := Start(req.Context(), "registry.request",
+		attribute.String("http.request.method", req.Method),
+		attribute.String("server.address", req.URL.Host),
+		attribute.String("url.path", req.URL.Path))
+	resp, err := t.inner.RoundTrip(req.WithContext(ctx))
+	if err == nil {
+		span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+	}
+	End(span, err)
+	return resp, err
+}
+
+// Provider installs the global TracerProvider according to the tracing
+// Config, and replaces it whenever the Config changes.
+type Provider struct {
+	mu     sync.Mutex
+	config *Config
+	tp     *sdktrace.TracerProvider
+}
+
+// NewProvider creates a new Provider, tracing is disabled until it is
+// configured through Update.
+func NewProvider() *Provider {
+	return &Provider{config: &Config{Backend: BackendNone}}
+}
+
+// Update installs a TracerProvider exporting to the backend in config, or a
+// no-op one if tracing is disabled, and shuts down the previous one.
+func (p *Provider) Update(ctx context.Context, config *Config) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if *config == *p.config {
+		return nil
+	}
+
+	var tp *sdktrace.TracerProvider
+	if config.Enabled() {
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return fmt.Errorf("creating OTLP exporter: %w", err)
+		}
+		tp = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRate))),
+			sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		)
+		otel.SetTracerProvider(tp)
+	} else {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	}
+
+	old := p.tp
+	p.tp = tp
+	p.config = config
+	if old != nil {
+		if err := old.Shutdown(ctx); err != nil {
+			return fmt.Errorf("shutting down previous tracer provider: %w", err)
+		}
+	}
+	return nil
+}
+
+// Shutdown flushes the pending spans and stops exporting them.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.tp == nil {
+		return nil
+	}
+	err := p.tp.Shutdown(ctx)
+	p.tp = nil
+	p.config = &Config{Backend: BackendNone}
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	return err
+}
+
+// Observer returns a function to pass to configmap.Watcher.Watch for the
+// config-observability ConfigMap.
+func (p *Provider) Observer(ctx context.Context) func(*corev1.ConfigMap) {
+	return func(cm *corev1.ConfigMap) {
+		config, err := NewConfigFromConfigMap(cm)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("Failed to parse tracing configuration: %v", err)
+			return
+		}
+		if err := p.Update(ctx, config); err != nil {
+			logging.FromContext(ctx).Errorf("Failed to configure tracing: %v", err)
+		}
+	}
+}
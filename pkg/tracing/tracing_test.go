@@ -0,0 +1,165 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func withSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	old := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(old)
+		_ = tp.Shutdown(context.Background())
+	})
+	return recorder
+}
+
+func TestStartEnd(t *testing.T) {
+	recorder := withSpanRecorder(t)
+
+	ctx, parent := Start(context.Background(), "parent", ImageKey.String("gcr.io/example/image"))
+	_, child := Start(ctx, "child", PolicyKey.String("cip"))
+	End(child, errors.New("failed"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, wanted 2", len(spans))
+	}
+	child0, parent0 := spans[0], spans[1]
+	if child0.Parent().SpanID() != parent0.SpanContext().SpanID() {
+		t.Errorf("child span is not a child of the parent span")
+	}
+	if child0.Status().Code != codes.Error || child0.Status().Description != "failed" {
+		t.Errorf("child status = %+v, wanted error", child0.Status())
+	}
+	if parent0.Status().Code != codes.Unset {
+		t.Errorf("parent status = %+v, wanted unset", parent0.Status())
+	}
+	if got := parent0.Attributes(); len(got) != 1 || got[0] != ImageKey.String("gcr.io/example/image") {
+		t.Errorf("parent attributes = %v", got)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	recorder := withSpanRecorder(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/missing/manifests/latest" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx, parent := Start(context.Background(), "parent")
+	client := &http.Client{Transport: Transport(http.DefaultTransport)}
+	for _, path := range []string{"/v2/", "/v2/missing/manifests/latest"} {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+path, nil)
+		if err != nil {
+			t.Fatalf("NewRequest() = %v", err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Do() = %v", err)
+		}
+		resp.Body.Close()
+	}
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, wanted 3", len(spans))
+	}
+	for _, s := range spans[:2] {
+		if s.Name() != "registry.request" {
+			t.Errorf("span name = %s, wanted registry.request", s.Name())
+		}
+		if s.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Errorf("registry span %s is not a child of the parent span", s.Name())
+		}
+	}
+	if spans[0].Status().Code != codes.Unset {
+		t.Errorf("status of successful request = %+v, wanted unset", spans[0].Status())
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Errorf("status of failed request = %+v, wanted error", spans[1].Status())
+	}
+}
+
+func TestProviderDisabled(t *testing.T) {
+	p := NewProvider()
+	cfg, err := NewConfigFromMap(nil)
+	if err != nil {
+		t.Fatalf("NewConfigFromMap() = %v", err)
+	}
+	if err := p.Update(context.Background(), cfg); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	if p.tp != nil {
+		t.Error("Update() with tracing disabled installed a tracer provider")
+	}
+	_, span := Start(context.Background(), "noop")
+	if span.SpanContext().IsValid() {
+		t.Error("Start() with tracing disabled created a recording span")
+	}
+	End(span, nil)
+	if err := p.Shutdown(context.Background()); err != nil {
+		t.Errorf("Shutdown() = %v", err)
+	}
+}
+
+func TestProviderOTLP(t *testing.T) {
+	old := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(old) })
+
+	ctx := context.Background()
+	p := NewProvider()
+	// Nothing is sampled so that nothing is actually exported.
+	cfg := &Config{Backend: BackendOTLP, Endpoint: "127.0.0.1:4317", SampleRate: 0, Insecure: true}
+	if err := p.Update(ctx, cfg); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	if p.tp == nil {
+		t.Fatal("Update() with OTLP did not install a tracer provider")
+	}
+	_, span := Start(ctx, "exported")
+	if !span.SpanContext().IsValid() {
+		t.Error("Start() with tracing enabled did not create a span")
+	}
+	span.End()
+
+	// Disabling tracing again replaces the provider.
+	if err := p.Update(ctx, &Config{Backend: BackendNone, SampleRate: 1}); err != nil {
+		t.Fatalf("Update() = %v", err)
+	}
+	if p.tp != nil {
+		t.Error("Update() with tracing disabled kept the tracer provider")
+	}
+}
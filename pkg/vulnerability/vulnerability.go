@@ -0,0 +1,321 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package vulnerability checks cosign vulnerability scan attestations
+// against the number of vulnerabilities of each severity that a policy
+// allows, and how old the scan may be, so that these common checks do not
+// have to be written as cue or rego. Vulnerabilities that OpenVEX statements
+// declare the image is not affected by are not counted.
+package vulnerability
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+)
+
+const (
+	// OpenVEXPredicateType is the prefix of the (versioned) predicate types
+	// of OpenVEX documents.
+	OpenVEXPredicateType = "https://openvex.dev/ns"
+
+	// unknownSeverity is what vulnerabilities without a recognized severity
+	// are counted as.
+	unknownSeverity = "UNKNOWN"
+)
+
+// vulnStatement is the subset of an in-toto statement holding a cosign vuln
+// predicate that is checked.
+type vulnStatement struct {
+	Predicate struct {
+		Scanner struct {
+			Result json.RawMessage `json:"result"`
+		} `json:"scanner"`
+		Metadata struct {
+			ScanStartedOn  time.Time `json:"scanStartedOn"`
+			ScanFinishedOn time.Time `json:"scanFinishedOn"`
+		} `json:"metadata"`
+	} `json:"predicate"`
+}
+
+// trivyResult is the subset of the JSON report of Trivy that is checked.
+type trivyResult struct {
+	SchemaVersion *int `json:"SchemaVersion"`
+	Results       []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID string `json:"VulnerabilityID"`
+			Severity        string `json:"Severity"`
+		} `json:"Vulnerabilities"`
+	} `json:"Results"`
+}
+
+// grypeResult is the subset of the JSON report of Grype that is checked.
+type grypeResult struct {
+	Matches *[]struct {
+		Vulnerability struct {
+			ID       string `json:"id"`
+			Severity string `json:"severity"`
+		} `json:"vulnerability"`
+	} `json:"matches"`
+}
+
+// vexStatement is the subset of an in-toto statement holding an OpenVEX
+// document that is checked.
+type vexStatement struct {
+	Subject []struct {
+		Digest map[string]string `json:"digest"`
+	} `json:"subject"`
+	Predicate struct {
+		Statements []struct {
+			// Vulnerability is a string in OpenVEX v0.0.1, and an object in
+			// later versions.
+			Vulnerability json.RawMessage `json:"vulnerability"`
+			// Products are strings in OpenVEX v0.0.1, and objects in later
+			// versions.
+			Products []json.RawMessage `json:"products"`
+			Status   string            `json:"status"`
+		} `json:"statements"`
+	} `json:"predicate"`
+}
+
+// vexProduct is the subset of an OpenVEX product that identifies it.
+type vexProduct struct {
+	ID          string            `json:"@id"`
+	Identifiers map[string]string `json:"identifiers"`
+	Hashes      map[string]string `json:"hashes"`
+}
+
+type vulnerability struct {
+	id       string
+	severity string
+}
+
+// IsOpenVEX returns whether predicateType is the predicate type of an
+// OpenVEX document.
+func IsOpenVEX(predicateType string) bool {
+	return predicateType == OpenVEXPredicateType || strings.HasPrefix(predicateType, OpenVEXPredicateType+"/")
+}
+
+// NotAffected returns the vulnerabilities that the in-toto statement holding
+// an OpenVEX document declares are not_affected or fixed in the image with
+// the given digest, for example sha256:be5d77c6... Only the OpenVEX
+// statements about the image are taken into account, that is those whose
+// products name its digest, and those without products when the in-toto
+// statement is about the image.
+func NotAffected(statementJSON []byte, digest string) ([]string, error) {
+	var s vexStatement
+	if err := json.Unmarshal(statementJSON, &s); err != nil {
+		return nil, fmt.Errorf("unmarshaling OpenVEX statement: %w", err)
+	}
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid digest %q", digest)
+	}
+	aboutImage := false
+	for _, subject := range s.Subject {
+		if subject.Digest[algorithm] == hex {
+			aboutImage = true
+		}
+	}
+	var ret []string
+	for _, st := range s.Predicate.Statements {
+		if st.Status != "not_affected" && st.Status != "fixed" {
+			continue
+		}
+		if len(st.Products) == 0 && !aboutImage {
+			continue
+		}
+		if len(st.Products) > 0 && !namesDigest(st.Products, algorithm, hex) {
+			continue
+		}
+		var name string
+		if err := json.Unmarshal(st.Vulnerability, &name); err == nil {
+			ret = append(ret, name)
+			continue
+		}
+		var v struct {
+			Name    string   `json:"name"`
+			Aliases []string `json:"aliases"`
+		}
+		if err := json.Unmarshal(st.Vulnerability, &v); err != nil {
+			return nil, fmt.Errorf("unmarshaling OpenVEX vulnerability: %w", err)
+		}
+		ret = append(ret, v.Name)
+		ret = append(ret, v.Aliases...)
+	}
+	return ret, nil
+}
+
+// namesDigest returns true if one of the OpenVEX products is the image with
+// the given digest, for example pkg:oci/app@sha256%3Abe5d77c6...
+func namesDigest(products []json.RawMessage, algorithm, hex string) bool {
+	names := func(id string) bool {
+		id = strings.ToLower(id)
+		return strings.Contains(id, algorithm+":"+hex) || strings.Contains(id, algorithm+"%3a"+hex)
+	}
+	for _, raw := range products {
+		var id string
+		if err := json.Unmarshal(raw, &id); err == nil {
+			if names(id) {
+				return true
+			}
+			continue
+		}
+		var product vexProduct
+		if err := json.Unmarshal(raw, &product); err != nil {
+			continue
+		}
+		if names(product.ID) {
+			return true
+		}
+		for _, identifier := range product.Identifiers {
+			if names(identifier) {
+				return true
+			}
+		}
+		// OpenVEX names the hash algorithms sha-256, sha-512, etc.
+		for hashAlgorithm, value := range product.Hashes {
+			if strings.ReplaceAll(hashAlgorithm, "-", "") == algorithm && strings.EqualFold(value, hex) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ScannedOn returns when the scan in the in-toto statement holding a cosign
+// vuln predicate was run, or the zero time if it does not record it.
+func ScannedOn(statementJSON []byte) (time.Time, error) {
+	var s vulnStatement
+	if err := json.Unmarshal(statementJSON, &s); err != nil {
+		return time.Time{}, fmt.Errorf("unmarshaling vuln statement: %w", err)
+	}
+	return s.scannedOn(), nil
+}
+
+func (s *vulnStatement) scannedOn() time.Time {
+	if scannedOn := s.Predicate.Metadata.ScanFinishedOn; !scannedOn.IsZero() {
+		return scannedOn
+	}
+	return s.Predicate.Metadata.ScanStartedOn
+}
+
+// Verify checks that the in-toto statement holding a cosign vuln predicate
+// satisfies the requirements as of now. The notAffected vulnerabilities are
+// not counted, on top of the ignored ones in the requirements.
+func Verify(requirements *v1alpha1.Vulnerability, statementJSON []byte, notAffected []string, now time.Time) error {
+	var s vulnStatement
+	if err := json.Unmarshal(statementJSON, &s); err != nil {
+		return fmt.Errorf("unmarshaling vuln statement: %w", err)
+	}
+
+	if requirements.MaxScanAge != nil {
+		scannedOn := s.scannedOn()
+		if scannedOn.IsZero() {
+			return errors.New("scan does not record when it was run")
+		}
+		if now.Sub(scannedOn) > requirements.MaxScanAge.Duration {
+			return fmt.Errorf("scan from %s is older than %s", scannedOn.Format(time.RFC3339), requirements.MaxScanAge.Duration)
+		}
+	}
+
+	if len(requirements.MaxSeverityCounts) == 0 {
+		return nil
+	}
+	vulns, err := parseResult(s.Predicate.Scanner.Result)
+	if err != nil {
+		return err
+	}
+	ignored := make(map[string]struct{}, len(requirements.IgnoredCVEs)+len(notAffected))
+	for _, id := range requirements.IgnoredCVEs {
+		ignored[id] = struct{}{}
+	}
+	for _, id := range notAffected {
+		ignored[id] = struct{}{}
+	}
+	// The same vulnerability is frequently reported for several packages,
+	// so only count it once.
+	found := map[string][]string{}
+	seen := map[vulnerability]struct{}{}
+	for _, v := range vulns {
+		if _, ok := ignored[v.id]; ok {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		found[v.severity] = append(found[v.severity], v.id)
+	}
+
+	severities := make([]string, 0, len(requirements.MaxSeverityCounts))
+	for severity := range requirements.MaxSeverityCounts {
+		severities = append(severities, severity)
+	}
+	sort.Strings(severities)
+	var errs []error
+	for _, severity := range severities {
+		if ids, maxCount := found[severity], requirements.MaxSeverityCounts[severity]; len(ids) > int(maxCount) {
+			sort.Strings(ids)
+			errs = append(errs, fmt.Errorf("found %d %s vulnerabilities, at most %d are allowed: %s", len(ids), severity, maxCount, strings.Join(ids, ", ")))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// parseResult returns the vulnerabilities in the scanner result, which is the
+// JSON report of either Trivy or Grype.
+func parseResult(result json.RawMessage) ([]vulnerability, error) {
+	if len(result) == 0 {
+		return nil, errors.New("scan has no result")
+	}
+	var trivy trivyResult
+	if err := json.Unmarshal(result, &trivy); err == nil && (trivy.SchemaVersion != nil || trivy.Results != nil) {
+		var ret []vulnerability
+		for _, r := range trivy.Results {
+			for _, v := range r.Vulnerabilities {
+				ret = append(ret, vulnerability{id: v.VulnerabilityID, severity: normalizeSeverity(v.Severity)})
+			}
+		}
+		return ret, nil
+	}
+	var grype grypeResult
+	if err := json.Unmarshal(result, &grype); err == nil && grype.Matches != nil {
+		ret := make([]vulnerability, 0, len(*grype.Matches))
+		for _, m := range *grype.Matches {
+			ret = append(ret, vulnerability{id: m.Vulnerability.ID, severity: normalizeSeverity(m.Vulnerability.Severity)})
+		}
+		return ret, nil
+	}
+	return nil, errors.New("scan result is neither a Trivy nor a Grype report")
+}
+
+// normalizeSeverity maps the severities of the different scanners onto
+// CRITICAL, HIGH, MEDIUM, LOW and UNKNOWN.
+func normalizeSeverity(severity string) string {
+	switch s := strings.ToUpper(severity); s {
+	case "CRITICAL", "HIGH", "MEDIUM", "LOW":
+		return s
+	case "NEGLIGIBLE":
+		return "LOW"
+	default:
+		return unknownSeverity
+	}
+}
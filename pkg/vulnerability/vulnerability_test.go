@@ -0,0 +1,217 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package vulnerability
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	trivyStatement = `{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "predicateType": "https://cosign.sigstore.dev/attestation/vuln/v1",
+  "predicate": {
+    "scanner": {
+      "uri": "pkg:github/aquasecurity/trivy@0.50.0",
+      "result": {
+        "SchemaVersion": 2,
+        "Results": [{
+          "Target": "debian 12",
+          "Vulnerabilities": [
+            {"VulnerabilityID": "CVE-2023-0001", "Severity": "CRITICAL"},
+            {"VulnerabilityID": "CVE-2023-0001", "Severity": "CRITICAL"},
+            {"VulnerabilityID": "CVE-2023-0002", "Severity": "HIGH"},
+            {"VulnerabilityID": "CVE-2023-0003", "Severity": "HIGH"}
+          ]
+        }]
+      }
+    },
+    "metadata": {
+      "scanStartedOn": "2026-10-01T10:00:00Z",
+      "scanFinishedOn": "2026-10-01T10:05:00Z"
+    }
+  }
+}`
+
+	grypeStatement = `{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "predicateType": "https://cosign.sigstore.dev/attestation/vuln/v1",
+  "predicate": {
+    "scanner": {
+      "uri": "pkg:github/anchore/grype@0.74.0",
+      "result": {
+        "matches": [
+          {"vulnerability": {"id": "CVE-2023-0004", "severity": "Critical"}},
+          {"vulnerability": {"id": "CVE-2023-0005", "severity": "Negligible"}}
+        ]
+      }
+    },
+    "metadata": {
+      "scanStartedOn": "0001-01-01T00:00:00Z",
+      "scanFinishedOn": "0001-01-01T00:00:00Z"
+    }
+  }
+}`
+
+	imageDigest = "sha256:1111111111111111111111111111111111111111111111111111111111111111"
+	otherDigest = "sha256:2222222222222222222222222222222222222222222222222222222222222222"
+
+	openVEXStatement = `{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "predicateType": "https://openvex.dev/ns/v0.2.0",
+  "subject": [{"name": "ghcr.io/example/app", "digest": {"sha256": "1111111111111111111111111111111111111111111111111111111111111111"}}],
+  "predicate": {
+    "@context": "https://openvex.dev/ns/v0.2.0",
+    "statements": [
+      {"vulnerability": {"name": "CVE-2023-0001", "aliases": ["GHSA-xxxx-xxxx-xxxx"]}, "status": "not_affected", "justification": "vulnerable_code_not_in_execute_path"},
+      {"vulnerability": {"name": "CVE-2023-0002"}, "status": "affected"},
+      {"vulnerability": "CVE-2023-0003", "status": "fixed"},
+      {"vulnerability": {"name": "CVE-2023-0004"}, "products": [{"@id": "pkg:oci/app@sha256%3A2222222222222222222222222222222222222222222222222222222222222222"}], "status": "not_affected"},
+      {"vulnerability": {"name": "CVE-2023-0005"}, "products": [{"@id": "pkg:oci/app@sha256%3A1111111111111111111111111111111111111111111111111111111111111111"}], "status": "not_affected"},
+      {"vulnerability": {"name": "CVE-2023-0006"}, "products": [{"@id": "app", "hashes": {"sha-256": "1111111111111111111111111111111111111111111111111111111111111111"}}], "status": "fixed"}
+    ]
+  }
+}`
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2026, time.October, 3, 10, 5, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		requirements v1alpha1.Vulnerability
+		statement    string
+		notAffected  []string
+		wantErr      string
+	}{{
+		name:         "within the severity counts",
+		requirements: v1alpha1.Vulnerability{MaxSeverityCounts: map[string]int32{"CRITICAL": 1, "HIGH": 2}},
+		statement:    trivyStatement,
+	}, {
+		name:         "too many vulnerabilities",
+		requirements: v1alpha1.Vulnerability{MaxSeverityCounts: map[string]int32{"CRITICAL": 0, "HIGH": 1, "LOW": 0}},
+		statement:    trivyStatement,
+		wantErr:      "found 1 CRITICAL vulnerabilities, at most 0 are allowed: CVE-2023-0001\nfound 2 HIGH vulnerabilities, at most 1 are allowed: CVE-2023-0002, CVE-2023-0003",
+	}, {
+		name: "ignored vulnerabilities",
+		requirements: v1alpha1.Vulnerability{
+			MaxSeverityCounts: map[string]int32{"CRITICAL": 0, "HIGH": 1},
+			IgnoredCVEs:       []string{"CVE-2023-0001", "CVE-2023-0002"},
+		},
+		statement: trivyStatement,
+	}, {
+		name:         "not affected vulnerabilities",
+		requirements: v1alpha1.Vulnerability{MaxSeverityCounts: map[string]int32{"CRITICAL": 0, "HIGH": 1}},
+		statement:    trivyStatement,
+		notAffected:  []string{"CVE-2023-0001", "CVE-2023-0003"},
+	}, {
+		name:         "grype report",
+		requirements: v1alpha1.Vulnerability{MaxSeverityCounts: map[string]int32{"CRITICAL": 0, "LOW": 0}},
+		statement:    grypeStatement,
+		wantErr:      "found 1 CRITICAL vulnerabilities, at most 0 are allowed: CVE-2023-0004\nfound 1 LOW vulnerabilities, at most 0 are allowed: CVE-2023-0005",
+	}, {
+		name:         "recent scan",
+		requirements: v1alpha1.Vulnerability{MaxScanAge: &metav1.Duration{Duration: 7 * 24 * time.Hour}},
+		statement:    trivyStatement,
+	}, {
+		name:         "old scan",
+		requirements: v1alpha1.Vulnerability{MaxScanAge: &metav1.Duration{Duration: 24 * time.Hour}},
+		statement:    trivyStatement,
+		wantErr:      "scan from 2026-10-01T10:05:00Z is older than 24h0m0s",
+	}, {
+		name:         "scan without time",
+		requirements: v1alpha1.Vulnerability{MaxScanAge: &metav1.Duration{Duration: 24 * time.Hour}},
+		statement:    grypeStatement,
+		wantErr:      "scan does not record when it was run",
+	}, {
+		name:         "unknown report",
+		requirements: v1alpha1.Vulnerability{MaxSeverityCounts: map[string]int32{"CRITICAL": 0}},
+		statement:    `{"predicate": {"scanner": {"result": {"vulns": []}}}}`,
+		wantErr:      "scan result is neither a Trivy nor a Grype report",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Verify(&tc.requirements, []byte(tc.statement), tc.notAffected, now)
+			switch {
+			case err == nil && tc.wantErr != "":
+				t.Errorf("Verify() succeeded, wanted error %q", tc.wantErr)
+			case err != nil && tc.wantErr == "":
+				t.Errorf("Verify() = %v", err)
+			case err != nil && err.Error() != tc.wantErr:
+				t.Errorf("Verify() = %v, wanted %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNotAffected(t *testing.T) {
+	tests := []struct {
+		name   string
+		digest string
+		want   []string
+	}{{
+		name:   "statements about the image",
+		digest: imageDigest,
+		want:   []string{"CVE-2023-0001", "GHSA-xxxx-xxxx-xxxx", "CVE-2023-0003", "CVE-2023-0005", "CVE-2023-0006"},
+	}, {
+		name:   "statements about another image",
+		digest: otherDigest,
+		want:   []string{"CVE-2023-0004"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NotAffected([]byte(openVEXStatement), tc.digest)
+			if err != nil {
+				t.Fatalf("NotAffected() = %v", err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("NotAffected() = %v, wanted %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestScannedOn(t *testing.T) {
+	for statement, want := range map[string]time.Time{
+		trivyStatement: time.Date(2026, time.October, 1, 10, 5, 0, 0, time.UTC),
+		grypeStatement: {},
+	} {
+		got, err := ScannedOn([]byte(statement))
+		if err != nil {
+			t.Fatalf("ScannedOn() = %v", err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("ScannedOn() = %v, wanted %v", got, want)
+		}
+	}
+}
+
+func TestIsOpenVEX(t *testing.T) {
+	for predicateType, want := range map[string]bool{
+		"https://openvex.dev/ns":                          true,
+		"https://openvex.dev/ns/v0.2.0":                   true,
+		"https://openvex.dev/nsfoo":                       false,
+		"https://cosign.sigstore.dev/attestation/vuln/v1": false,
+	} {
+		if got := IsOpenVEX(predicateType); got != want {
+			t.Errorf("IsOpenVEX(%q) = %t, wanted %t", predicateType, got, want)
+		}
+	}
+}
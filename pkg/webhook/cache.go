@@ -17,10 +17,19 @@ package webhook
 
 import (
 	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
 )
 
 type cacheKey struct{}
 
+// This is attached to contexts passed to ValidatePolicy so that results are
+// only reused for admissions that fetch the image with the same credentials.
+type keychainIdentityKey struct{}
+
 // CacheResult wraps PolicyResult and errors that are suitable for caching
 // purposes. By doing this we can make choices that control things like, should
 // errors be cached, and if so, for how long that's independent of the
@@ -44,11 +53,39 @@ func ToContext(ctx context.Context, cache ResultCache) context.Context {
 	return context.WithValue(ctx, cacheKey{}, cache)
 }
 
+// withKeychainIdentity records in ctx the identity of the credentials the
+// images are fetched with, that is the namespace, service account and image
+// pull secrets that the keychain is built from.
+func withKeychainIdentity(ctx context.Context, opt k8schain.Options) context.Context {
+	secrets := slices.Clone(opt.ImagePullSecrets)
+	slices.Sort(secrets)
+	identity := opt.Namespace + "/" + opt.ServiceAccountName + "/" + strings.Join(secrets, ",")
+	return context.WithValue(ctx, keychainIdentityKey{}, identity)
+}
+
+// resultKey returns the key that identifies the result of evaluating a
+// policy against ref with the credentials recorded in ctx, and false if
+// there is none. Only images referenced by digest have one, since a tag could
+// resolve to different images, and only when the credentials are known,
+// since they decide which signatures and attestations can be fetched.
+func resultKey(ctx context.Context, ref name.Reference) (string, bool) {
+	digest, ok := ref.(name.Digest)
+	if !ok {
+		return "", false
+	}
+	identity, ok := ctx.Value(keychainIdentityKey{}).(string)
+	if !ok {
+		return "", false
+	}
+	return digest.Name() + "|" + identity, true
+}
+
 type ResultCache interface {
 	// Set caches a PolicyResult for a given CIP evaluated for a given image at
 	// a particular point in time. image, uid & resourceVersion will give a
 	// unique point in time, so we can make sure we're not caching things that
-	// are out of date.
+	// are out of date. image is the key returned by resultKey, so that
+	// results are not shared across credentials.
 	Set(ctx context.Context, image, name, uid, resourceVersion string, cacheResult *CacheResult)
 
 	// Get returns a cached result for a given image or nil if there are none.
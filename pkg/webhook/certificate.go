@@ -0,0 +1,103 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	"github.com/sigstore/policy-controller/pkg/certificate"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+// certificateAuthorities returns the root and intermediate certificates of
+// a certificate authority, either from the inline PEM encoded caBundle or
+// from the referred TrustRoot.
+func certificateAuthorities(ctx context.Context, caBundle, trustRootRef string) ([]*x509.Certificate, error) {
+	if trustRootRef == "" {
+		certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(caBundle))
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling certificates: %w", err)
+		}
+		return certs, nil
+	}
+	sigstoreKeys, err := sigstoreKeysFromContext(ctx, trustRootRef)
+	if err != nil {
+		return nil, fmt.Errorf("getting SigstoreKeys: %w", err)
+	}
+	sk, ok := sigstoreKeys.SigstoreKeys[trustRootRef]
+	if !ok {
+		return nil, fmt.Errorf("trustRootRef %s not found", trustRootRef)
+	}
+	var ret []*x509.Certificate
+	for _, ca := range sk.CertificateAuthorities {
+		certs, err := cryptoutils.UnmarshalCertificatesFromPEM(config.SerializeCertChain(ca.CertChain))
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling certificates: %w", err)
+		}
+		ret = append(ret, certs...)
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("trustRootRef %s has no certificate authorities", trustRootRef)
+	}
+	return ret, nil
+}
+
+// filterByCertificateAuthority returns the signatures whose certificate
+// meets the constraints of the certificate authority.
+func filterByCertificateAuthority(ctx context.Context, sigs []oci.Signature, certRef *v1alpha1.CertificateRef) ([]oci.Signature, error) {
+	cas, err := certificateAuthorities(ctx, certRef.CABundle, certRef.TrustRootRef)
+	if err != nil {
+		return nil, fmt.Errorf("getting certificate authorities: %w", err)
+	}
+	return filterByCertificate(sigs, certRef, cas, time.Now())
+}
+
+// filterByCertificate returns the signatures whose certificate meets the
+// constraints of the certificate authority. Cosign has already verified
+// that the certificates chain up to cas.
+func filterByCertificate(sigs []oci.Signature, certRef *v1alpha1.CertificateRef, cas []*x509.Certificate, now time.Time) ([]oci.Signature, error) {
+	ret := make([]oci.Signature, 0, len(sigs))
+	var errs []error
+	for _, sig := range sigs {
+		cert, err := sig.Cert()
+		if err != nil || cert == nil {
+			errs = append(errs, errors.New("signature has no certificate"))
+			continue
+		}
+		chain, err := sig.Chain()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("getting certificate chain: %w", err))
+			continue
+		}
+		issuers := certificate.Issuers(cert, cas, chain)
+		if err := certificate.Verify(cert, issuers, certRef, now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		ret = append(ret, sig)
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("none of the certificates met the constraints of the certificate authority: %w", errors.Join(errs...))
+	}
+	return ret, nil
+}
@@ -19,6 +19,7 @@ import (
 	"crypto"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/authn/kubernetes"
@@ -29,6 +30,7 @@ import (
 	signaturealgo "github.com/sigstore/policy-controller/pkg/apis/signaturealgo"
 	"github.com/sigstore/policy-controller/pkg/webhook/registryauth"
 	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"knative.dev/pkg/apis"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
@@ -61,6 +63,19 @@ type ClusterImagePolicy struct {
 	Mode string `json:"mode,omitempty"`
 	// Match allows selecting resources based on their properties.
 	Match []v1alpha1.MatchResource `json:"match,omitempty"`
+	// AuthorityThreshold is the number of Authorities that must be
+	// satisfied for the image to be admitted. Zero means one.
+	// +optional
+	AuthorityThreshold int32 `json:"authorityThreshold,omitempty"`
+	// VerifyPlatforms, if set, also requires the Authorities to be
+	// satisfied by the platform manifests of image indexes.
+	// +optional
+	VerifyPlatforms *v1alpha1.PlatformVerification `json:"verifyPlatforms,omitempty"`
+	// Namespace is set when this policy was compiled from a namespaced
+	// ImagePolicy, in which case it only applies to resources in that
+	// namespace. It is empty for ClusterImagePolicies.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 }
 
 type Authority struct {
@@ -73,6 +88,10 @@ type Authority struct {
 	// +optional
 	Keyless *KeylessRef `json:"keyless,omitempty"`
 	// +optional
+	Certificate *v1alpha1.CertificateRef `json:"certificate,omitempty"`
+	// +optional
+	Notation *v1alpha1.NotationRef `json:"notation,omitempty"`
+	// +optional
 	Static *StaticRef `json:"static,omitempty"`
 	// +optional
 	Sources []v1alpha1.Source `json:"source,omitempty"`
@@ -107,6 +126,14 @@ type KeyRef struct {
 	// errors for *big.Int
 	// +optional
 	PublicKeys []crypto.PublicKey `json:"-"`
+	// ValidFrom is the time from which signatures made with the key are
+	// trusted.
+	// +optional
+	ValidFrom *metav1.Time `json:"validFrom,omitempty"`
+	// ValidUntil is the time until which signatures made with the key are
+	// trusted.
+	// +optional
+	ValidUntil *metav1.Time `json:"validUntil,omitempty"`
 }
 
 type KeylessRef struct {
@@ -134,11 +161,17 @@ type AttestationPolicy struct {
 	Name string `json:"name"`
 	// PredicateType to attest, one of the accepted in verify-attestation
 	PredicateType string `json:"predicateType"`
-	// Type specifies how to evaluate policy, only rego/cue are understood.
+	// Type specifies how to evaluate policy, only rego/cue/cel are understood.
 	Type string `json:"type,omitempty"`
 	// Data is the inlined version of the Policy used to evaluate the
 	// Attestation.
 	Data string `json:"data,omitempty"`
+	// SLSA holds the checks to run against a SLSA provenance Attestation,
+	// alongside or instead of the Policy.
+	SLSA *v1alpha1.SLSA `json:"slsa,omitempty"`
+	// Vulnerability holds the checks to run against a vulnerability scan
+	// Attestation, alongside or instead of the Policy.
+	Vulnerability *v1alpha1.Vulnerability `json:"vulnerability,omitempty"`
 	// FetchConfigFile controls whether ConfigFile will be fetched and made
 	// available for CIP level policy evaluation. Note that this only gets
 	// evaluated (and hence fetched) iff at least one authority matches.
@@ -159,6 +192,12 @@ type AttestationPolicy struct {
 	// evaluated iff at least one authority matches.
 	// +optional
 	IncludeTypeMeta *bool `json:"includeTypeMeta,omitempty"`
+
+	// IncludeAttestationPayloads controls whether the predicates of the
+	// verified attestations will be included and made available for CIP
+	// level policy evaluation.
+	// +optional
+	IncludeAttestationPayloads *bool `json:"includeAttestationPayloads,omitempty"`
 }
 
 // RFC3161Timestamp specifies the URL to a RFC3161 time-stamping server that holds
@@ -200,6 +239,21 @@ func (k *KeyRef) UnmarshalJSON(data []byte) error {
 	}
 	k.PublicKeys = publicKeys
 
+	if ret["validFrom"] != "" {
+		validFrom, err := time.Parse(time.RFC3339, ret["validFrom"])
+		if err != nil {
+			return fmt.Errorf("failed to parse validFrom %w", err)
+		}
+		k.ValidFrom = &metav1.Time{Time: validFrom}
+	}
+	if ret["validUntil"] != "" {
+		validUntil, err := time.Parse(time.RFC3339, ret["validUntil"])
+		if err != nil {
+			return fmt.Errorf("failed to parse validUntil %w", err)
+		}
+		k.ValidUntil = &metav1.Time{Time: validUntil}
+	}
+
 	return nil
 }
 
@@ -299,18 +353,39 @@ func ConvertClusterImagePolicyV1alpha1ToWebhook(in *v1alpha1.ClusterImagePolicy)
 		if in.Spec.Policy.IncludeTypeMeta != nil {
 			cipAttestationPolicy.IncludeTypeMeta = ptr.Bool(*in.Spec.Policy.IncludeTypeMeta)
 		}
+		if in.Spec.Policy.IncludeAttestationPayloads != nil {
+			cipAttestationPolicy.IncludeAttestationPayloads = ptr.Bool(*in.Spec.Policy.IncludeAttestationPayloads)
+		}
+	}
+	var authorityThreshold int32
+	if in.Spec.AuthorityThreshold != nil {
+		authorityThreshold = *in.Spec.AuthorityThreshold
 	}
 	return &ClusterImagePolicy{
-		UID:             copyIn.UID,
-		ResourceVersion: copyIn.ResourceVersion,
-		Images:          copyIn.Spec.Images,
-		Authorities:     outAuthorities,
-		Policy:          cipAttestationPolicy,
-		Mode:            in.Spec.Mode,
-		Match:           in.Spec.Match,
+		UID:                copyIn.UID,
+		ResourceVersion:    copyIn.ResourceVersion,
+		Images:             copyIn.Spec.Images,
+		Authorities:        outAuthorities,
+		Policy:             cipAttestationPolicy,
+		Mode:               in.Spec.Mode,
+		Match:              in.Spec.Match,
+		AuthorityThreshold: authorityThreshold,
+		VerifyPlatforms:    copyIn.Spec.VerifyPlatforms,
 	}
 }
 
+// ConvertImagePolicyV1alpha1ToWebhook converts a namespaced ImagePolicy into
+// the same internal representation used for ClusterImagePolicies, scoped to
+// the namespace of the ImagePolicy.
+func ConvertImagePolicyV1alpha1ToWebhook(in *v1alpha1.ImagePolicy) *ClusterImagePolicy {
+	ret := ConvertClusterImagePolicyV1alpha1ToWebhook(&v1alpha1.ClusterImagePolicy{
+		ObjectMeta: in.ObjectMeta,
+		Spec:       in.Spec,
+	})
+	ret.Namespace = in.Namespace
+	return ret
+}
+
 func convertAuthorityV1Alpha1ToWebhook(in v1alpha1.Authority) *Authority {
 	keyRef := convertKeyRefV1Alpha1ToWebhook(in.Key)
 	keylessRef := convertKeylessRefV1Alpha1ToWebhook(in.Keyless)
@@ -322,6 +397,8 @@ func convertAuthorityV1Alpha1ToWebhook(in v1alpha1.Authority) *Authority {
 		Name:             in.Name,
 		Key:              keyRef,
 		Keyless:          keylessRef,
+		Certificate:      in.Certificate,
+		Notation:         in.Notation,
 		Static:           staticRef,
 		Sources:          in.Sources,
 		CTLog:            in.CTLog,
@@ -347,6 +424,8 @@ func convertAttestationsV1Alpha1ToWebhook(in []v1alpha1.Attestation) []Attestati
 		outAtt := AttestationPolicy{
 			Name:          inAtt.Name,
 			PredicateType: inAtt.PredicateType,
+			SLSA:          inAtt.SLSA,
+			Vulnerability: inAtt.Vulnerability,
 		}
 		if inAtt.Policy != nil {
 			outAtt.Type = inAtt.Policy.Type
@@ -363,6 +442,9 @@ func convertAttestationsV1Alpha1ToWebhook(in []v1alpha1.Attestation) []Attestati
 			if inAtt.Policy.IncludeTypeMeta != nil {
 				outAtt.IncludeTypeMeta = ptr.Bool(*inAtt.Policy.IncludeTypeMeta)
 			}
+			if inAtt.Policy.IncludeAttestationPayloads != nil {
+				outAtt.IncludeAttestationPayloads = ptr.Bool(*inAtt.Policy.IncludeAttestationPayloads)
+			}
 		}
 		ret = append(ret, outAtt)
 	}
@@ -386,6 +468,8 @@ func convertKeyRefV1Alpha1ToWebhook(in *v1alpha1.KeyRef) *KeyRef {
 		Data:              in.Data,
 		HashAlgorithm:     algorithm,
 		HashAlgorithmCode: algorithmCode,
+		ValidFrom:         in.ValidFrom.DeepCopy(),
+		ValidUntil:        in.ValidUntil.DeepCopy(),
 	}
 }
 
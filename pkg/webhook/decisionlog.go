@@ -0,0 +1,529 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	policycontrollerconfig "github.com/sigstore/policy-controller/pkg/config"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// decisionLogQueueSize is how many decisions can be waiting to be sent
+	// to an HTTP endpoint before admission waits for room.
+	decisionLogQueueSize = 1000
+	// decisionLogQueueTimeout is how long admission waits for room in a full
+	// queue before the decision is dropped.
+	decisionLogQueueTimeout = time.Second
+	// decisionLogHTTPTimeout is how long sending a decision to an HTTP
+	// endpoint can take.
+	decisionLogHTTPTimeout = 10 * time.Second
+	// decisionLogHTTPAttempts is how many times sending a decision to an HTTP
+	// endpoint is tried before it is dropped.
+	decisionLogHTTPAttempts = 5
+	// decisionLogHTTPBackoff is how long to wait before retrying to send a
+	// decision the first time, doubling on every retry.
+	decisionLogHTTPBackoff = 500 * time.Millisecond
+)
+
+var errDecisionLogClosed = errors.New("decision log is closed")
+
+type decisionLoggerKey struct{}
+type requestUIDKey struct{}
+
+// Decision is the record of an admission decision made by the webhook for a
+// resource, as written to the decision log.
+type Decision struct {
+	Time       time.Time `json:"time"`
+	RequestUID string    `json:"requestUID,omitempty"`
+	Operation  string    `json:"operation,omitempty"`
+	User       string    `json:"user,omitempty"`
+	Namespace  string    `json:"namespace"`
+	Kind       string    `json:"kind"`
+	APIVersion string    `json:"apiVersion"`
+	Name       string    `json:"name,omitempty"`
+	// Images holds the outcome for each of the images of the resource.
+	Images []ImageDecision `json:"images"`
+	// Verdict is one of allow, warn or deny.
+	Verdict string `json:"verdict"`
+	// Message is the error or warning returned for the resource, if any.
+	Message string `json:"message,omitempty"`
+}
+
+// ImageDecision is the outcome of evaluating the policies matching an image.
+type ImageDecision struct {
+	Image string `json:"image"`
+	// Policies holds the outcome for each of the matching policies, keyed by
+	// the name of the policy. Empty if no policies matched the image.
+	Policies map[string]PolicyDecision `json:"policies,omitempty"`
+}
+
+// PolicyDecision is the outcome of evaluating a single policy against an
+// image.
+type PolicyDecision struct {
+	Mode string `json:"mode,omitempty"`
+	// Result is one of pass, fail or skip if the image was exempted from the
+	// policy by a PolicyException.
+	Result    string `json:"result"`
+	Exception string `json:"exception,omitempty"`
+	// AuthorityMatches holds the signatures and attestations that satisfied
+	// each of the authorities, if the policy passed.
+	AuthorityMatches map[string]AuthorityMatch `json:"authorityMatches,omitempty"`
+	Errors           []string                  `json:"errors,omitempty"`
+}
+
+// DecisionLogger writes admission decisions as lines of JSON to the sink
+// configured with the decision-log key in config-policy-controller. The sink
+// is reopened whenever that configuration changes.
+type DecisionLogger struct {
+	// mu guards dest and sink, but is not held while writing to the sink so
+	// that a slow one does not hold up the other admission requests.
+	mu   sync.Mutex
+	dest string
+	sink decisionSink
+
+	// For testing.
+	now func() time.Time
+}
+
+// NewDecisionLogger creates a new DecisionLogger.
+func NewDecisionLogger() *DecisionLogger {
+	return &DecisionLogger{now: time.Now}
+}
+
+// Log writes the decision to the configured sink, if any. Failures are
+// logged as errors and counted in the decision_log_dropped metric, but do not
+// affect admission.
+func (l *DecisionLogger) Log(ctx context.Context, decision *Decision) {
+	dest := policycontrollerconfig.FromContextOrDefaults(ctx).DecisionLog
+
+	l.mu.Lock()
+	if dest != l.dest {
+		l.reopen(ctx, dest)
+	}
+	sink := l.sink
+	l.mu.Unlock()
+	if sink == nil {
+		return
+	}
+	decision.Time = l.now().UTC()
+	line, err := json.Marshal(decision)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Failed to marshal admission decision %s, dropping it: %v", decision.RequestUID, err)
+		recordDecisionDropped(ctx)
+		return
+	}
+	if err := sink.write(ctx, line); err != nil {
+		logging.FromContext(ctx).Errorf("Failed to write admission decision %s to %s, dropping it: %v", decision.RequestUID, dest, err)
+		recordDecisionDropped(ctx)
+	}
+}
+
+// Close closes the current sink.
+func (l *DecisionLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var err error
+	if l.sink != nil {
+		err = l.sink.close()
+	}
+	l.dest, l.sink = "", nil
+	return err
+}
+
+// reopen closes the current sink, and opens the one for dest. Must be
+// called with the lock held.
+func (l *DecisionLogger) reopen(ctx context.Context, dest string) {
+	if l.sink != nil {
+		if err := l.sink.close(); err != nil {
+			logging.FromContext(ctx).Warnf("Failed to close decision log %s: %v", l.dest, err)
+		}
+	}
+	// If opening fails, do not try again until the configuration changes.
+	l.dest, l.sink = dest, nil
+	if dest == "" {
+		return
+	}
+	sink, err := newDecisionSink(logging.FromContext(ctx), dest)
+	if err != nil {
+		logging.FromContext(ctx).Errorf("Failed to open decision log %s: %v", dest, err)
+		return
+	}
+	l.sink = sink
+}
+
+// decisionSink is where the DecisionLogger writes the lines to. A sink can be
+// closed while lines are being written to it, writing to a closed sink must
+// return an error.
+type decisionSink interface {
+	write(ctx context.Context, line []byte) error
+	close() error
+}
+
+func newDecisionSink(logger *zap.SugaredLogger, dest string) (decisionSink, error) {
+	if dest == policycontrollerconfig.DecisionLogStdout {
+		return &writerSink{w: os.Stdout}, nil
+	}
+	u, err := url.Parse(dest)
+	if err != nil {
+		return nil, err
+	}
+	switch u.Scheme {
+	case "file":
+		f, err := os.OpenFile(u.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, err
+		}
+		return &writerSink{w: f, c: f}, nil
+	case "http", "https":
+		return newHTTPSink(logger, dest, &http.Client{Timeout: decisionLogHTTPTimeout}), nil
+	default:
+		return nil, fmt.Errorf("unsupported decision log %q", dest)
+	}
+}
+
+// writerSink writes each line to an io.Writer, like stdout or a file.
+type writerSink struct {
+	w io.Writer
+	c io.Closer
+}
+
+func (s *writerSink) write(_ context.Context, line []byte) error {
+	_, err := s.w.Write(append(line, '\n'))
+	return err
+}
+
+func (s *writerSink) close() error {
+	if s.c == nil {
+		return nil
+	}
+	return s.c.Close()
+}
+
+// httpSink POSTs each line to an HTTP endpoint. This is done in the
+// background so that a slow endpoint does not slow down admission. Failed
+// POSTs are retried with exponential backoff. If the endpoint can not keep
+// up and the queue is full, admission waits for room for a while, and only
+// then is the decision dropped.
+type httpSink struct {
+	logger *zap.SugaredLogger
+	url    string
+	client *http.Client
+	lines  chan []byte
+	// done is closed when the sink is, lines is never closed since it can
+	// still be written to.
+	done chan struct{}
+
+	// For testing.
+	queueTimeout time.Duration
+	attempts     int
+	backoff      time.Duration
+}
+
+func newHTTPSink(logger *zap.SugaredLogger, endpoint string, client *http.Client) *httpSink {
+	s := &httpSink{
+		logger:       logger,
+		url:          endpoint,
+		client:       client,
+		lines:        make(chan []byte, decisionLogQueueSize),
+		done:         make(chan struct{}),
+		queueTimeout: decisionLogQueueTimeout,
+		attempts:     decisionLogHTTPAttempts,
+		backoff:      decisionLogHTTPBackoff,
+	}
+	go s.run()
+	return s
+}
+
+func (s *httpSink) write(ctx context.Context, line []byte) error {
+	select {
+	case <-s.done:
+		return errDecisionLogClosed
+	case s.lines <- line:
+		return nil
+	default:
+	}
+	timer := time.NewTimer(s.queueTimeout)
+	defer timer.Stop()
+	select {
+	case <-s.done:
+		return errDecisionLogClosed
+	case s.lines <- line:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("%d decisions are waiting to be sent", cap(s.lines))
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *httpSink) close() error {
+	close(s.done)
+	return nil
+}
+
+func (s *httpSink) run() {
+	for {
+		select {
+		case line := <-s.lines:
+			s.sendOrDrop(line)
+		case <-s.done:
+			// Send the decisions that were queued before closing.
+			for {
+				select {
+				case line := <-s.lines:
+					s.sendOrDrop(line)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *httpSink) sendOrDrop(line []byte) {
+	if err := s.send(line); err != nil {
+		s.logger.Errorf("Failed to send admission decision to %s, dropping it: %v", s.url, err)
+		recordDecisionDropped(context.Background())
+	}
+}
+
+// send POSTs the line, retrying with exponential backoff until it succeeds
+// or all the attempts fail.
+func (s *httpSink) send(line []byte) error {
+	backoff := s.backoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = s.post(line); err == nil {
+			return nil
+		}
+		if attempt >= s.attempts {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt, err)
+		}
+		s.logger.Warnf("Failed to send admission decision to %s, retrying in %v: %v", s.url, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+func (s *httpSink) post(line []byte) error {
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(line))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// WithDecisionLogger attaches the DecisionLogger to the context.
+func WithDecisionLogger(ctx context.Context, logger *DecisionLogger) context.Context {
+	return context.WithValue(ctx, decisionLoggerKey{}, logger)
+}
+
+// DecisionLoggerFromContext returns the DecisionLogger attached to the
+// context, or nil if there is none.
+func DecisionLoggerFromContext(ctx context.Context) *DecisionLogger {
+	x, ok := ctx.Value(decisionLoggerKey{}).(*DecisionLogger)
+	if ok {
+		return x
+	}
+	return nil
+}
+
+// WithRequestUID attaches the UID of the admission request being handled to
+// the context.
+func WithRequestUID(ctx context.Context, uid string) context.Context {
+	return context.WithValue(ctx, requestUIDKey{}, uid)
+}
+
+// RequestUIDFromContext returns the UID of the admission request being
+// handled, or empty string if there is none.
+func RequestUIDFromContext(ctx context.Context) string {
+	x, _ := ctx.Value(requestUIDKey{}).(string)
+	return x
+}
+
+// decisionRecorder is a Reporter that collects the outcome for each of the
+// images of a resource being admitted, so that the decision for the resource
+// as a whole can be logged once all of them are done. Evaluations are passed
+// on to the Reporter that was in the context, if any.
+type decisionRecorder struct {
+	logger *DecisionLogger
+	next   Reporter
+
+	mu       sync.Mutex
+	decision Decision
+	images   map[string]*ImageDecision
+}
+
+var _ Reporter = (*decisionRecorder)(nil)
+
+// startDecision returns a context to validate the images of the PodSpec
+// with, along with the decisionRecorder collecting their outcome. The
+// decisionRecorder is nil if there is no DecisionLogger in the context, or
+// this is not an admission request.
+func startDecision(ctx context.Context, namespace, kind, apiVersion string, ps *corev1.PodSpec) (context.Context, *decisionRecorder) {
+	logger := DecisionLoggerFromContext(ctx)
+	if logger == nil || (!apis.IsInCreate(ctx) && !apis.IsInUpdate(ctx)) {
+		return ctx, nil
+	}
+	r := &decisionRecorder{
+		logger: logger,
+		next:   ReporterFromContext(ctx),
+		decision: Decision{
+			RequestUID: RequestUIDFromContext(ctx),
+			Operation:  "CREATE",
+			Namespace:  namespace,
+			Kind:       kind,
+			APIVersion: apiVersion,
+		},
+		images: map[string]*ImageDecision{},
+	}
+	if apis.IsInUpdate(ctx) {
+		r.decision.Operation = "UPDATE"
+	}
+	if ui := apis.GetUserInfo(ctx); ui != nil {
+		r.decision.User = ui.Username
+	}
+	if om, ok := GetIncludeObjectMeta(ctx).(metav1.ObjectMeta); ok {
+		r.decision.Name = om.Name
+	}
+	// Images that do not match any policy are not evaluated, so start with
+	// all of them.
+	for _, c := range ps.InitContainers {
+		r.images[c.Image] = &ImageDecision{Image: c.Image}
+	}
+	for _, c := range ps.Containers {
+		r.images[c.Image] = &ImageDecision{Image: c.Image}
+	}
+	for _, c := range ps.EphemeralContainers {
+		r.images[c.Image] = &ImageDecision{Image: c.Image}
+	}
+	for _, vol := range ps.Volumes {
+		if vol.Image != nil && vol.Image.Reference != "" {
+			r.images[vol.Image.Reference] = &ImageDecision{Image: vol.Image.Reference}
+		}
+	}
+	return WithReporter(ctx, r), r
+}
+
+// Report implements Reporter.
+func (r *decisionRecorder) Report(ctx context.Context, evaluation *ImageEvaluation) {
+	if r.next != nil {
+		r.next.Report(ctx, evaluation)
+	}
+	image := &ImageDecision{
+		Image:    evaluation.Image,
+		Policies: make(map[string]PolicyDecision, len(evaluation.Policies)),
+	}
+	for name, policy := range evaluation.Policies {
+		pd := PolicyDecision{
+			Mode:      policy.Mode,
+			Exception: policy.Exception,
+		}
+		switch {
+		case policy.Exception != "":
+			pd.Result = "skip"
+		case policy.Result != nil:
+			pd.Result = "pass"
+			pd.AuthorityMatches = withoutPredicates(policy.Result.AuthorityMatches)
+		default:
+			pd.Result = "fail"
+		}
+		for _, err := range policy.Errors {
+			pd.Errors = append(pd.Errors, err.Error())
+		}
+		image.Policies[name] = pd
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.images[evaluation.Image] = image
+}
+
+// withoutPredicates returns a copy of the authority matches without the
+// predicates of the attestations, since they can be large and are not part of
+// the decision. ValidatePolicy does not return them, but a Reporter can be
+// handed results from elsewhere.
+func withoutPredicates(matches map[string]AuthorityMatch) map[string]AuthorityMatch {
+	if matches == nil {
+		return nil
+	}
+	ret := make(map[string]AuthorityMatch, len(matches))
+	for name, match := range matches {
+		if match.Attestations != nil {
+			attestations := make(map[string][]PolicyAttestation, len(match.Attestations))
+			for attName, atts := range match.Attestations {
+				stripped := make([]PolicyAttestation, len(atts))
+				for i, att := range atts {
+					att.Predicate = nil
+					stripped[i] = att
+				}
+				attestations[attName] = stripped
+			}
+			match.Attestations = attestations
+		}
+		ret[name] = match
+	}
+	return ret
+}
+
+// finish logs the decision given the errors returned for the resource. This
+// is a nop on a nil decisionRecorder.
+func (r *decisionRecorder) finish(ctx context.Context, errs *apis.FieldError) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decision.Images = make([]ImageDecision, 0, len(r.images))
+	for _, image := range r.images {
+		r.decision.Images = append(r.decision.Images, *image)
+	}
+	sort.Slice(r.decision.Images, func(i, j int) bool {
+		return r.decision.Images[i].Image < r.decision.Images[j].Image
+	})
+	switch {
+	case errs == nil:
+		r.decision.Verdict = decisionAllow
+	case errs.Filter(apis.ErrorLevel) != nil:
+		r.decision.Verdict = decisionDeny
+		r.decision.Message = errs.Error()
+	default:
+		r.decision.Verdict = decisionWarn
+		r.decision.Message = errs.Error()
+	}
+	r.logger.Log(ctx, &r.decision)
+}
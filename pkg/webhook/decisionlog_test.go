@@ -0,0 +1,322 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	policycontrollerconfig "github.com/sigstore/policy-controller/pkg/config"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+const decisionTestImage = "gcr.io/distroless/base@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"
+
+func withDecisionLog(ctx context.Context, dest string) context.Context {
+	return policycontrollerconfig.ToContext(ctx, &policycontrollerconfig.PolicyControllerConfig{DecisionLog: dest})
+}
+
+func readDecisions(t *testing.T, path string) []Decision {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open decision log: %v", err)
+	}
+	defer f.Close()
+	var ret []Decision
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var d Decision
+		if err := json.Unmarshal(scanner.Bytes(), &d); err != nil {
+			t.Fatalf("Failed to unmarshal %q: %v", scanner.Text(), err)
+		}
+		ret = append(ret, d)
+	}
+	return ret
+}
+
+func TestDecisionLoggerFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "decisions.log")
+	now := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	l := NewDecisionLogger()
+	l.now = func() time.Time { return now }
+	defer l.Close()
+
+	// Not configured, nothing is logged.
+	l.Log(context.Background(), &Decision{Verdict: decisionAllow})
+
+	ctx := withDecisionLog(context.Background(), "file://"+path)
+	l.Log(ctx, &Decision{RequestUID: "first", Verdict: decisionAllow})
+	l.Log(ctx, &Decision{RequestUID: "second", Verdict: decisionDeny, Message: "failed policy: cip"})
+
+	// Disabled again, nothing more is logged.
+	l.Log(context.Background(), &Decision{RequestUID: "third", Verdict: decisionAllow})
+
+	want := []Decision{
+		{Time: now, RequestUID: "first", Verdict: decisionAllow},
+		{Time: now, RequestUID: "second", Verdict: decisionDeny, Message: "failed policy: cip"},
+	}
+	if diff := cmp.Diff(want, readDecisions(t, path)); diff != "" {
+		t.Errorf("Unexpected decisions (-want, +got): %s", diff)
+	}
+}
+
+func TestDecisionLoggerHTTP(t *testing.T) {
+	bodies := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected Content-Type %q", r.Header.Get("Content-Type"))
+		}
+		bodies <- body
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	l := NewDecisionLogger()
+	defer l.Close()
+	l.Log(withDecisionLog(context.Background(), server.URL), &Decision{RequestUID: "uid", Verdict: decisionWarn})
+
+	select {
+	case body := <-bodies:
+		var got Decision
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("Failed to unmarshal %q: %v", body, err)
+		}
+		if got.RequestUID != "uid" || got.Verdict != decisionWarn {
+			t.Errorf("Unexpected decision %+v", got)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Timed out waiting for the decision")
+	}
+}
+
+func TestHTTPSinkRetries(t *testing.T) {
+	var mu sync.Mutex
+	failures, requests := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	s := newHTTPSink(zap.NewNop().Sugar(), server.URL, server.Client())
+	defer s.close()
+	s.backoff = time.Millisecond
+	for _, tc := range []struct {
+		failures     int
+		wantRequests int
+		wantErr      bool
+	}{
+		{failures: 2, wantRequests: 3},
+		{failures: decisionLogHTTPAttempts, wantRequests: decisionLogHTTPAttempts, wantErr: true},
+	} {
+		mu.Lock()
+		failures, requests = tc.failures, 0
+		mu.Unlock()
+		err := s.send([]byte(`{}`))
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("send() with %d failures = %v, wanted error %t", tc.failures, err, tc.wantErr)
+		}
+		mu.Lock()
+		if requests != tc.wantRequests {
+			t.Errorf("send() with %d failures made %d requests, wanted %d", tc.failures, requests, tc.wantRequests)
+		}
+		mu.Unlock()
+	}
+}
+
+func TestHTTPSinkQueueFull(t *testing.T) {
+	// Not started, so nothing is ever taken off the queue.
+	s := &httpSink{
+		lines:        make(chan []byte, 1),
+		queueTimeout: time.Millisecond,
+	}
+	if err := s.write(context.Background(), []byte(`{}`)); err != nil {
+		t.Fatalf("write() = %v", err)
+	}
+	if err := s.write(context.Background(), []byte(`{}`)); err == nil {
+		t.Error("write() to a full queue succeeded, wanted an error")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.queueTimeout = time.Hour
+	if err := s.write(ctx, []byte(`{}`)); !errors.Is(err, context.Canceled) {
+		t.Errorf("write() = %v, wanted %v", err, context.Canceled)
+	}
+}
+
+func TestHTTPSinkClosed(t *testing.T) {
+	s := newHTTPSink(zap.NewNop().Sugar(), "http://localhost", http.DefaultClient)
+	if err := s.close(); err != nil {
+		t.Fatalf("close() = %v", err)
+	}
+	if err := s.write(context.Background(), []byte(`{}`)); !errors.Is(err, errDecisionLogClosed) {
+		t.Errorf("write() = %v, wanted %v", err, errDecisionLogClosed)
+	}
+}
+
+// blockingSink blocks writes until released.
+type blockingSink struct {
+	writing chan struct{}
+	release chan struct{}
+}
+
+func (s *blockingSink) write(context.Context, []byte) error {
+	s.writing <- struct{}{}
+	<-s.release
+	return nil
+}
+
+func (s *blockingSink) close() error {
+	return nil
+}
+
+func TestDecisionLoggerSlowSink(t *testing.T) {
+	const dest = "file:///slow"
+	sink := &blockingSink{writing: make(chan struct{}), release: make(chan struct{})}
+	l := NewDecisionLogger()
+	l.dest, l.sink = dest, sink
+	defer close(sink.release)
+
+	// A write in progress does not hold up the next one.
+	ctx := withDecisionLog(context.Background(), dest)
+	for i := 0; i < 2; i++ {
+		go l.Log(ctx, &Decision{Verdict: decisionAllow})
+		select {
+		case <-sink.writing:
+		case <-time.After(10 * time.Second):
+			t.Fatalf("Timed out waiting for write %d", i)
+		}
+	}
+}
+
+func TestWithoutPredicates(t *testing.T) {
+	matches := map[string]AuthorityMatch{
+		"authority-0": {
+			Attestations: map[string][]PolicyAttestation{
+				"sbom": {{PredicateType: "https://spdx.dev/Document", Predicate: json.RawMessage(`{"spdxVersion":"SPDX-2.3"}`)}},
+			},
+		},
+		"authority-1": {Static: true},
+	}
+	want := map[string]AuthorityMatch{
+		"authority-0": {
+			Attestations: map[string][]PolicyAttestation{
+				"sbom": {{PredicateType: "https://spdx.dev/Document"}},
+			},
+		},
+		"authority-1": {Static: true},
+	}
+	if diff := cmp.Diff(want, withoutPredicates(matches)); diff != "" {
+		t.Errorf("withoutPredicates() (-want, +got): %s", diff)
+	}
+	if matches["authority-0"].Attestations["sbom"][0].Predicate == nil {
+		t.Error("withoutPredicates() modified the authority matches")
+	}
+}
+
+func TestDecisionRecorder(t *testing.T) {
+	ps := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Image: decisionTestImage}},
+		Containers:     []corev1.Container{{Image: cacheTestImage}},
+	}
+
+	// Outside of admission there is no decision to log.
+	ctx := WithDecisionLogger(context.Background(), NewDecisionLogger())
+	if _, r := startDecision(ctx, "default", "Pod", "v1", ps); r != nil {
+		t.Errorf("startDecision() outside of admission = %v, wanted nil", r)
+	}
+
+	path := filepath.Join(t.TempDir(), "decisions.log")
+	l := NewDecisionLogger()
+	defer l.Close()
+	next := &recordingReporter{}
+	ctx = withDecisionLog(apis.WithinCreate(context.Background()), "file://"+path)
+	ctx = WithDecisionLogger(ctx, l)
+	ctx = WithReporter(ctx, next)
+	ctx = WithRequestUID(ctx, "request-uid")
+	ctx = IncludeObjectMeta(ctx, metav1.ObjectMeta{Name: "test"})
+
+	ctx, r := startDecision(ctx, "default", "Pod", "v1", ps)
+	if r == nil {
+		t.Fatal("startDecision() = nil, wanted a decisionRecorder")
+	}
+	ReporterFromContext(ctx).Report(ctx, &ImageEvaluation{
+		Image: cacheTestImage,
+		Policies: map[string]PolicyEvaluation{
+			"passing":  {Mode: "enforce", Result: &PolicyResult{AuthorityMatches: map[string]AuthorityMatch{"authority-0": {Static: true}}}},
+			"failing":  {Mode: "warn", Errors: []error{errors.New("no signatures")}},
+			"exempted": {Mode: "enforce", Exception: "vendor-exception"},
+		},
+	})
+	warn := apis.ErrGeneric("failed policy: failing", "image").At(apis.WarningLevel)
+	r.finish(ctx, warn)
+
+	if len(next.evaluations) != 1 {
+		t.Errorf("Wanted the evaluation passed on to the next Reporter, got %v", next.evaluations)
+	}
+	got := readDecisions(t, path)
+	if len(got) != 1 {
+		t.Fatalf("Wanted 1 decision, got %v", got)
+	}
+	got[0].Time = time.Time{}
+	want := Decision{
+		RequestUID: "request-uid",
+		Operation:  "CREATE",
+		Namespace:  "default",
+		Kind:       "Pod",
+		APIVersion: "v1",
+		Name:       "test",
+		Images: []ImageDecision{{
+			Image: decisionTestImage,
+		}, {
+			Image: cacheTestImage,
+			Policies: map[string]PolicyDecision{
+				"passing":  {Mode: "enforce", Result: "pass", AuthorityMatches: map[string]AuthorityMatch{"authority-0": {Static: true}}},
+				"failing":  {Mode: "warn", Result: "fail", Errors: []string{"no signatures"}},
+				"exempted": {Mode: "enforce", Result: "skip", Exception: "vendor-exception"},
+			},
+		}},
+		Verdict: decisionWarn,
+		Message: warn.Error(),
+	}
+	if diff := cmp.Diff(want, got[0]); diff != "" {
+		t.Errorf("Unexpected decision (-want, +got): %s", diff)
+	}
+}
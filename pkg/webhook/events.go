@@ -0,0 +1,214 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// PolicyDeniedReason is the reason of the Event recorded when an image
+	// fails at least one policy in enforce mode.
+	PolicyDeniedReason = "PolicyDenied"
+	// PolicyWarningReason is the reason of the Event recorded when an image
+	// only fails policies in warn mode.
+	PolicyWarningReason = "PolicyWarning"
+
+	// maxEventMessageLength is the maximum length of the message of the
+	// Events, longer messages are truncated. The API server rejects
+	// messages larger than this.
+	maxEventMessageLength = 1024
+
+	// maxOwnerDepth is how far up the chain of controllers Events are
+	// moved, in case the owner references loop.
+	maxOwnerDepth = 5
+)
+
+// EventReporter is a Reporter that records a Kubernetes Event for every image
+// that fails a policy on admission. Events are recorded against the object
+// that owns the resource being admitted if there is one, walking up to the
+// top-level controller, so that for example a Pod denied when created by the
+// ReplicaSet of a Deployment shows up when describing the Deployment, instead
+// of being lost with the Pod that was never created.
+type EventReporter struct {
+	recorder    record.EventRecorder
+	replicaSets appsv1listers.ReplicaSetLister
+	jobs        batchv1listers.JobLister
+}
+
+var _ Reporter = (*EventReporter)(nil)
+
+// NewEventReporter creates a new EventReporter recording Events with the
+// given recorder, and looking up the owners of resources with the listers,
+// so that admission does not wait on the API server.
+func NewEventReporter(recorder record.EventRecorder, replicaSets appsv1listers.ReplicaSetLister, jobs batchv1listers.JobLister) *EventReporter {
+	return &EventReporter{recorder: recorder, replicaSets: replicaSets, jobs: jobs}
+}
+
+// Report implements Reporter. Evaluations done outside of admission, for
+// example by the audit controller, or on dry run requests are not recorded.
+func (r *EventReporter) Report(ctx context.Context, evaluation *ImageEvaluation) {
+	if !apis.IsInCreate(ctx) && !apis.IsInUpdate(ctx) {
+		return
+	}
+	if apis.IsDryRun(ctx) {
+		return
+	}
+	reason, message := eventFor(evaluation)
+	if reason == "" {
+		return
+	}
+	ref := r.eventObjectFor(ctx, evaluation)
+	if ref.Name == "" {
+		// Nothing to attach the Event to, for example a Pod being created
+		// with a generateName and without an owner.
+		return
+	}
+	r.recorder.Event(ref, corev1.EventTypeWarning, reason, message)
+}
+
+// eventObjectFor returns the object the Event for the evaluation should be
+// recorded against, that is the top-level controller of the resource that was
+// evaluated if it has one, or the resource itself.
+func (r *EventReporter) eventObjectFor(ctx context.Context, evaluation *ImageEvaluation) *corev1.ObjectReference {
+	ref := evaluation.Resource
+	om, ok := GetIncludeObjectMeta(ctx).(metav1.ObjectMeta)
+	if !ok {
+		return &ref
+	}
+	owner := metav1.GetControllerOfNoCopy(&om)
+	for depth := 0; owner != nil && depth < maxOwnerDepth; depth++ {
+		ref = corev1.ObjectReference{
+			APIVersion: owner.APIVersion,
+			Kind:       owner.Kind,
+			Namespace:  evaluation.Resource.Namespace,
+			Name:       owner.Name,
+			UID:        owner.UID,
+		}
+		owner = r.controllerOf(ctx, ref)
+	}
+	return &ref
+}
+
+// controllerOf returns the controller of the object, for the kinds that are
+// usually created by another workload: ReplicaSets by Deployments and Jobs by
+// CronJobs. Objects of any other kind are taken to be the top-level
+// controller, and so are the ones that are not in the informer caches.
+func (r *EventReporter) controllerOf(ctx context.Context, ref corev1.ObjectReference) *metav1.OwnerReference {
+	var (
+		obj metav1.Object
+		err error
+	)
+	switch schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind) {
+	case appsv1.SchemeGroupVersion.WithKind("ReplicaSet"):
+		obj, err = r.replicaSets.ReplicaSets(ref.Namespace).Get(ref.Name)
+	case batchv1.SchemeGroupVersion.WithKind("Job"):
+		obj, err = r.jobs.Jobs(ref.Namespace).Get(ref.Name)
+	default:
+		return nil
+	}
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Failed to get the controller of %s %s/%s: %v", ref.Kind, ref.Namespace, ref.Name, err)
+		return nil
+	}
+	if obj.GetUID() != ref.UID {
+		// A different object with the same name as the owner.
+		return nil
+	}
+	return metav1.GetControllerOfNoCopy(obj)
+}
+
+// eventFor returns the reason and message of the Event for the evaluation,
+// or empty reason if all the policies were satisfied. The message has the
+// image, the policies that failed, and a summary of why.
+func eventFor(evaluation *ImageEvaluation) (string, string) {
+	failed := make([]string, 0, len(evaluation.Policies))
+	reason := ""
+	for name, policy := range evaluation.Policies {
+		if policy.Result != nil || policy.Exception != "" || len(policy.Errors) == 0 {
+			continue
+		}
+		failed = append(failed, name)
+		if policy.Mode == "warn" {
+			if reason == "" {
+				reason = PolicyWarningReason
+			}
+		} else {
+			reason = PolicyDeniedReason
+		}
+	}
+	if reason == "" {
+		return "", ""
+	}
+	sort.Strings(failed)
+
+	summaries := make([]string, 0, len(failed))
+	for _, name := range failed {
+		summaries = append(summaries, fmt.Sprintf("%s: %s", name, summarizeErrors(evaluation.Policies[name].Errors)))
+	}
+	message := fmt.Sprintf("image %s failed policies %s: %s", evaluation.Image, strings.Join(failed, ", "), strings.Join(summaries, "; "))
+	if len(message) > maxEventMessageLength {
+		message = truncate(message, maxEventMessageLength-3) + "..."
+	}
+	return reason, message
+}
+
+// truncate returns the longest prefix of s that is at most n bytes long
+// without splitting a UTF-8 encoded character.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// summarizeErrors returns the first of the errors of a policy, along with the
+// authority that caused it, and how many more there are. The details of
+// FieldErrors are left out since they just repeat the image.
+func summarizeErrors(errs []error) string {
+	err := errs[0]
+	summary := err.Error()
+	var fe *apis.FieldError
+	if errors.As(err, &fe) && fe.Message != "" {
+		summary = fe.Message
+	}
+	if authority := AuthorityOf(err); authority != "" {
+		summary = fmt.Sprintf("authority %s: %s", authority, summary)
+	}
+	if len(errs) > 1 {
+		summary = fmt.Sprintf("%s (and %d more)", summary, len(errs)-1)
+	}
+	return summary
+}
@@ -0,0 +1,225 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	appsv1listers "k8s.io/client-go/listers/apps/v1"
+	batchv1listers "k8s.io/client-go/listers/batch/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+)
+
+func TestEventFor(t *testing.T) {
+	failed := &AuthorityError{Authority: "authority-0", Err: asFieldError(false, errors.New("no matching signatures"))}
+	tests := []struct {
+		name        string
+		policies    map[string]PolicyEvaluation
+		wantReason  string
+		wantMessage string
+	}{{
+		name: "all passed",
+		policies: map[string]PolicyEvaluation{
+			"passing":  {Mode: "enforce", Result: &PolicyResult{}},
+			"exempted": {Mode: "enforce", Exception: "vendor-exception"},
+		},
+	}, {
+		name: "enforce failed",
+		policies: map[string]PolicyEvaluation{
+			"passing": {Mode: "enforce", Result: &PolicyResult{}},
+			"failing": {Mode: "enforce", Errors: []error{failed, errors.New("failed to fetch")}},
+			"warning": {Mode: "warn", Errors: []error{errors.New("no attestations")}},
+		},
+		wantReason:  PolicyDeniedReason,
+		wantMessage: "image " + cacheTestImage + " failed policies failing, warning: failing: authority authority-0: no matching signatures (and 1 more); warning: no attestations",
+	}, {
+		name: "only warn failed",
+		policies: map[string]PolicyEvaluation{
+			"warning": {Mode: "warn", Errors: []error{errors.New("no attestations")}},
+		},
+		wantReason:  PolicyWarningReason,
+		wantMessage: "image " + cacheTestImage + " failed policies warning: warning: no attestations",
+	}, {
+		name: "long message is truncated",
+		policies: map[string]PolicyEvaluation{
+			"failing": {Mode: "enforce", Errors: []error{errors.New(strings.Repeat("x", 2*maxEventMessageLength))}},
+		},
+		wantReason:  PolicyDeniedReason,
+		wantMessage: "image " + cacheTestImage + " failed policies failing: failing: " + strings.Repeat("x", maxEventMessageLength-len("image "+cacheTestImage+" failed policies failing: failing: ")-3) + "...",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, message := eventFor(&ImageEvaluation{Image: cacheTestImage, Policies: tc.policies})
+			if reason != tc.wantReason {
+				t.Errorf("eventFor() reason = %q, wanted %q", reason, tc.wantReason)
+			}
+			if message != tc.wantMessage {
+				t.Errorf("eventFor() message = %q, wanted %q", message, tc.wantMessage)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	// Each é is two bytes long, so 5 bytes would split the third one.
+	if got, want := truncate("ééééé", 5), "éé"; got != want {
+		t.Errorf("truncate() = %q, wanted %q", got, want)
+	}
+	if got, want := truncate("short", 10), "short"; got != want {
+		t.Errorf("truncate() = %q, wanted %q", got, want)
+	}
+
+	_, message := eventFor(&ImageEvaluation{Image: cacheTestImage, Policies: map[string]PolicyEvaluation{
+		"failing": {Mode: "enforce", Errors: []error{errors.New(strings.Repeat("é", maxEventMessageLength))}},
+	}})
+	if len(message) > maxEventMessageLength || !utf8.ValidString(message) {
+		t.Errorf("eventFor() message is %d bytes long and valid UTF-8 %t", len(message), utf8.ValidString(message))
+	}
+}
+
+// newTestEventReporter returns an EventReporter that finds the given
+// ReplicaSets and Jobs in its listers.
+func newTestEventReporter(t *testing.T, recorder record.EventRecorder, objs ...interface{}) *EventReporter {
+	t.Helper()
+	replicaSets := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	jobs := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, obj := range objs {
+		indexer := replicaSets
+		if _, ok := obj.(*batchv1.Job); ok {
+			indexer = jobs
+		}
+		if err := indexer.Add(obj); err != nil {
+			t.Fatalf("Failed to add %v: %v", obj, err)
+		}
+	}
+	return NewEventReporter(recorder, appsv1listers.NewReplicaSetLister(replicaSets), batchv1listers.NewJobLister(jobs))
+}
+
+func TestEventObjectFor(t *testing.T) {
+	r := newTestEventReporter(t, record.NewFakeRecorder(1),
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-rs",
+			UID:       "rs-uid",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "test-deployment",
+				UID:        "deployment-uid",
+				Controller: ptr.Bool(true),
+			}},
+		}},
+		&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-job",
+			UID:       "job-uid",
+		}},
+	)
+	evaluation := &ImageEvaluation{
+		Resource: corev1.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "test-pod"},
+	}
+	ownedBy := func(apiVersion, kind, name, uid string) context.Context {
+		return IncludeObjectMeta(context.Background(), metav1.ObjectMeta{
+			Name: "test-pod",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Name:       name,
+				UID:        types.UID(uid),
+				Controller: ptr.Bool(true),
+			}},
+		})
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want corev1.ObjectReference
+	}{{
+		name: "without owner",
+		ctx:  context.Background(),
+		want: evaluation.Resource,
+	}, {
+		name: "owned by the ReplicaSet of a Deployment",
+		ctx:  ownedBy("apps/v1", "ReplicaSet", "test-rs", "rs-uid"),
+		want: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "test-deployment", UID: "deployment-uid"},
+	}, {
+		name: "owned by a Job without owner",
+		ctx:  ownedBy("batch/v1", "Job", "test-job", "job-uid"),
+		want: corev1.ObjectReference{APIVersion: "batch/v1", Kind: "Job", Namespace: "default", Name: "test-job", UID: "job-uid"},
+	}, {
+		name: "owned by a ReplicaSet that is gone",
+		ctx:  ownedBy("apps/v1", "ReplicaSet", "missing-rs", "missing-uid"),
+		want: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Namespace: "default", Name: "missing-rs", UID: "missing-uid"},
+	}, {
+		name: "owned by a ReplicaSet that was replaced",
+		ctx:  ownedBy("apps/v1", "ReplicaSet", "test-rs", "old-rs-uid"),
+		want: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Namespace: "default", Name: "test-rs", UID: "old-rs-uid"},
+	}, {
+		name: "owned by a StatefulSet",
+		ctx:  ownedBy("apps/v1", "StatefulSet", "test-sts", "sts-uid"),
+		want: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "StatefulSet", Namespace: "default", Name: "test-sts", UID: "sts-uid"},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := r.eventObjectFor(tc.ctx, evaluation); *got != tc.want {
+				t.Errorf("eventObjectFor() = %+v, wanted %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestEventReporter(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	r := newTestEventReporter(t, recorder)
+	evaluation := &ImageEvaluation{
+		Resource: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "test"},
+		Image:    cacheTestImage,
+		Policies: map[string]PolicyEvaluation{
+			"failing": {Mode: "enforce", Errors: []error{errors.New("failed")}},
+		},
+	}
+
+	// Not on admission, nothing is recorded.
+	r.Report(context.Background(), evaluation)
+	// Nor on dry run.
+	r.Report(apis.WithDryRun(apis.WithinCreate(context.Background())), evaluation)
+	// Nor without an object to record it against.
+	r.Report(apis.WithinCreate(context.Background()), &ImageEvaluation{Policies: evaluation.Policies})
+	r.Report(apis.WithinCreate(context.Background()), evaluation)
+
+	close(recorder.Events)
+	var got []string
+	for event := range recorder.Events {
+		got = append(got, event)
+	}
+	want := []string{"Warning PolicyDenied image " + cacheTestImage + " failed policies failing: failing: failed"}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("Recorded events = %v, wanted %v", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"crypto/x509"
+	"errors"
+	"regexp"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	"github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+)
+
+// fulcioExtensions returns the provider independent Fulcio extensions of
+// the certificate.
+func fulcioExtensions(cert *x509.Certificate) (FulcioExtensions, error) {
+	ext, err := certificate.ParseExtensions(cert.Extensions)
+	if err != nil {
+		return FulcioExtensions{}, err
+	}
+	return FulcioExtensions{
+		BuildSignerURI:                      ext.BuildSignerURI,
+		BuildSignerDigest:                   ext.BuildSignerDigest,
+		RunnerEnvironment:                   ext.RunnerEnvironment,
+		SourceRepositoryURI:                 ext.SourceRepositoryURI,
+		SourceRepositoryDigest:              ext.SourceRepositoryDigest,
+		SourceRepositoryRef:                 ext.SourceRepositoryRef,
+		SourceRepositoryIdentifier:          ext.SourceRepositoryIdentifier,
+		SourceRepositoryOwnerURI:            ext.SourceRepositoryOwnerURI,
+		SourceRepositoryOwnerIdentifier:     ext.SourceRepositoryOwnerIdentifier,
+		BuildConfigURI:                      ext.BuildConfigURI,
+		BuildConfigDigest:                   ext.BuildConfigDigest,
+		BuildTrigger:                        ext.BuildTrigger,
+		RunInvocationURI:                    ext.RunInvocationURI,
+		SourceRepositoryVisibilityAtSigning: ext.SourceRepositoryVisibilityAtSigning,
+	}, nil
+}
+
+// filterByExtensions returns the signatures whose certificate matches one of
+// the identities, including the certificate extensions of the identity.
+// Cosign has already checked the issuer and subject of the identities, but
+// not which of them matched, so they are checked again along with the
+// extensions.
+func filterByExtensions(sigs []oci.Signature, identities []v1alpha1.Identity) ([]oci.Signature, error) {
+	hasExtensions := false
+	for _, id := range identities {
+		if len(id.Extensions) > 0 || len(id.ExtensionsRegExp) > 0 {
+			hasExtensions = true
+			break
+		}
+	}
+	if !hasExtensions {
+		return sigs, nil
+	}
+
+	ret := make([]oci.Signature, 0, len(sigs))
+	for _, sig := range sigs {
+		cert, err := sig.Cert()
+		if err != nil || cert == nil {
+			continue
+		}
+		for _, id := range identities {
+			if matchesIdentity(cert, id) {
+				ret = append(ret, sig)
+				break
+			}
+		}
+	}
+	if len(ret) == 0 {
+		return nil, errors.New("none of the certificates matched the extensions of the identities")
+	}
+	return ret, nil
+}
+
+// matchesIdentity returns whether the certificate has the issuer, subject and
+// extensions of the identity.
+func matchesIdentity(cert *x509.Certificate, id v1alpha1.Identity) bool {
+	co := &cosign.CheckOpts{
+		Identities: []cosign.Identity{{
+			Issuer:        id.Issuer,
+			Subject:       id.Subject,
+			IssuerRegExp:  id.IssuerRegExp,
+			SubjectRegExp: id.SubjectRegExp,
+		}},
+	}
+	if err := cosign.CheckCertificatePolicy(cert, co); err != nil {
+		return false
+	}
+	ext, err := fulcioExtensions(cert)
+	if err != nil {
+		return false
+	}
+	values := ext.byName()
+	for name, want := range id.Extensions {
+		if values[name] != want {
+			return false
+		}
+	}
+	// Like the glob matches, the regular expressions must match the whole
+	// value of the extension.
+	for name, regex := range id.ExtensionsRegExp {
+		re, err := regexp.Compile("^(?:" + regex + ")$")
+		if err != nil || !re.MatchString(values[name]) {
+			return false
+		}
+	}
+	return true
+}
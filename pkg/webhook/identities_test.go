@@ -0,0 +1,241 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/cosign/v3/pkg/oci/static"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+)
+
+const (
+	testIssuer  = "https://token.actions.githubusercontent.com"
+	testSubject = "https://github.com/sigstore/policy-controller/.github/workflows/release.yaml@refs/tags/v1.0.0"
+	testRepo    = "https://github.com/sigstore/policy-controller"
+)
+
+// signatureWithExtensions returns a signature with a certificate issued to
+// testSubject by testIssuer with some of the Fulcio v2 extensions.
+func signatureWithExtensions(t *testing.T) oci.Signature {
+	t.Helper()
+	derString := func(s string) []byte {
+		b, err := asn1.MarshalWithParams(s, "utf8")
+		if err != nil {
+			t.Fatalf("asn1.Marshal() = %v", err)
+		}
+		return b
+	}
+	subject, err := url.Parse(testSubject)
+	if err != nil {
+		t.Fatalf("url.Parse() = %v", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(10 * time.Minute),
+		URIs:         []*url.URL{subject},
+		ExtraExtensions: []pkix.Extension{
+			// Issuer (deprecated), which is not DER encoded.
+			{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}, Value: []byte(testIssuer)},
+			// Runner Environment
+			{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 11}, Value: derString("github-hosted")},
+			// Source Repository URI
+			{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 12}, Value: derString(testRepo)},
+			// Source Repository Ref
+			{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 14}, Value: derString("refs/tags/v1.0.0")},
+		},
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey() = %v", err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() = %v", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	sig, err := static.NewSignature([]byte("payload"), "", static.WithCertChain(certPEM, nil))
+	if err != nil {
+		t.Fatalf("static.NewSignature() = %v", err)
+	}
+	return sig
+}
+
+func TestFulcioExtensions(t *testing.T) {
+	cert, err := signatureWithExtensions(t).Cert()
+	if err != nil {
+		t.Fatalf("Cert() = %v", err)
+	}
+	got, err := fulcioExtensions(cert)
+	if err != nil {
+		t.Fatalf("fulcioExtensions() = %v", err)
+	}
+	want := FulcioExtensions{
+		RunnerEnvironment:   "github-hosted",
+		SourceRepositoryURI: testRepo,
+		SourceRepositoryRef: "refs/tags/v1.0.0",
+	}
+	if got != want {
+		t.Errorf("fulcioExtensions() = %+v, wanted %+v", got, want)
+	}
+}
+
+func TestFilterByExtensions(t *testing.T) {
+	sig := signatureWithExtensions(t)
+	unsigned, err := static.NewSignature([]byte("payload"), "")
+	if err != nil {
+		t.Fatalf("static.NewSignature() = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		identities []v1alpha1.Identity
+		sigs       []oci.Signature
+		want       int
+		wantErr    bool
+	}{{
+		name:       "no extensions keeps all signatures",
+		identities: []v1alpha1.Identity{{Issuer: testIssuer, Subject: testSubject}},
+		sigs:       []oci.Signature{sig, unsigned},
+		want:       2,
+	}, {
+		name: "matching extensions",
+		identities: []v1alpha1.Identity{{
+			Issuer:     testIssuer,
+			Subject:    testSubject,
+			Extensions: map[string]string{"sourceRepositoryURI": testRepo, "runnerEnvironment": "github-hosted"},
+		}},
+		sigs: []oci.Signature{sig, unsigned},
+		want: 1,
+	}, {
+		name: "matching extensions regexp",
+		identities: []v1alpha1.Identity{{
+			IssuerRegExp:     ".*",
+			SubjectRegExp:    ".*",
+			ExtensionsRegExp: map[string]string{"sourceRepositoryRef": "^refs/tags/v[0-9.]+$"},
+		}},
+		sigs: []oci.Signature{sig},
+		want: 1,
+	}, {
+		name: "one of the identities matches",
+		identities: []v1alpha1.Identity{{
+			Issuer:     testIssuer,
+			Subject:    testSubject,
+			Extensions: map[string]string{"sourceRepositoryURI": "https://github.com/sigstore/cosign"},
+		}, {
+			Issuer:     testIssuer,
+			Subject:    testSubject,
+			Extensions: map[string]string{"sourceRepositoryURI": testRepo},
+		}},
+		sigs: []oci.Signature{sig},
+		want: 1,
+	}, {
+		name: "extensions regexp must match the whole value",
+		identities: []v1alpha1.Identity{{
+			Issuer:           testIssuer,
+			Subject:          testSubject,
+			ExtensionsRegExp: map[string]string{"sourceRepositoryURI": "github.com/sigstore/.*"},
+		}},
+		sigs:    []oci.Signature{sig},
+		wantErr: true,
+	}, {
+		name: "mismatched extension",
+		identities: []v1alpha1.Identity{{
+			Issuer:     testIssuer,
+			Subject:    testSubject,
+			Extensions: map[string]string{"runnerEnvironment": "self-hosted"},
+		}},
+		sigs:    []oci.Signature{sig},
+		wantErr: true,
+	}, {
+		name: "missing extension",
+		identities: []v1alpha1.Identity{{
+			Issuer:           testIssuer,
+			Subject:          testSubject,
+			ExtensionsRegExp: map[string]string{"buildTrigger": "push"},
+		}},
+		sigs:    []oci.Signature{sig},
+		wantErr: true,
+	}, {
+		name: "extensions of an identity with another subject",
+		identities: []v1alpha1.Identity{{
+			Issuer:     testIssuer,
+			Subject:    "https://github.com/sigstore/cosign/.github/workflows/release.yaml@refs/tags/v1.0.0",
+			Extensions: map[string]string{"sourceRepositoryURI": testRepo},
+		}},
+		sigs:    []oci.Signature{sig},
+		wantErr: true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := filterByExtensions(tc.sigs, tc.identities)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("filterByExtensions() error = %v, wantErr %v", err, tc.wantErr)
+			}
+			if len(got) != tc.want {
+				t.Errorf("filterByExtensions() returned %d signatures, wanted %d", len(got), tc.want)
+			}
+		})
+	}
+}
+
+func TestAttestationFulcioExtensions(t *testing.T) {
+	sig := signatureWithExtensions(t)
+	identities := []v1alpha1.Identity{{
+		Issuer:     testIssuer,
+		Subject:    testSubject,
+		Extensions: map[string]string{"sourceRepositoryURI": testRepo},
+	}}
+	// Attestations go through the same filtering as signatures.
+	va, err := filterByExtensions([]oci.Signature{sig}, identities)
+	if err != nil {
+		t.Fatalf("filterByExtensions() = %v", err)
+	}
+	atts := make([]attestation, 0, len(va))
+	for _, s := range va {
+		atts = append(atts, attestation{Signature: s, PredicateType: "https://slsa.dev/provenance/v1", Digest: "sha256:abcd"})
+	}
+
+	got := attestationToPolicyAttestations(context.Background(), atts)
+	if len(got) != 1 {
+		t.Fatalf("attestationToPolicyAttestations() returned %d attestations, wanted 1", len(got))
+	}
+	want := FulcioExtensions{
+		RunnerEnvironment:   "github-hosted",
+		SourceRepositoryURI: testRepo,
+		SourceRepositoryRef: "refs/tags/v1.0.0",
+	}
+	if got[0].FulcioExtensions != want {
+		t.Errorf("FulcioExtensions = %+v, wanted %+v", got[0].FulcioExtensions, want)
+	}
+	if got[0].Issuer != testIssuer || got[0].Subject != testSubject {
+		t.Errorf("got issuer %q and subject %q, wanted %q and %q", got[0].Issuer, got[0].Subject, testIssuer, testSubject)
+	}
+}
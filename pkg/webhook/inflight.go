@@ -0,0 +1,83 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+	"golang.org/x/sync/singleflight"
+	"knative.dev/pkg/logging"
+)
+
+// inflight coalesces concurrent evaluations of the same ClusterImagePolicy
+// against the same image, both within an admission request (the same image
+// used by several containers) and across admission requests (for example
+// all the Pods of a Deployment rollout being created at once).
+var inflight = &inflightEvaluations{}
+
+type inflightEvaluations struct {
+	group singleflight.Group
+}
+
+// inflightTimeout bounds a shared evaluation. It runs detached from the
+// admission requests waiting on it, so it is bounded by the longest an
+// admission webhook can take instead.
+const inflightTimeout = 30 * time.Second
+
+// inflightKey returns the key that identifies the evaluation of cip against
+// ref with the credentials recorded in ctx, and false if the evaluation can
+// not be shared. Like the cached results (see resultKey), only images
+// referenced by digest and fetched with the same credentials are coalesced,
+// and only policies with a UID, since those without one (for example when
+// evaluated by the policy tester) can not be told apart.
+func inflightKey(ctx context.Context, ref name.Reference, cip webhookcip.ClusterImagePolicy) (string, bool) {
+	key, ok := resultKey(ctx, ref)
+	if !ok || cip.UID == "" || !isCacheable(cip) {
+		return "", false
+	}
+	return key + "|" + string(cip.UID) + "|" + cip.ResourceVersion, true
+}
+
+// do calls evaluate, unless an evaluation for the same key is already in
+// flight, in which case it waits for it and returns its result instead.
+// shared reports whether the result was shared with other callers.
+//
+// evaluate runs with a context that is detached from the caller that
+// started it, and bounded by inflightTimeout, so that the callers waiting
+// on it do not fail when that admission request is canceled. Each caller
+// still stops waiting when its own context is done.
+func (i *inflightEvaluations) do(ctx context.Context, key string, evaluate func(context.Context) (*PolicyResult, []error)) (policyResult *PolicyResult, errs []error, shared bool) {
+	ch := i.group.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), inflightTimeout)
+		defer cancel()
+		policyResult, errs := evaluate(ctx)
+		return &CacheResult{PolicyResult: policyResult, Errors: errs}, nil
+	})
+	select {
+	case <-ctx.Done():
+		return nil, []error{fmt.Errorf("%w before validation completed", ctx.Err())}, false
+	case res := <-ch:
+		if res.Shared {
+			logging.FromContext(ctx).Debugf("Shared in-flight evaluation %s", key)
+		}
+		result := res.Val.(*CacheResult)
+		return result.PolicyResult, result.Errors, res.Shared
+	}
+}
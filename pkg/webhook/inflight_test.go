@@ -0,0 +1,150 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+)
+
+func TestInflightKey(t *testing.T) {
+	digest := name.MustParseReference(cacheTestImage)
+	tag := name.MustParseReference("gcr.io/distroless/static:nonroot")
+	includeSpec := true
+	cip := webhookcip.ClusterImagePolicy{UID: "uid", ResourceVersion: "1"}
+	ctx := withKeychainIdentity(context.Background(), k8schain.Options{Namespace: "a"})
+
+	if _, ok := inflightKey(context.Background(), digest, cip); ok {
+		t.Error("inflightKey() without credentials succeeded, wanted no key")
+	}
+	if _, ok := inflightKey(ctx, tag, cip); ok {
+		t.Error("inflightKey() for a tag succeeded, wanted no key")
+	}
+	if _, ok := inflightKey(ctx, digest, webhookcip.ClusterImagePolicy{}); ok {
+		t.Error("inflightKey() for a policy without UID succeeded, wanted no key")
+	}
+	if _, ok := inflightKey(ctx, digest, webhookcip.ClusterImagePolicy{UID: "uid", Policy: &webhookcip.AttestationPolicy{IncludeSpec: &includeSpec}}); ok {
+		t.Error("inflightKey() for a policy including the spec succeeded, wanted no key")
+	}
+	key, ok := inflightKey(ctx, digest, cip)
+	if !ok {
+		t.Fatal("inflightKey() failed, wanted key")
+	}
+	otherNamespace := withKeychainIdentity(context.Background(), k8schain.Options{Namespace: "b"})
+	if keyB, _ := inflightKey(otherNamespace, digest, cip); keyB == key {
+		t.Errorf("inflightKey() for a different namespace = %s, wanted a different key", keyB)
+	}
+	cip.ResourceVersion = "2"
+	if key2, _ := inflightKey(ctx, digest, cip); key2 == key {
+		t.Errorf("inflightKey() for a different resourceVersion = %s, wanted a different key", key2)
+	}
+}
+
+func TestInflightDo(t *testing.T) {
+	ctx := context.Background()
+	i := &inflightEvaluations{}
+	want := &PolicyResult{}
+	release := make(chan struct{})
+	var calls atomic.Int32
+	evaluate := func(context.Context) (*PolicyResult, []error) {
+		calls.Add(1)
+		<-release
+		return want, nil
+	}
+
+	const callers = 10
+	wg := new(sync.WaitGroup)
+	var sharedCount atomic.Int32
+	for j := 0; j < callers; j++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, errs, shared := i.do(ctx, "key", evaluate)
+			if got != want || len(errs) != 0 {
+				t.Errorf("do() = %v, %v, wanted %v", got, errs, want)
+			}
+			if shared {
+				sharedCount.Add(1)
+			}
+		}()
+	}
+	// Give the callers a chance to pile up behind the first one.
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("evaluate was called %d times, wanted 1", got)
+	}
+	if got := sharedCount.Load(); got != callers {
+		t.Errorf("%d callers got a shared result, wanted %d", got, callers)
+	}
+
+	// Once done, the next evaluation is not shared.
+	if _, _, shared := i.do(ctx, "key", func(context.Context) (*PolicyResult, []error) { return want, nil }); shared {
+		t.Error("do() after the evaluation completed was shared")
+	}
+}
+
+func TestInflightDoLeaderCanceled(t *testing.T) {
+	i := &inflightEvaluations{}
+	want := &PolicyResult{}
+	started := make(chan struct{})
+	release := make(chan struct{})
+	evaluate := func(ctx context.Context) (*PolicyResult, []error) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			return nil, []error{ctx.Err()}
+		case <-release:
+			return want, nil
+		}
+	}
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErrs := make(chan []error)
+	go func() {
+		_, errs, _ := i.do(leaderCtx, "key", evaluate)
+		leaderErrs <- errs
+	}()
+	<-started
+
+	waiter := make(chan *PolicyResult)
+	go func() {
+		got, _, _ := i.do(context.Background(), "key", evaluate)
+		waiter <- got
+	}()
+	// Give the waiter a chance to join the evaluation.
+	time.Sleep(100 * time.Millisecond)
+
+	// Canceling the leader only fails the leader.
+	cancel()
+	if errs := <-leaderErrs; len(errs) != 1 || !errors.Is(errs[0], context.Canceled) {
+		t.Errorf("do() for the canceled leader = %v, wanted %v", errs, context.Canceled)
+	}
+	close(release)
+	if got := <-waiter; got != want {
+		t.Errorf("do() for the waiter = %v, wanted %v", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/digitorus/timestamp"
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/oci"
+
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+)
+
+// filterByKeyValidity returns the signatures that were made within the
+// validity window of the key. Cosign has already verified the RFC3161
+// timestamp or the Rekor bundle that the signing time is taken from.
+func filterByKeyValidity(sigs []oci.Signature, keyRef *webhookcip.KeyRef, checkOpts *cosign.CheckOpts) ([]oci.Signature, error) {
+	if keyRef.ValidFrom == nil && keyRef.ValidUntil == nil {
+		return sigs, nil
+	}
+	ret := make([]oci.Signature, 0, len(sigs))
+	var errs []error
+	for _, sig := range sigs {
+		signedAt, err := signingTime(sig, checkOpts)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if keyRef.ValidFrom != nil && signedAt.Before(keyRef.ValidFrom.Time) {
+			errs = append(errs, fmt.Errorf("signature was made at %s, before the key was valid from %s", signedAt.UTC().Format(time.RFC3339), keyRef.ValidFrom.UTC().Format(time.RFC3339)))
+			continue
+		}
+		if keyRef.ValidUntil != nil && signedAt.After(keyRef.ValidUntil.Time) {
+			errs = append(errs, fmt.Errorf("signature was made at %s, after the key was valid until %s", signedAt.UTC().Format(time.RFC3339), keyRef.ValidUntil.UTC().Format(time.RFC3339)))
+			continue
+		}
+		ret = append(ret, sig)
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("none of the signatures were made within the validity of the key: %w", errors.Join(errs...))
+	}
+	return ret, nil
+}
+
+// signingTime returns the time that sig was made at, as attested by its
+// RFC3161 timestamp or by the integrated time of its Rekor bundle. Only the
+// evidence that cosign verified with checkOpts is used.
+func signingTime(sig oci.Signature, checkOpts *cosign.CheckOpts) (time.Time, error) {
+	if checkOpts.UseSignedTimestamps {
+		ts, err := sig.RFC3161Timestamp()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("getting RFC3161 timestamp: %w", err)
+		}
+		if ts != nil {
+			parsed, err := timestamp.ParseResponse(ts.SignedRFC3161Timestamp)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("parsing RFC3161 timestamp: %w", err)
+			}
+			return parsed.Time, nil
+		}
+	}
+	if !checkOpts.IgnoreTlog {
+		bundle, err := sig.Bundle()
+		if err != nil {
+			return time.Time{}, fmt.Errorf("getting Rekor bundle: %w", err)
+		}
+		if bundle != nil {
+			return time.Unix(bundle.Payload.IntegratedTime, 0), nil
+		}
+	}
+	return time.Time{}, errors.New("signature has no verified RFC3161 timestamp or Rekor bundle to establish the signing time")
+}
@@ -0,0 +1,96 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/bundle"
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/cosign/v3/pkg/oci/static"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+)
+
+func TestFilterByKeyValidity(t *testing.T) {
+	rotatedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newSig := func(integratedTime time.Time) oci.Signature {
+		sig, err := static.NewSignature(nil, "", static.WithBundle(&bundle.RekorBundle{
+			Payload: bundle.RekorPayload{IntegratedTime: integratedTime.Unix()},
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	before := newSig(rotatedAt.Add(-time.Hour))
+	after := newSig(rotatedAt.Add(time.Hour))
+
+	tests := []struct {
+		name      string
+		sigs      []oci.Signature
+		keyRef    webhookcip.KeyRef
+		checkOpts cosign.CheckOpts
+		want      int
+		wantErr   string
+	}{{
+		name: "no validity",
+		sigs: []oci.Signature{before, after},
+		want: 2,
+	}, {
+		name:   "signed before the key was retired",
+		sigs:   []oci.Signature{before, after},
+		keyRef: webhookcip.KeyRef{ValidUntil: &metav1.Time{Time: rotatedAt}},
+		want:   1,
+	}, {
+		name:    "signed after the key was retired",
+		sigs:    []oci.Signature{after},
+		keyRef:  webhookcip.KeyRef{ValidUntil: &metav1.Time{Time: rotatedAt}},
+		wantErr: "after the key was valid until 2026-01-01T00:00:00Z",
+	}, {
+		name:    "signed before the key was valid",
+		sigs:    []oci.Signature{before},
+		keyRef:  webhookcip.KeyRef{ValidFrom: &metav1.Time{Time: rotatedAt}},
+		wantErr: "before the key was valid from 2026-01-01T00:00:00Z",
+	}, {
+		name:      "tlog not verified",
+		sigs:      []oci.Signature{before},
+		keyRef:    webhookcip.KeyRef{ValidUntil: &metav1.Time{Time: rotatedAt}},
+		checkOpts: cosign.CheckOpts{IgnoreTlog: true},
+		wantErr:   "signature has no verified RFC3161 timestamp or Rekor bundle",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := filterByKeyValidity(test.sigs, &test.keyRef, &test.checkOpts)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("filterByKeyValidity() = %v, wanted error containing %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("filterByKeyValidity() = %v", err)
+			}
+			if len(got) != test.want {
+				t.Errorf("filterByKeyValidity() returned %d signatures, wanted %d", len(got), test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+	"knative.dev/pkg/logging"
+)
+
+// lruCacheKey uniquely identifies the evaluation of a given CIP (at a
+// particular resourceVersion) against an image.
+type lruCacheKey struct {
+	image           string
+	uid             string
+	resourceVersion string
+}
+
+type lruCacheEntry struct {
+	result  *CacheResult
+	expires time.Time
+}
+
+// LRUCache is a bounded, in-memory ResultCache. Entries are evicted when the
+// cache is full (least recently used first), when they are older than the
+// configured TTL, or when the CIP they were computed from is updated or
+// deleted (see EvictStale).
+type LRUCache struct {
+	cache *lru.Cache
+	// ttl is how long successful validations are cached for.
+	ttl time.Duration
+	// errorTTL is how long failed validations are cached for. Failures are
+	// frequently transient (registry timeouts, rate limiting, etc.) so this
+	// is configured independently, and 0 means they are not cached at all.
+	errorTTL time.Duration
+
+	// For testing.
+	now func() time.Time
+}
+
+var _ ResultCache = (*LRUCache)(nil)
+
+// NewLRUCache creates a new LRUCache holding at most size entries.
+func NewLRUCache(size int, ttl, errorTTL time.Duration) (*LRUCache, error) {
+	if ttl <= 0 {
+		return nil, fmt.Errorf("ttl must be positive, got %s", ttl)
+	}
+	if errorTTL < 0 {
+		return nil, fmt.Errorf("errorTTL must not be negative, got %s", errorTTL)
+	}
+	c, err := lru.New(size)
+	if err != nil {
+		return nil, fmt.Errorf("creating lru cache: %w", err)
+	}
+	return &LRUCache{
+		cache:    c,
+		ttl:      ttl,
+		errorTTL: errorTTL,
+		now:      time.Now,
+	}, nil
+}
+
+// Get implements ResultCache.
+func (c *LRUCache) Get(ctx context.Context, image, uid, resourceVersion string) *CacheResult {
+	key := lruCacheKey{image: image, uid: uid, resourceVersion: resourceVersion}
+	v, ok := c.cache.Get(key)
+	if !ok {
+		recordResultCacheLookup(ctx, false)
+		return nil
+	}
+	entry := v.(*lruCacheEntry)
+	if c.now().After(entry.expires) {
+		logging.FromContext(ctx).Debugf("Cached result for %s expired", image)
+		c.cache.Remove(key)
+		recordResultCacheLookup(ctx, false)
+		return nil
+	}
+	logging.FromContext(ctx).Debugf("Using cached result for %s", image)
+	recordResultCacheLookup(ctx, true)
+	return entry.result
+}
+
+// Set implements ResultCache.
+func (c *LRUCache) Set(ctx context.Context, image, name, uid, resourceVersion string, cacheResult *CacheResult) {
+	if cacheResult == nil {
+		return
+	}
+	ttl := c.ttl
+	if cacheResult.PolicyResult == nil {
+		ttl = c.errorTTL
+	}
+	if ttl == 0 {
+		return
+	}
+	key := lruCacheKey{image: image, uid: uid, resourceVersion: resourceVersion}
+	expires := c.now().Add(ttl)
+	// Setting a result that is already cached does not extend how long it is
+	// cached for, so that images that keep being admitted are still verified
+	// again once the TTL has passed.
+	if v, ok := c.cache.Peek(key); ok {
+		if entry := v.(*lruCacheEntry); c.now().Before(entry.expires) && entry.expires.Before(expires) {
+			expires = entry.expires
+		}
+	}
+	logging.FromContext(ctx).Debugf("Caching result of %s for %s until %s", name, image, expires)
+	c.cache.Add(key, &lruCacheEntry{
+		result:  cacheResult,
+		expires: expires,
+	})
+}
+
+// EvictStale removes all the cached results that were computed from a CIP
+// that is no longer in policies, or that has since been modified. This is
+// meant to be called whenever the compiled policies change so that results
+// for updated or deleted CIPs do not linger in the cache until they expire.
+func (c *LRUCache) EvictStale(policies map[string]webhookcip.ClusterImagePolicy) {
+	current := make(map[string]string, len(policies))
+	for _, cip := range policies {
+		current[string(cip.UID)] = cip.ResourceVersion
+	}
+	for _, k := range c.cache.Keys() {
+		key := k.(lruCacheKey)
+		if rv, ok := current[key.uid]; !ok || rv != key.resourceVersion {
+			c.cache.Remove(key)
+		}
+	}
+}
+
+// Purge removes all the cached results. This is meant to be called whenever
+// something that every policy depends on changes, like the revocations or the
+// TrustRoots.
+func (c *LRUCache) Purge() {
+	c.cache.Purge()
+}
+
+// Len returns the number of results currently in the cache.
+func (c *LRUCache) Len() int {
+	return c.cache.Len()
+}
@@ -0,0 +1,194 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+)
+
+const (
+	cacheTestImage = "gcr.io/distroless/static@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"
+)
+
+func TestLRUCacheGetSet(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewLRUCache(2, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("NewLRUCache() = %v", err)
+	}
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	success := &CacheResult{PolicyResult: &PolicyResult{}}
+	c.Set(ctx, cacheTestImage, "cip", "uid", "1", success)
+	if got := c.Get(ctx, cacheTestImage, "uid", "1"); got != success {
+		t.Errorf("Get() = %v, wanted %v", got, success)
+	}
+	// Different resourceVersion must not hit.
+	if got := c.Get(ctx, cacheTestImage, "uid", "2"); got != nil {
+		t.Errorf("Get() with different resourceVersion = %v, wanted nil", got)
+	}
+
+	// Errors are not cached with a zero errorTTL.
+	c.Set(ctx, cacheTestImage, "cip", "uid-2", "1", &CacheResult{Errors: []error{errors.New("failed")}})
+	if got := c.Get(ctx, cacheTestImage, "uid-2", "1"); got != nil {
+		t.Errorf("Get() for failed result = %v, wanted nil", got)
+	}
+
+	// Expire the entry.
+	now = now.Add(2 * time.Minute)
+	if got := c.Get(ctx, cacheTestImage, "uid", "1"); got != nil {
+		t.Errorf("Get() after expiry = %v, wanted nil", got)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len() after expiry = %d, wanted 0", c.Len())
+	}
+}
+
+func TestLRUCacheHitsDoNotExtendTTL(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewLRUCache(2, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("NewLRUCache() = %v", err)
+	}
+	start := time.Now()
+	now := start
+	c.now = func() time.Time { return now }
+
+	success := &CacheResult{PolicyResult: &PolicyResult{}}
+	c.Set(ctx, cacheTestImage, "cip", "uid", "1", success)
+	// The image keeps getting admitted, and its result cached again.
+	for _, elapsed := range []time.Duration{30 * time.Second, 59 * time.Second} {
+		now = start.Add(elapsed)
+		if got := c.Get(ctx, cacheTestImage, "uid", "1"); got != success {
+			t.Errorf("Get() after %s = %v, wanted %v", elapsed, got, success)
+		}
+		c.Set(ctx, cacheTestImage, "cip", "uid", "1", success)
+	}
+	// It still expires a minute after it was first cached.
+	now = start.Add(61 * time.Second)
+	if got := c.Get(ctx, cacheTestImage, "uid", "1"); got != nil {
+		t.Errorf("Get() after the TTL = %v, wanted nil", got)
+	}
+}
+
+func TestLRUCacheSize(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewLRUCache(2, time.Minute, time.Minute)
+	if err != nil {
+		t.Fatalf("NewLRUCache() = %v", err)
+	}
+	for _, uid := range []string{"a", "b", "c"} {
+		c.Set(ctx, cacheTestImage, "cip", uid, "1", &CacheResult{Errors: []error{errors.New("failed")}})
+	}
+	if c.Len() != 2 {
+		t.Errorf("Len() = %d, wanted 2", c.Len())
+	}
+	if got := c.Get(ctx, cacheTestImage, "a", "1"); got != nil {
+		t.Errorf("Get() for least recently used = %v, wanted nil", got)
+	}
+}
+
+func TestLRUCacheEvictStale(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewLRUCache(10, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("NewLRUCache() = %v", err)
+	}
+	result := &CacheResult{PolicyResult: &PolicyResult{}}
+	c.Set(ctx, cacheTestImage, "unchanged", "unchanged", "1", result)
+	c.Set(ctx, cacheTestImage, "updated", "updated", "1", result)
+	c.Set(ctx, cacheTestImage, "deleted", "deleted", "1", result)
+
+	c.EvictStale(map[string]webhookcip.ClusterImagePolicy{
+		"unchanged": {UID: "unchanged", ResourceVersion: "1"},
+		"updated":   {UID: "updated", ResourceVersion: "2"},
+	})
+	if got := c.Get(ctx, cacheTestImage, "unchanged", "1"); got == nil {
+		t.Error("Get() for unchanged CIP = nil, wanted result")
+	}
+	if got := c.Get(ctx, cacheTestImage, "updated", "1"); got != nil {
+		t.Errorf("Get() for updated CIP = %v, wanted nil", got)
+	}
+	if got := c.Get(ctx, cacheTestImage, "deleted", "1"); got != nil {
+		t.Errorf("Get() for deleted CIP = %v, wanted nil", got)
+	}
+}
+
+func TestLRUCachePurge(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewLRUCache(10, time.Minute, 0)
+	if err != nil {
+		t.Fatalf("NewLRUCache() = %v", err)
+	}
+	result := &CacheResult{PolicyResult: &PolicyResult{}}
+	c.Set(ctx, cacheTestImage, "first", "first", "1", result)
+	c.Set(ctx, cacheTestImage, "second", "second", "1", result)
+
+	c.Purge()
+	if c.Len() != 0 {
+		t.Errorf("Len() after Purge() = %d, wanted 0", c.Len())
+	}
+}
+
+func TestNewLRUCacheErrors(t *testing.T) {
+	if _, err := NewLRUCache(0, time.Minute, 0); err == nil {
+		t.Error("NewLRUCache() with size 0 succeeded, wanted error")
+	}
+	if _, err := NewLRUCache(1, 0, 0); err == nil {
+		t.Error("NewLRUCache() with zero ttl succeeded, wanted error")
+	}
+	if _, err := NewLRUCache(1, time.Minute, -time.Minute); err == nil {
+		t.Error("NewLRUCache() with negative errorTTL succeeded, wanted error")
+	}
+}
+
+func TestResultKey(t *testing.T) {
+	digest := name.MustParseReference("gcr.io/distroless/static@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4")
+	tag := name.MustParseReference("gcr.io/distroless/static:nonroot")
+	ctxA := withKeychainIdentity(context.Background(), k8schain.Options{Namespace: "a", ImagePullSecrets: []string{"y", "x"}})
+	ctxA2 := withKeychainIdentity(context.Background(), k8schain.Options{Namespace: "a", ImagePullSecrets: []string{"x", "y"}})
+	ctxB := withKeychainIdentity(context.Background(), k8schain.Options{Namespace: "b", ImagePullSecrets: []string{"x", "y"}})
+
+	if _, ok := resultKey(context.Background(), digest); ok {
+		t.Error("resultKey() without credentials succeeded, wanted none")
+	}
+	if _, ok := resultKey(ctxA, tag); ok {
+		t.Error("resultKey() of a tag succeeded, wanted none")
+	}
+	keyA, ok := resultKey(ctxA, digest)
+	if !ok {
+		t.Fatal("resultKey() of a digest failed")
+	}
+	short, _ := resultKey(ctxA, name.MustParseReference("busybox@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"))
+	full, _ := resultKey(ctxA, name.MustParseReference("index.docker.io/library/busybox@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"))
+	if short != full {
+		t.Errorf("resultKey() of a short reference = %q, wanted %q", short, full)
+	}
+	if keyA2, _ := resultKey(ctxA2, digest); keyA2 != keyA {
+		t.Errorf("resultKey() with reordered pull secrets = %q, wanted %q", keyA2, keyA)
+	}
+	if keyB, _ := resultKey(ctxB, digest); keyB == keyA {
+		t.Errorf("resultKey() in another namespace = %q, wanted it to differ", keyB)
+	}
+}
@@ -0,0 +1,207 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/metrics"
+)
+
+// The metrics are exported through knative's metrics exporter, which is
+// configured by the config-observability ConfigMap, prometheus by default.
+
+const (
+	// Values of the result tag of admission decisions.
+	decisionAllow = "allow"
+	decisionDeny  = "deny"
+	decisionWarn  = "warn"
+
+	// Values of the authority_type tag.
+	authorityTypeCertificate = "certificate"
+	authorityTypeKey         = "key"
+	authorityTypeKeyless     = "keyless"
+	authorityTypeNotation    = "notation"
+	authorityTypeStatic      = "static"
+	authorityTypeTSA         = "tsa"
+
+	// Values of the artifact tag of fetch errors.
+	artifactSignature   = "signature"
+	artifactAttestation = "attestation"
+)
+
+var (
+	admissionDecisionsM = stats.Int64(
+		"policy_admission_decisions",
+		"Number of admission decisions made for each ClusterImagePolicy matching an image",
+		stats.UnitDimensionless)
+	authorityVerificationLatencyM = stats.Float64(
+		"authority_verification_latency",
+		"Time it took to verify an image against an authority",
+		stats.UnitMilliseconds)
+	fetchErrorsM = stats.Int64(
+		"fetch_errors",
+		"Number of errors fetching signatures or attestations from the registry",
+		stats.UnitDimensionless)
+	resultCacheLookupsM = stats.Int64(
+		"policy_result_cache_lookups",
+		"Number of policy evaluation result cache lookups, the hit tag tells whether a result was found",
+		stats.UnitDimensionless)
+	decisionLogDroppedM = stats.Int64(
+		"decision_log_dropped",
+		"Number of admission decisions that could not be written to the decision log",
+		stats.UnitDimensionless)
+
+	policyKey        = tag.MustNewKey("policy")
+	modeKey          = tag.MustNewKey("mode")
+	resultKey        = tag.MustNewKey("result")
+	authorityTypeTag = tag.MustNewKey("authority_type")
+	artifactKey      = tag.MustNewKey("artifact")
+	hitKey           = tag.MustNewKey("hit")
+)
+
+func init() {
+	if err := metrics.RegisterResourceView(
+		&view.View{
+			Description: admissionDecisionsM.Description(),
+			Measure:     admissionDecisionsM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{policyKey, modeKey, resultKey},
+		},
+		&view.View{
+			Description: authorityVerificationLatencyM.Description(),
+			Measure:     authorityVerificationLatencyM,
+			Aggregation: view.Distribution(10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000),
+			TagKeys:     []tag.Key{authorityTypeTag},
+		},
+		&view.View{
+			Description: fetchErrorsM.Description(),
+			Measure:     fetchErrorsM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{artifactKey},
+		},
+		&view.View{
+			Description: resultCacheLookupsM.Description(),
+			Measure:     resultCacheLookupsM,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{hitKey},
+		},
+		&view.View{
+			Description: decisionLogDroppedM.Description(),
+			Measure:     decisionLogDroppedM,
+			Aggregation: view.Count(),
+		},
+	); err != nil {
+		panic(err)
+	}
+}
+
+// recordAdmissionDecision records whether the image was admitted by the
+// given ClusterImagePolicy. Evaluations done outside of admission, for
+// example by the audit controller, are not recorded.
+func recordAdmissionDecision(ctx context.Context, name string, cip webhookcip.ClusterImagePolicy, admitted bool) {
+	if !apis.IsInCreate(ctx) && !apis.IsInUpdate(ctx) {
+		return
+	}
+	mode := cip.Mode
+	if mode == "" {
+		mode = "enforce"
+	}
+	result := decisionAllow
+	switch {
+	case admitted:
+	case mode == "warn":
+		result = decisionWarn
+	default:
+		result = decisionDeny
+	}
+	metrics.Record(ctx, admissionDecisionsM.M(1), stats.WithTags(
+		tag.Upsert(policyKey, name),
+		tag.Upsert(modeKey, mode),
+		tag.Upsert(resultKey, result),
+	))
+}
+
+// authorityType returns the authority_type tag value for the authority.
+func authorityType(authority webhookcip.Authority) string {
+	switch {
+	case authority.Static != nil:
+		return authorityTypeStatic
+	case authority.Key != nil:
+		return authorityTypeKey
+	case authority.Keyless != nil:
+		return authorityTypeKeyless
+	case authority.Certificate != nil:
+		return authorityTypeCertificate
+	case authority.Notation != nil:
+		return authorityTypeNotation
+	case authority.RFC3161Timestamp != nil:
+		return authorityTypeTSA
+	}
+	return "unknown"
+}
+
+// recordAuthorityVerificationLatency records how long verifying an image
+// against the authority took since start.
+func recordAuthorityVerificationLatency(ctx context.Context, authority webhookcip.Authority, start time.Time) {
+	metrics.Record(ctx, authorityVerificationLatencyM.M(float64(time.Since(start).Milliseconds())), stats.WithTags(
+		tag.Upsert(authorityTypeTag, authorityType(authority)),
+	))
+}
+
+// recordFetchError records err if it was caused by failing to talk to the
+// registry, as opposed to for example there being no valid signatures.
+func recordFetchError(ctx context.Context, artifact string, err error) {
+	if !isFetchError(err) {
+		return
+	}
+	metrics.Record(ctx, fetchErrorsM.M(1), stats.WithTags(
+		tag.Upsert(artifactKey, artifact),
+	))
+}
+
+func isFetchError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var terr *transport.Error
+	var nerr net.Error
+	return errors.As(err, &terr) || errors.As(err, &nerr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// recordResultCacheLookup records a lookup in the result cache, the hit
+// ratio being the share of these with hit="true".
+func recordResultCacheLookup(ctx context.Context, hit bool) {
+	metrics.Record(ctx, resultCacheLookupsM.M(1), stats.WithTags(
+		tag.Upsert(hitKey, strconv.FormatBool(hit)),
+	))
+}
+
+// recordDecisionDropped records an admission decision that could not be
+// written to the decision log.
+func recordDecisionDropped(ctx context.Context) {
+	metrics.Record(ctx, decisionLogDroppedM.M(1))
+}
@@ -0,0 +1,89 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+)
+
+func TestAuthorityType(t *testing.T) {
+	tests := []struct {
+		name      string
+		authority webhookcip.Authority
+		want      string
+	}{{
+		name:      "key",
+		authority: webhookcip.Authority{Key: &webhookcip.KeyRef{}},
+		want:      authorityTypeKey,
+	}, {
+		name:      "keyless",
+		authority: webhookcip.Authority{Keyless: &webhookcip.KeylessRef{}},
+		want:      authorityTypeKeyless,
+	}, {
+		name:      "static",
+		authority: webhookcip.Authority{Static: &webhookcip.StaticRef{Action: "pass"}},
+		want:      authorityTypeStatic,
+	}, {
+		name:      "tsa",
+		authority: webhookcip.Authority{RFC3161Timestamp: &webhookcip.RFC3161Timestamp{}},
+		want:      authorityTypeTSA,
+	}, {
+		name: "unknown",
+		want: "unknown",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := authorityType(test.authority); got != test.want {
+				t.Errorf("authorityType() = %s, wanted %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestIsFetchError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{{
+		name: "nil",
+	}, {
+		name: "no signatures",
+		err:  errors.New("no matching signatures"),
+	}, {
+		name: "registry error",
+		err:  fmt.Errorf("fetching signatures: %w", &transport.Error{StatusCode: http.StatusTooManyRequests}),
+		want: true,
+	}, {
+		name: "timeout",
+		err:  fmt.Errorf("fetching signatures: %w", context.DeadlineExceeded),
+		want: true,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := isFetchError(test.err); got != test.want {
+				t.Errorf("isFetchError() = %t, wanted %t", got, test.want)
+			}
+		})
+	}
+}
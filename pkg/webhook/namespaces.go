@@ -0,0 +1,46 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	corev1listers "k8s.io/client-go/listers/core/v1"
+)
+
+type namespaceListerKey struct{}
+
+// WithNamespaceLister attaches the NamespaceLister used to look up the labels
+// of the namespace of the resources being validated, which the
+// namespaceSelector of the policies is matched against.
+func WithNamespaceLister(ctx context.Context, lister corev1listers.NamespaceLister) context.Context {
+	return context.WithValue(ctx, namespaceListerKey{}, lister)
+}
+
+// getNamespaceLabels returns the labels of the namespace. If there is no
+// NamespaceLister in the context, or no namespace, it returns nil.
+func getNamespaceLabels(ctx context.Context, namespace string) (map[string]string, error) {
+	lister, ok := ctx.Value(namespaceListerKey{}).(corev1listers.NamespaceLister)
+	if !ok || namespace == "" {
+		return nil, nil
+	}
+	ns, err := lister.Get(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("getting the labels of namespace %s: %w", namespace, err)
+	}
+	return ns.Labels, nil
+}
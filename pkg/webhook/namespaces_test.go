@@ -0,0 +1,53 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestGetNamespaceLabels(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := indexer.Add(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}},
+	}); err != nil {
+		t.Fatalf("indexer.Add() = %v", err)
+	}
+	ctx := WithNamespaceLister(context.Background(), corev1listers.NewNamespaceLister(indexer))
+
+	labels, err := getNamespaceLabels(ctx, "prod")
+	if err != nil {
+		t.Fatalf("getNamespaceLabels() = %v", err)
+	}
+	if labels["env"] != "prod" {
+		t.Errorf("getNamespaceLabels() = %v, wanted env=prod", labels)
+	}
+	if _, err := getNamespaceLabels(ctx, "missing"); err == nil {
+		t.Error("getNamespaceLabels() for missing namespace succeeded, wanted error")
+	}
+
+	// Without a NamespaceLister there are no labels to match against.
+	labels, err = getNamespaceLabels(context.Background(), "prod")
+	if err != nil || labels != nil {
+		t.Errorf("getNamespaceLabels() without lister = %v, %v, wanted nil, nil", labels, err)
+	}
+}
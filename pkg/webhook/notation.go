@@ -0,0 +1,161 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"knative.dev/pkg/logging"
+
+	ociremote "github.com/sigstore/cosign/v3/pkg/oci/remote"
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	"github.com/sigstore/policy-controller/pkg/notation"
+)
+
+// maxNotationEnvelopeSize limits how much of a signature envelope is read.
+const maxNotationEnvelopeSize = 4 << 20
+
+// notationEnvelope is a Notary Project signature envelope that is stored
+// as a referrer of an image.
+type notationEnvelope struct {
+	// digest of the OCI layer holding the envelope.
+	digest    string
+	mediaType string
+	data      []byte
+}
+
+// For testing
+var notationEnvelopes = fetchNotationEnvelopes
+
+// validNotationSignatures returns the Notary Project signatures of ref that
+// are trusted by the trust store and trust policy of the notation authority.
+func validNotationSignatures(ctx context.Context, ref name.Reference, notationRef *v1alpha1.NotationRef, remoteOpts ...ociremote.Option) ([]PolicySignature, error) {
+	digest, err := ociremoteResolveDigest(ref, remoteOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("resolving digest: %w", err)
+	}
+	roots, err := certificateAuthorities(ctx, notationRef.CABundle, notationRef.TrustRootRef)
+	if err != nil {
+		return nil, fmt.Errorf("getting trust store: %w", err)
+	}
+	envelopes, err := notationEnvelopes(digest, remoteOpts...)
+	recordFetchError(ctx, artifactSignature, err)
+	if err != nil {
+		return nil, fmt.Errorf("fetching Notary Project signatures: %w", err)
+	}
+	if len(envelopes) == 0 {
+		return nil, errors.New("no Notary Project signatures found")
+	}
+
+	revocations := config.FromContextOrDefaults(ctx).RevocationsConfig
+	now := time.Now()
+	ret := make([]PolicySignature, 0, len(envelopes))
+	var errs []error
+	for _, envelope := range envelopes {
+		sig, err := notation.ParseEnvelope(envelope.mediaType, envelope.data)
+		if err == nil {
+			err = notation.Verify(sig, digest.DigestStr(), roots, notationRef, now)
+		}
+		if err != nil {
+			logging.FromContext(ctx).Debugf("Notary Project signature %s is not trusted: %v", envelope.digest, err)
+			errs = append(errs, fmt.Errorf("signature %s: %w", envelope.digest, err))
+			continue
+		}
+		leaf := sig.CertificateChain[0]
+		fingerprint, err := keyFingerprint(leaf.PublicKey)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("signature %s: %w", envelope.digest, err))
+			continue
+		}
+		// The signing time of a Notary Project signature is claimed by the
+		// signer rather than attested, so it cannot be relied upon to tell
+		// whether the signature was made before a compromise.
+		if revokedBy, revoked := revocations.Revoked(config.RevocationCandidate{
+			KeyFingerprint:    fingerprint,
+			CertificateSerial: leaf.SerialNumber.Text(16),
+			Digest:            envelope.digest,
+		}); revoked {
+			logging.FromContext(ctx).Warnf("Discarding Notary Project signature %s revoked by %s", envelope.digest, revokedBy)
+			errs = append(errs, fmt.Errorf("signature %s is revoked by %s", envelope.digest, revokedBy))
+			continue
+		}
+		ret = append(ret, PolicySignature{
+			ID:      envelope.digest,
+			Subject: leaf.Subject.String(),
+			Issuer:  leaf.Issuer.String(),
+		})
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("none of the Notary Project signatures were trusted: %w", errors.Join(errs...))
+	}
+	return ret, nil
+}
+
+// fetchNotationEnvelopes returns the Notary Project signature envelopes that
+// are stored as referrers of digest.
+func fetchNotationEnvelopes(digest name.Digest, remoteOpts ...ociremote.Option) ([]notationEnvelope, error) {
+	indexManifest, err := ociremoteReferrers(digest, notation.ArtifactType, remoteOpts...)
+	if err != nil {
+		return nil, err
+	}
+	var envelopes []notationEnvelope
+	for _, manifest := range indexManifest.Manifests {
+		// Registries that do not support filtering return all the referrers.
+		if manifest.ArtifactType != notation.ArtifactType {
+			continue
+		}
+		signedEntity, err := ociremoteSignedImage(digest.Context().Digest(manifest.Digest.String()), remoteOpts...)
+		if err != nil {
+			return nil, err
+		}
+		layers, err := signedEntity.Layers()
+		if err != nil {
+			return nil, err
+		}
+		if len(layers) != 1 {
+			return nil, fmt.Errorf("signature manifest %s has %d layers, expected 1", manifest.Digest, len(layers))
+		}
+		mediaType, err := layers[0].MediaType()
+		if err != nil {
+			return nil, err
+		}
+		layerDigest, err := layers[0].Digest()
+		if err != nil {
+			return nil, err
+		}
+		rc, err := layers[0].Compressed()
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(io.LimitReader(rc, maxNotationEnvelopeSize))
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		envelopes = append(envelopes, notationEnvelope{
+			digest:    layerDigest.String(),
+			mediaType: string(mediaType),
+			data:      data,
+		})
+	}
+	return envelopes, nil
+}
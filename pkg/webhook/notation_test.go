@@ -0,0 +1,169 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/sigstore/cosign/v3/pkg/oci/remote"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+	"github.com/sigstore/policy-controller/pkg/notation"
+)
+
+// notationJWS returns a PEM encoded self-signed code signing certificate and
+// a JWS envelope of a Notary Project signature of digest made with it.
+func notationJWS(t *testing.T, digest string) (string, []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Country: []string{"US"}, Province: []string{"WA"}, Organization: []string{"Example Corp"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marshal := func(v interface{}) string {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(b)
+	}
+	protected := marshal(map[string]interface{}{
+		"alg":                          "ES256",
+		"cty":                          notation.MediaTypePayload,
+		"crit":                         []string{"io.cncf.notary.signingScheme"},
+		"io.cncf.notary.signingScheme": notation.SigningSchemeX509,
+		"io.cncf.notary.signingTime":   time.Now().Format(time.RFC3339),
+	})
+	payload := marshal(map[string]interface{}{"targetArtifact": map[string]interface{}{
+		"mediaType": "application/vnd.oci.image.manifest.v1+json",
+		"digest":    digest,
+		"size":      1234,
+	}})
+	hash := sha256.Sum256([]byte(protected + "." + payload))
+	r, s, err := ecdsa.Sign(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig := make([]byte, 64)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:])
+	envelope, err := json.Marshal(map[string]interface{}{
+		"payload":   payload,
+		"protected": protected,
+		"header":    map[string]interface{}{"x5c": [][]byte{der}},
+		"signature": base64.RawURLEncoding.EncodeToString(sig),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), envelope
+}
+
+func TestValidNotationSignatures(t *testing.T) {
+	const digest = "sha256:abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234"
+	ref := name.MustParseReference("example.com/test@" + digest)
+	root, envelope := notationJWS(t, digest)
+	otherRoot, _ := notationJWS(t, digest)
+
+	origResolve := ociremoteResolveDigest
+	origEnvelopes := notationEnvelopes
+	defer func() {
+		ociremoteResolveDigest = origResolve
+		notationEnvelopes = origEnvelopes
+	}()
+	ociremoteResolveDigest = func(ref name.Reference, _ ...remote.Option) (name.Digest, error) {
+		return ref.(name.Digest), nil
+	}
+
+	tests := []struct {
+		name      string
+		envelopes []notationEnvelope
+		ref       v1alpha1.NotationRef
+		wantErr   string
+	}{{
+		name:      "trusted signature",
+		envelopes: []notationEnvelope{{digest: "sha256:jws", mediaType: notation.MediaTypeJWS, data: envelope}},
+		ref:       v1alpha1.NotationRef{CABundle: root, TrustedIdentities: []string{"x509.subject: C=US, ST=WA, O=Example Corp"}},
+	}, {
+		name: "one of the signatures is trusted",
+		envelopes: []notationEnvelope{
+			{digest: "sha256:cose", mediaType: notation.MediaTypeCOSE, data: []byte("not cbor")},
+			{digest: "sha256:jws", mediaType: notation.MediaTypeJWS, data: envelope},
+		},
+		ref: v1alpha1.NotationRef{CABundle: root},
+	}, {
+		name:      "untrusted root",
+		envelopes: []notationEnvelope{{digest: "sha256:jws", mediaType: notation.MediaTypeJWS, data: envelope}},
+		ref:       v1alpha1.NotationRef{CABundle: otherRoot},
+		wantErr:   "none of the Notary Project signatures were trusted: signature sha256:jws: verifying certificate chain",
+	}, {
+		name:      "untrusted identity",
+		envelopes: []notationEnvelope{{digest: "sha256:jws", mediaType: notation.MediaTypeJWS, data: envelope}},
+		ref:       v1alpha1.NotationRef{CABundle: root, TrustedIdentities: []string{"x509.subject: C=US, ST=WA, O=Other Corp"}},
+		wantErr:   "is not a trusted identity",
+	}, {
+		name:    "no signatures",
+		ref:     v1alpha1.NotationRef{CABundle: root},
+		wantErr: "no Notary Project signatures found",
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			notationEnvelopes = func(name.Digest, ...remote.Option) ([]notationEnvelope, error) {
+				return test.envelopes, nil
+			}
+			sigs, err := validNotationSignatures(context.Background(), ref, &test.ref)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("validNotationSignatures() = %v, wanted error containing %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validNotationSignatures() = %v", err)
+			}
+			if len(sigs) != 1 || sigs[0].ID != "sha256:jws" || sigs[0].Subject != "O=Example Corp,ST=WA,C=US" {
+				t.Errorf("validNotationSignatures() = %+v", sigs)
+			}
+		})
+	}
+}
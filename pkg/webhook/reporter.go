@@ -0,0 +1,146 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type reporterKey struct{}
+
+// Reporter is notified of the outcome of evaluating all the policies that
+// match an image. This happens both on admission, and when the audit
+// controller re-evaluates running Pods.
+type Reporter interface {
+	Report(ctx context.Context, evaluation *ImageEvaluation)
+}
+
+// Reporters is a Reporter that notifies each of the Reporters in turn.
+type Reporters []Reporter
+
+// Report implements Reporter.
+func (rs Reporters) Report(ctx context.Context, evaluation *ImageEvaluation) {
+	for _, r := range rs {
+		r.Report(ctx, evaluation)
+	}
+}
+
+// ImageEvaluation is the outcome of evaluating all the policies matching an
+// image used by a resource.
+type ImageEvaluation struct {
+	// Resource is the resource that was evaluated, for example the
+	// Deployment being admitted.
+	Resource corev1.ObjectReference
+	Image    string
+	// Policies holds the outcome for each of the matching policies, keyed by
+	// the name of the policy.
+	Policies map[string]PolicyEvaluation
+}
+
+// PolicyEvaluation is the outcome of evaluating a single policy.
+type PolicyEvaluation struct {
+	// Mode of the policy, enforce or warn.
+	Mode string
+	// Result is set if the policy was satisfied.
+	Result *PolicyResult
+	// Errors holds the reasons the policy was not satisfied. Errors caused
+	// by a particular authority are AuthorityErrors.
+	Errors []error
+	// Exception is the name of the PolicyException that exempted the image
+	// from the policy, in which case the policy was not evaluated at all.
+	Exception string
+}
+
+// AuthorityError is returned from ValidatePolicy when an image fails the
+// checks of a particular authority, so that callers can tell which one.
+type AuthorityError struct {
+	Authority string
+	Err       error
+}
+
+// Error implements error, and is the same as the wrapped error.
+func (e *AuthorityError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the wrapped error.
+func (e *AuthorityError) Unwrap() error {
+	return e.Err
+}
+
+// AuthorityOf returns the name of the authority that caused err, or empty
+// string if it was not caused by a particular authority.
+func AuthorityOf(err error) string {
+	var ae *AuthorityError
+	if errors.As(err, &ae) {
+		return ae.Authority
+	}
+	return ""
+}
+
+// WithReporter attaches the Reporter to the context.
+func WithReporter(ctx context.Context, reporter Reporter) context.Context {
+	return context.WithValue(ctx, reporterKey{}, reporter)
+}
+
+// ReporterFromContext returns the Reporter attached to the context, or nil if
+// there is none.
+func ReporterFromContext(ctx context.Context) Reporter {
+	x, ok := ctx.Value(reporterKey{}).(Reporter)
+	if ok {
+		return x
+	}
+	return nil
+}
+
+// reportEvaluation sends the outcome of evaluating the given policies
+// against the image to the Reporter in the context, if any. Policies the image
+// was exempted from are reported as they are in exempted.
+func reportEvaluation(ctx context.Context, namespace, kind, apiVersion, image string, policies map[string]webhookcip.ClusterImagePolicy, exempted map[string]PolicyEvaluation, policyResults map[string]*PolicyResult, policyErrors map[string][]error) {
+	reporter := ReporterFromContext(ctx)
+	if reporter == nil {
+		return
+	}
+	evaluation := &ImageEvaluation{
+		Resource: corev1.ObjectReference{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Namespace:  namespace,
+		},
+		Image:    image,
+		Policies: make(map[string]PolicyEvaluation, len(policies)+len(exempted)),
+	}
+	if om, ok := GetIncludeObjectMeta(ctx).(metav1.ObjectMeta); ok {
+		evaluation.Resource.Name = om.Name
+		evaluation.Resource.UID = om.UID
+	}
+	for name, cip := range policies {
+		evaluation.Policies[name] = PolicyEvaluation{
+			Mode:   cip.Mode,
+			Result: policyResults[name],
+			Errors: policyErrors[name],
+		}
+	}
+	for name, exemption := range exempted {
+		evaluation.Policies[name] = exemption
+	}
+	reporter.Report(ctx, evaluation)
+}
@@ -0,0 +1,99 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+)
+
+type recordingReporter struct {
+	evaluations []*ImageEvaluation
+}
+
+func (r *recordingReporter) Report(_ context.Context, evaluation *ImageEvaluation) {
+	r.evaluations = append(r.evaluations, evaluation)
+}
+
+func TestAuthorityError(t *testing.T) {
+	fe := asFieldError(true, errors.New("bad signature"))
+	err := fmt.Errorf("wrapped: %w", &AuthorityError{Authority: "authority-0", Err: fe})
+	if got := AuthorityOf(err); got != "authority-0" {
+		t.Errorf("AuthorityOf() = %q, wanted authority-0", got)
+	}
+	if got := AuthorityOf(fe); got != "" {
+		t.Errorf("AuthorityOf() = %q, wanted empty", got)
+	}
+	var got *apis.FieldError
+	if !errors.As(err, &got) || got != fe {
+		t.Errorf("errors.As() = %v, wanted %v", got, fe)
+	}
+	if err.Error() != "wrapped: bad signature" {
+		t.Errorf("Error() = %q, wanted the message unchanged", err.Error())
+	}
+}
+
+func TestReportEvaluation(t *testing.T) {
+	// No reporter, nothing happens.
+	reportEvaluation(context.Background(), "default", "Pod", "v1", cacheTestImage, nil, nil, nil, nil)
+
+	reporter := &recordingReporter{}
+	ctx := WithReporter(context.Background(), reporter)
+	ctx = IncludeObjectMeta(ctx, metav1.ObjectMeta{Name: "test", UID: types.UID("uid")})
+	policies := map[string]webhookcip.ClusterImagePolicy{
+		"passing": {Mode: "enforce"},
+		"failing": {Mode: "warn"},
+	}
+	results := map[string]*PolicyResult{"passing": {}}
+	errs := map[string][]error{"failing": {errors.New("failed")}}
+	exempted := map[string]PolicyEvaluation{"exempted": {Mode: "enforce", Exception: "vendor-exception"}}
+	reportEvaluation(ctx, "default", "Pod", "v1", cacheTestImage, policies, exempted, results, errs)
+
+	if len(reporter.evaluations) != 1 {
+		t.Fatalf("Wanted 1 evaluation, got %d", len(reporter.evaluations))
+	}
+	got := reporter.evaluations[0]
+	if got.Resource.Name != "test" || got.Resource.UID != "uid" || got.Resource.Kind != "Pod" || got.Resource.Namespace != "default" {
+		t.Errorf("Unexpected resource %+v", got.Resource)
+	}
+	if got.Policies["passing"].Result == nil || len(got.Policies["passing"].Errors) != 0 {
+		t.Errorf("Unexpected evaluation for passing %+v", got.Policies["passing"])
+	}
+	if got.Policies["failing"].Result != nil || got.Policies["failing"].Mode != "warn" || len(got.Policies["failing"].Errors) != 1 {
+		t.Errorf("Unexpected evaluation for failing %+v", got.Policies["failing"])
+	}
+	if got.Policies["exempted"].Exception != "vendor-exception" || got.Policies["exempted"].Result != nil {
+		t.Errorf("Unexpected evaluation for exempted %+v", got.Policies["exempted"])
+	}
+}
+
+func TestReporters(t *testing.T) {
+	first, second := &recordingReporter{}, &recordingReporter{}
+	evaluation := &ImageEvaluation{Image: cacheTestImage}
+	Reporters{first, second}.Report(context.Background(), evaluation)
+	for i, r := range []*recordingReporter{first, second} {
+		if len(r.evaluations) != 1 || r.evaluations[0] != evaluation {
+			t.Errorf("Reporter %d got %v, wanted %v", i, r.evaluations, evaluation)
+		}
+	}
+}
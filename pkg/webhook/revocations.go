@@ -0,0 +1,121 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"knative.dev/pkg/logging"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+)
+
+// filterRevoked returns the signatures that are not revoked by any of the
+// Revocations. checkOpts are the options the signatures were verified with,
+// they tell which key verified them and which evidence of the signing time
+// can be relied upon.
+func filterRevoked(ctx context.Context, sigs []oci.Signature, checkOpts *cosign.CheckOpts) ([]oci.Signature, error) {
+	revocations := config.FromContextOrDefaults(ctx).RevocationsConfig
+	if revocations == nil || len(revocations.Revocations) == 0 {
+		return sigs, nil
+	}
+	ret := make([]oci.Signature, 0, len(sigs))
+	var errs []error
+	for _, sig := range sigs {
+		candidate, err := revocationCandidate(sig, checkOpts)
+		if err != nil {
+			// Without knowing what signed it, there is no telling whether
+			// the signature has been revoked, so discard it.
+			errs = append(errs, err)
+			continue
+		}
+		if revokedBy, revoked := revocations.Revoked(candidate); revoked {
+			logging.FromContext(ctx).Warnf("Discarding signature %s revoked by %s", candidate.Digest, revokedBy)
+			errs = append(errs, fmt.Errorf("signature %s is revoked by %s", candidate.Digest, revokedBy))
+			continue
+		}
+		ret = append(ret, sig)
+	}
+	if len(ret) == 0 {
+		return nil, fmt.Errorf("all the signatures are revoked: %w", errors.Join(errs...))
+	}
+	return ret, nil
+}
+
+// revocationCandidate describes sig for matching it against the
+// Revocations.
+func revocationCandidate(sig oci.Signature, checkOpts *cosign.CheckOpts) (config.RevocationCandidate, error) {
+	digest, err := sig.Digest()
+	if err != nil {
+		return config.RevocationCandidate{}, fmt.Errorf("getting signature digest: %w", err)
+	}
+	candidate := config.RevocationCandidate{Digest: digest.String()}
+
+	cert, err := sig.Cert()
+	if err != nil {
+		return config.RevocationCandidate{}, fmt.Errorf("getting signature certificate: %w", err)
+	}
+	var pub crypto.PublicKey
+	switch {
+	case checkOpts.SigVerifier != nil:
+		if pub, err = checkOpts.SigVerifier.PublicKey(); err != nil {
+			return config.RevocationCandidate{}, fmt.Errorf("getting verifier public key: %w", err)
+		}
+	case cert != nil:
+		pub = cert.PublicKey
+	}
+	if pub != nil {
+		if candidate.KeyFingerprint, err = keyFingerprint(pub); err != nil {
+			return config.RevocationCandidate{}, err
+		}
+	}
+	if cert != nil {
+		ce := cosign.CertExtensions{
+			Cert: cert,
+		}
+		candidate.Issuer = ce.GetIssuer()
+		if sans := cryptoutils.GetSubjectAlternateNames(cert); len(sans) > 0 {
+			candidate.Subject = sans[0]
+		}
+		candidate.CertificateSerial = cert.SerialNumber.Text(16)
+	}
+	// If the signing time is not known, the signature is revoked regardless
+	// of when the key or identity was compromised.
+	if signedAt, err := signingTime(sig, checkOpts); err == nil {
+		candidate.SignedAt = &signedAt
+	}
+	return candidate, nil
+}
+
+// keyFingerprint returns the hex encoded SHA-256 digest of the DER encoded
+// public key, the way the Revocations list them.
+func keyFingerprint(pub crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("marshaling public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return hex.EncodeToString(sum[:]), nil
+}
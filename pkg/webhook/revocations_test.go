@@ -0,0 +1,154 @@
+//
+// Copyright 2026 The Sigstore Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v3/pkg/cosign"
+	"github.com/sigstore/cosign/v3/pkg/cosign/bundle"
+	"github.com/sigstore/cosign/v3/pkg/oci"
+	"github.com/sigstore/cosign/v3/pkg/oci/static"
+	"github.com/sigstore/sigstore/pkg/signature"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/sigstore/policy-controller/pkg/apis/config"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
+)
+
+func TestFilterRevoked(t *testing.T) {
+	compromisedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := signature.LoadVerifier(key.Public(), crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint, err := keyFingerprint(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	newSig := func(payload string, integratedTime time.Time) oci.Signature {
+		sig, err := static.NewSignature([]byte(payload), "", static.WithBundle(&bundle.RekorBundle{
+			Payload: bundle.RekorPayload{IntegratedTime: integratedTime.Unix()},
+		}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return sig
+	}
+	before := newSig("before", compromisedAt.Add(-time.Hour))
+	after := newSig("after", compromisedAt.Add(time.Hour))
+	afterDigest, err := after.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyless := signatureWithExtensions(t)
+
+	tests := []struct {
+		name        string
+		revocations map[string]config.Revocation
+		sigs        []oci.Signature
+		checkOpts   cosign.CheckOpts
+		want        int
+		wantErr     string
+	}{{
+		name: "no revocations",
+		sigs: []oci.Signature{before, after},
+		want: 2,
+	}, {
+		name: "key revoked",
+		revocations: map[string]config.Revocation{
+			"leaked-key": {KeyFingerprints: []string{fingerprint}},
+		},
+		sigs:      []oci.Signature{before, after},
+		checkOpts: cosign.CheckOpts{SigVerifier: verifier},
+		wantErr:   "all the signatures are revoked",
+	}, {
+		name: "key revoked since",
+		revocations: map[string]config.Revocation{
+			"leaked-key": {KeyFingerprints: []string{fingerprint}, CompromisedAt: &metav1.Time{Time: compromisedAt}},
+		},
+		sigs:      []oci.Signature{before, after},
+		checkOpts: cosign.CheckOpts{SigVerifier: verifier},
+		want:      1,
+	}, {
+		name: "key revoked since, tlog not verified",
+		revocations: map[string]config.Revocation{
+			"leaked-key": {KeyFingerprints: []string{fingerprint}, CompromisedAt: &metav1.Time{Time: compromisedAt}},
+		},
+		sigs:      []oci.Signature{before},
+		checkOpts: cosign.CheckOpts{SigVerifier: verifier, IgnoreTlog: true},
+		wantErr:   "is revoked by leaked-key",
+	}, {
+		name: "digest revoked",
+		revocations: map[string]config.Revocation{
+			"bad-signature": {Digests: []string{afterDigest.String()}},
+		},
+		sigs: []oci.Signature{before, after},
+		want: 1,
+	}, {
+		name: "identity revoked",
+		revocations: map[string]config.Revocation{
+			"compromised-workflow": {Identities: []v1alpha1.RevokedIdentity{{Issuer: testIssuer, Subject: testSubject}}},
+		},
+		sigs:    []oci.Signature{keyless},
+		wantErr: "is revoked by compromised-workflow",
+	}, {
+		name: "certificate revoked",
+		revocations: map[string]config.Revocation{
+			"bad-certificate": {CertificateSerials: []string{"1"}},
+		},
+		sigs:    []oci.Signature{keyless},
+		wantErr: "is revoked by bad-certificate",
+	}, {
+		name: "other identity revoked",
+		revocations: map[string]config.Revocation{
+			"compromised-workflow": {Identities: []v1alpha1.RevokedIdentity{{Issuer: testIssuer, Subject: "https://github.com/example/repo/.github/workflows/release.yaml@refs/heads/main"}}},
+		},
+		sigs: []oci.Signature{keyless},
+		want: 1,
+	}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := config.ToContext(context.Background(), &config.Config{
+				RevocationsConfig: &config.RevocationsConfig{Revocations: test.revocations},
+			})
+			got, err := filterRevoked(ctx, test.sigs, &test.checkOpts)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("filterRevoked() = %v, wanted error containing %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("filterRevoked() = %v", err)
+			}
+			if len(got) != test.want {
+				t.Errorf("filterRevoked() returned %d signatures, wanted %d", len(got), test.want)
+			}
+		})
+	}
+}
@@ -56,6 +56,14 @@ func valid(ctx context.Context, ref name.Reference, keys []crypto.PublicKey, has
 			lastErr = err
 			continue
 		}
+		// Check the signatures against the Revocations while we still know
+		// which key verified them, so that another key gets a chance if
+		// this one has been revoked.
+		sps, err = filterRevoked(ctx, sps, checkOpts)
+		if err != nil {
+			lastErr = err
+			continue
+		}
 		return sps, nil
 	}
 	logging.FromContext(ctx).Debug("No valid signatures were found.")
@@ -73,6 +81,7 @@ var testProcessAttestationArtifact = processAttestationArtifact
 func validSignatures(ctx context.Context, ref name.Reference, checkOpts *cosign.CheckOpts) ([]oci.Signature, error) {
 	checkOpts.ClaimVerifier = cosign.SimpleClaimVerifier
 	sigs, _, err := cosignVerifySignatures(ctx, ref, checkOpts)
+	recordFetchError(ctx, artifactSignature, err)
 	return sigs, err
 }
 
@@ -86,6 +95,7 @@ func validAttestations(ctx context.Context, ref name.Reference, checkOpts *cosig
 
 	checkOpts.ClaimVerifier = cosign.IntotoSubjectClaimVerifier
 	attestations, _, err := cosignVerifyAttestations(ctx, ref, checkOpts)
+	recordFetchError(ctx, artifactAttestation, err)
 	return attestations, err
 }
 
@@ -25,8 +25,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
@@ -40,8 +42,14 @@ import (
 	"github.com/sigstore/cosign/v3/pkg/policy"
 	"github.com/sigstore/policy-controller/pkg/apis/config"
 	policyduckv1beta1 "github.com/sigstore/policy-controller/pkg/apis/duck/v1beta1"
+	"github.com/sigstore/policy-controller/pkg/apis/policy/common"
+	policycel "github.com/sigstore/policy-controller/pkg/cel"
+	"github.com/sigstore/policy-controller/pkg/certificate"
 	policycontrollerconfig "github.com/sigstore/policy-controller/pkg/config"
+	"github.com/sigstore/policy-controller/pkg/slsa"
+	"github.com/sigstore/policy-controller/pkg/tracing"
 	pctuf "github.com/sigstore/policy-controller/pkg/tuf"
+	"github.com/sigstore/policy-controller/pkg/vulnerability"
 	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
 	"github.com/sigstore/policy-controller/pkg/webhook/registryauth"
 	rekor "github.com/sigstore/rekor/pkg/client"
@@ -51,7 +59,9 @@ import (
 	"github.com/sigstore/sigstore/pkg/fulcioroots"
 	"github.com/sigstore/sigstore/pkg/signature"
 	"github.com/sigstore/sigstore/pkg/tuf"
+	"go.opentelemetry.io/otel/trace"
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
@@ -257,11 +267,29 @@ func (v *Validator) ValidateCronJob(ctx context.Context, c *duckv1.CronJob) *api
 }
 
 func (v *Validator) validatePodSpec(ctx context.Context, namespace, kind, apiVersion string, labels map[string]string, ps *corev1.PodSpec, opt k8schain.Options) (errs *apis.FieldError) {
+	ctx, decision := startDecision(ctx, namespace, kind, apiVersion, ps)
+	defer func() {
+		decision.finish(ctx, errs)
+	}()
+	ctx, span := tracing.Start(ctx, "validatePodSpec", tracing.NamespaceKey.String(namespace), tracing.KindKey.String(kind))
+	defer func() {
+		// Only errors fail the span, not warnings.
+		var err error
+		if fe := errs.Filter(apis.ErrorLevel); fe != nil {
+			err = fe
+		}
+		tracing.End(span, err)
+	}()
+
 	kc, err := registryauth.NewK8sKeychain(ctx, kubeclient.Get(ctx), opt)
 	if err != nil {
 		logging.FromContext(ctx).Warnf("Unable to build k8schain: %v", err)
 		return apis.ErrGeneric(err.Error(), apis.CurrentField)
 	}
+	// Results are only reused for images fetched with the same credentials.
+	ctx = withKeychainIdentity(ctx, opt)
+	// Who is making the request, which is nil outside of admission.
+	userInfo := apis.GetUserInfo(ctx)
 
 	type containerCheckResult struct {
 		index                int
@@ -285,9 +313,11 @@ func (v *Validator) validatePodSpec(ctx context.Context, namespace, kind, apiVer
 					return
 				}
 
-				containerErrors := v.validateContainerImage(ctx, c.Image, namespace, field, i, kind, apiVersion, labels, kc, ociremote.WithRemoteOptions(
+				containerErrors := v.validateContainerImage(ctx, c.Image, namespace, field, i, kind, apiVersion, labels, userInfo, kc, ociremote.WithRemoteOptions(
 					remote.WithContext(ctx),
 					remote.WithAuthFromKeychain(kc),
+					// Records the registry round trips in the trace.
+					remote.WithTransport(tracing.Transport(remote.DefaultTransport)),
 				))
 				results <- containerCheckResult{index: i, containerCheckResult: containerErrors}
 			}()
@@ -325,9 +355,10 @@ func (v *Validator) validatePodSpec(ctx context.Context, namespace, kind, apiVer
 					return
 				}
 
-				containerErrors := v.validateContainerImage(ctx, c.Image, namespace, field, i, kind, apiVersion, labels, kc, ociremote.WithRemoteOptions(
+				containerErrors := v.validateContainerImage(ctx, c.Image, namespace, field, i, kind, apiVersion, labels, userInfo, kc, ociremote.WithRemoteOptions(
 					remote.WithContext(ctx),
 					remote.WithAuthFromKeychain(kc),
+					remote.WithTransport(tracing.Transport(remote.DefaultTransport)),
 				))
 				results <- containerCheckResult{index: i, containerCheckResult: containerErrors}
 			}()
@@ -368,9 +399,10 @@ func (v *Validator) validatePodSpec(ctx context.Context, namespace, kind, apiVer
 					return
 				}
 
-				containerErrors := v.validateContainerImage(ctx, ref, namespace, "volumes", i, kind, apiVersion, labels, kc, ociremote.WithRemoteOptions(
+				containerErrors := v.validateContainerImage(ctx, ref, namespace, "volumes", i, kind, apiVersion, labels, userInfo, kc, ociremote.WithRemoteOptions(
 					remote.WithContext(ctx),
 					remote.WithAuthFromKeychain(kc),
+					remote.WithTransport(tracing.Transport(remote.DefaultTransport)),
 				))
 				results <- containerCheckResult{index: i, containerCheckResult: containerErrors}
 			}()
@@ -437,6 +469,9 @@ func setNoMatchingPoliciesError(ctx context.Context, image, field string, index
 // reasonable that the return value is 0, nil since there were no errors, but
 // the image was not validated against any matching policy and hence authority.
 func validatePolicies(ctx context.Context, namespace string, ref name.Reference, policies map[string]webhookcip.ClusterImagePolicy, kc authn.Keychain, remoteOpts ...ociremote.Option) (map[string]*PolicyResult, map[string][]error) {
+	ctx, span := tracing.Start(ctx, "validatePolicies", tracing.ImageKey.String(ref.Name()), tracing.PoliciesKey.Int(len(policies)))
+	defer span.End()
+
 	type retChannelType struct {
 		name         string
 		policyResult *PolicyResult
@@ -463,12 +498,22 @@ func validatePolicies(ctx context.Context, namespace string, ref name.Reference,
 			defer wg.Done()
 			result := retChannelType{name: cipName}
 
-			result.policyResult, result.errors = ValidatePolicy(ctx, namespace, ref, cip, kc, remoteOpts...)
-			// Cache the result.
-			FromContext(ctx).Set(ctx, ref.Name(), cipName, string(cip.UID), cip.ResourceVersion, &CacheResult{
-				PolicyResult: result.policyResult,
-				Errors:       result.errors,
-			})
+			ctx, span := tracing.Start(ctx, "ValidatePolicy", tracing.ImageKey.String(ref.Name()), tracing.PolicyKey.String(cipName))
+			var cached bool
+			result.policyResult, result.errors, cached = validatePolicyCached(ctx, namespace, ref, cip, kc, remoteOpts...)
+			var err error
+			if result.policyResult == nil {
+				err = errors.Join(result.errors...)
+			}
+			tracing.End(span, err)
+			// Cache the result, unless it came from the cache, so that it is
+			// only cached for the TTL from when it was computed.
+			if key, ok := resultKey(ctx, ref); ok && !cached && isCacheable(cip) {
+				FromContext(ctx).Set(ctx, key, cipName, string(cip.UID), cip.ResourceVersion, &CacheResult{
+					PolicyResult: result.policyResult,
+					Errors:       result.errors,
+				})
+			}
 			results <- result
 		}()
 	}
@@ -500,12 +545,59 @@ func validatePolicies(ctx context.Context, namespace string, ref name.Reference,
 			default:
 				ret[result.name] = append(ret[result.name], fmt.Errorf("failed to process policy: %s", result.name))
 			}
+			recordAdmissionDecision(ctx, result.name, policies[result.name], result.policyResult != nil)
 		}
 	}
 	wg.Wait()
 	return policyResults, ret
 }
 
+// attestationPayloadJSON returns the payload of the attestation if it has the
+// predicate type the wanted attestation asks for, see
+// policy.AttestationToPayloadJSON. SLSA checks look at any version of the
+// SLSA provenance, so that picking the wrong one does not silently match no
+// attestations.
+func attestationPayloadJSON(ctx context.Context, wantedAttestation webhookcip.AttestationPolicy, va policy.PayloadProvider) ([]byte, string, error) {
+	if wantedAttestation.SLSA == nil {
+		return policy.AttestationToPayloadJSON(ctx, wantedAttestation.PredicateType, va)
+	}
+	var gotPredicateType string
+	for _, predicateType := range []string{slsa.PredicateTypeV02, slsa.PredicateTypeV1} {
+		attBytes, got, err := policy.AttestationToPayloadJSON(ctx, predicateType, va)
+		if err != nil || attBytes != nil {
+			return attBytes, got, err
+		}
+		gotPredicateType = got
+	}
+	return nil, gotPredicateType, nil
+}
+
+// isCacheable returns true if the result of evaluating the given CIP only
+// depends on the image being evaluated. If the CIP level policy looks at the
+// resource being admitted, or the signatures are fetched with namespace
+// specific pull secrets, the result can not be reused across admissions.
+func isCacheable(cip webhookcip.ClusterImagePolicy) bool {
+	if cip.Policy != nil {
+		if cip.Policy.IncludeSpec != nil && *cip.Policy.IncludeSpec {
+			return false
+		}
+		if cip.Policy.IncludeObjectMeta != nil && *cip.Policy.IncludeObjectMeta {
+			return false
+		}
+		if cip.Policy.IncludeTypeMeta != nil && *cip.Policy.IncludeTypeMeta {
+			return false
+		}
+	}
+	for _, authority := range cip.Authorities {
+		for _, source := range authority.Sources {
+			if len(source.SignaturePullSecrets) > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func asFieldError(warn bool, err error) *apis.FieldError {
 	r := &apis.FieldError{Message: err.Error()}
 	if warn {
@@ -515,20 +607,49 @@ func asFieldError(warn bool, err error) *apis.FieldError {
 }
 
 // ValidatePolicy will go through all the Authorities for a given image/policy
-// and return validated authorities if at least one of the Authorities
-// validated the signatures OR attestations if atttestations were specified.
+// and return validated authorities if at least one of the Authorities (or
+// cip.AuthorityThreshold of them if set) validated the signatures OR
+// attestations if atttestations were specified.
 // Returns PolicyResult if one or more authorities matched, otherwise nil.
 // In any case returns all errors encountered if none of the authorities
 // passed.
 // kc is the Keychain to use for fetching ConfigFile that's independent of the
 // signatures / attestations.
 func ValidatePolicy(ctx context.Context, namespace string, ref name.Reference, cip webhookcip.ClusterImagePolicy, kc authn.Keychain, remoteOpts ...ociremote.Option) (*PolicyResult, []error) {
+	policyResult, errs, _ := validatePolicyCached(ctx, namespace, ref, cip, kc, remoteOpts...)
+	return policyResult, errs
+}
+
+// validatePolicyCached is ValidatePolicy, and also returns whether the result
+// came from the cache.
+func validatePolicyCached(ctx context.Context, namespace string, ref name.Reference, cip webhookcip.ClusterImagePolicy, kc authn.Keychain, remoteOpts ...ociremote.Option) (*PolicyResult, []error, bool) {
 	// Check the cache and return if hit, otherwise, check the policy
-	cacheResult := FromContext(ctx).Get(ctx, ref.String(), string(cip.UID), cip.ResourceVersion)
-	if cacheResult != nil {
-		return cacheResult.PolicyResult, cacheResult.Errors
-	}
+	if key, ok := resultKey(ctx, ref); ok && isCacheable(cip) {
+		cacheResult := FromContext(ctx).Get(ctx, key, string(cip.UID), cip.ResourceVersion)
+		trace.SpanFromContext(ctx).SetAttributes(tracing.CacheHitKey.Bool(cacheResult != nil))
+		if cacheResult != nil {
+			return cacheResult.PolicyResult, cacheResult.Errors, true
+		}
+	}
+
+	// Concurrent evaluations of the same policy against the same image
+	// share a single evaluation.
+	if key, ok := inflightKey(ctx, ref, cip); ok {
+		policyResult, errs, shared := inflight.do(ctx, key, func(ctx context.Context) (*PolicyResult, []error) {
+			// The registry requests must not be canceled along with the
+			// admission request that started the evaluation either.
+			remoteOpts := append(slices.Clone(remoteOpts), ociremote.WithMoreRemoteOptions(remote.WithContext(ctx)))
+			return validatePolicy(ctx, namespace, ref, cip, kc, remoteOpts...)
+		})
+		trace.SpanFromContext(ctx).SetAttributes(tracing.SharedKey.Bool(shared))
+		return policyResult, errs, false
+	}
+	policyResult, errs := validatePolicy(ctx, namespace, ref, cip, kc, remoteOpts...)
+	return policyResult, errs, false
+}
 
+// validatePolicy evaluates cip against ref, see ValidatePolicy.
+func validatePolicy(ctx context.Context, namespace string, ref name.Reference, cip webhookcip.ClusterImagePolicy, kc authn.Keychain, remoteOpts ...ociremote.Option) (*PolicyResult, []error) {
 	// Each gofunc creates and puts one of these into a results channel.
 	// Once each gofunc finishes, we go through the channel and pull out
 	// the results.
@@ -539,6 +660,16 @@ func ValidatePolicy(ctx context.Context, namespace string, ref name.Reference, c
 		signatures   []PolicySignature
 		err          error
 	}
+	// When asked to, the platform manifests of an index must satisfy the
+	// Authorities as well as the index itself.
+	var platforms []platformManifest
+	if cip.VerifyPlatforms != nil {
+		var err error
+		platforms, err = getPlatformManifests(ref, cip.VerifyPlatforms.Platforms, registryRemoteOptions(ctx, kc)...)
+		if err != nil {
+			return nil, []error{asFieldError(cip.Mode == "warn", err)}
+		}
+	}
 	wg := new(sync.WaitGroup)
 
 	results := make(chan retChannelType, len(cip.Authorities))
@@ -549,7 +680,12 @@ func ValidatePolicy(ctx context.Context, namespace string, ref name.Reference, c
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			defer recordAuthorityVerificationLatency(ctx, authority, time.Now())
 			result := retChannelType{name: authority.Name}
+			ctx, span := tracing.Start(ctx, "authority", tracing.AuthorityKey.String(authority.Name), tracing.AuthorityTypeKey.String(authorityType(authority)))
+			defer func() {
+				tracing.End(span, result.err)
+			}()
 			// Assignment for appendAssign lint error
 			authorityRemoteOpts := remoteOpts
 			authorityRemoteOpts = append(authorityRemoteOpts, authority.RemoteOpts...)
@@ -578,6 +714,19 @@ func ValidatePolicy(ctx context.Context, namespace string, ref name.Reference, c
 			default:
 				result.signatures, result.err = ValidatePolicySignaturesForAuthority(ctx, ref, authority, authorityRemoteOpts...)
 			}
+			if result.err == nil && !result.static {
+				for _, platform := range platforms {
+					if len(authority.Attestations) > 0 && cip.VerifyPlatforms.Attestations {
+						_, err = ValidatePolicyAttestationsForAuthority(ctx, platform.ref, authority, authorityRemoteOpts...)
+					} else {
+						_, err = ValidatePolicySignaturesForAuthority(ctx, platform.ref, authority, authorityRemoteOpts...)
+					}
+					if err != nil {
+						result.err = fmt.Errorf("platform %s (%s): %w", platform.platform, platform.ref.DigestStr(), err)
+						break
+					}
+				}
+			}
 			results <- result
 		}()
 	}
@@ -606,7 +755,10 @@ func ValidatePolicy(ctx context.Context, namespace string, ref name.Reference, c
 				// We only wrap actual policy failures as FieldErrors with the
 				// possibly Warn level. Other things imho should be still
 				// be considered errors.
-				authorityErrors = append(authorityErrors, asFieldError(cip.Mode == "warn", result.err))
+				authorityErrors = append(authorityErrors, &AuthorityError{
+					Authority: result.name,
+					Err:       asFieldError(cip.Mode == "warn", result.err),
+				})
 
 			case len(result.signatures) > 0:
 				policyResult.AuthorityMatches[result.name] = AuthorityMatch{Signatures: result.signatures}
@@ -629,13 +781,18 @@ func ValidatePolicy(ctx context.Context, namespace string, ref name.Reference, c
 	}
 	wg.Wait()
 	// Even if there are errors, return the policies, since as per the
-	// spec, we just need one authority to pass checks. If more than
-	// one are required, that is enforced at the CIP policy level.
+	// spec, we just need one authority to pass checks (or
+	// AuthorityThreshold of them if specified). Anything more elaborate
+	// than that is enforced at the CIP policy level.
 	// If however there are no authorityMatches, return nil so we don't have
 	// to keep checking the length on the returned calls.
 	if len(policyResult.AuthorityMatches) == 0 {
 		return nil, authorityErrors
 	}
+	if threshold := int(cip.AuthorityThreshold); len(policyResult.AuthorityMatches) < threshold {
+		err := fmt.Errorf("%d of the required %d authorities matched", len(policyResult.AuthorityMatches), threshold)
+		return nil, append(authorityErrors, asFieldError(cip.Mode == "warn", err))
+	}
 	// Ok, there's at least one valid authority that matched. If there's a CIP
 	// level policy, validate it here before returning.
 	if cip.Policy != nil {
@@ -645,11 +802,7 @@ func ValidatePolicy(ctx context.Context, namespace string, ref name.Reference, c
 			// would be nice if we could just unwrap/generate the ggcr remote
 			// options from the oci remote options, but for now this is how
 			// we're rolling.
-			rOpts := []remote.Option{
-				remote.WithContext(ctx),
-				remote.WithAuthFromKeychain(kc),
-			}
-			configFiles, errs := getConfigs(ctx, ref, rOpts...)
+			configFiles, errs := getConfigs(ctx, ref, registryRemoteOptions(ctx, kc)...)
 			if len(errs) > 0 {
 				for _, e := range errs {
 					authorityErrors = append(authorityErrors, asFieldError(cip.Mode == "warn", e))
@@ -667,26 +820,75 @@ func ValidatePolicy(ctx context.Context, namespace string, ref name.Reference, c
 		if cip.Policy.IncludeTypeMeta != nil && *cip.Policy.IncludeTypeMeta {
 			policyResult.TypeMeta = GetIncludeTypeMeta(ctx)
 		}
+		// The predicates are only added to the document the policy is
+		// evaluated against, not to the result that is cached and reported.
+		evaluated := policyResult
+		if cip.Policy.IncludeAttestationPayloads != nil && *cip.Policy.IncludeAttestationPayloads {
+			evaluated = withAttestationPayloads(ctx, policyResult)
+		}
 
 		logging.FromContext(ctx).Info("Validating CIP level policy")
-		policyJSON, err := json.Marshal(policyResult)
+		policyJSON, err := json.Marshal(evaluated)
 		if err != nil {
 			return nil, append(authorityErrors, err)
 		}
-		logging.FromContext(ctx).Infof("CIP level policy: %s", string(policyJSON))
-		warn, err := policy.EvaluatePolicyAgainstJSON(ctx, "ClusterImagePolicy", cip.Policy.Type, cip.Policy.Data, policyJSON)
+		// The document can hold the attestation predicates, like whole SBOMs,
+		// so it is only logged at debug level.
+		logging.FromContext(ctx).Debugf("CIP level policy: %s", string(policyJSON))
+		warn, err := evaluatePolicy(ctx, "ClusterImagePolicy", cip.Policy.Type, cip.Policy.Data, policyJSON)
 		if err != nil {
-			logging.FromContext(ctx).Warnf("Failed to validate CIP level policy; err: %w; against %s", err, string(policyJSON))
+			logging.FromContext(ctx).Warnf("Failed to validate CIP level policy; err: %v", err)
 			return nil, append(authorityErrors, asFieldError(cip.Mode == "warn", err))
 		}
 		if warn != nil {
-			logging.FromContext(ctx).Warnf("Failed to validate CIP level policy; warn: %w; against %s", warn, string(policyJSON))
+			logging.FromContext(ctx).Warnf("Failed to validate CIP level policy; warn: %v", warn)
 			return nil, append(authorityErrors, asFieldError(cip.Mode == "warn", warn))
 		}
 	}
 	return policyResult, authorityErrors
 }
 
+// withAttestationPayloads returns a copy of policyResult with the predicates
+// of all the attestations filled in, to make them available to the CIP level
+// policy. policyResult itself is not modified.
+func withAttestationPayloads(ctx context.Context, policyResult *PolicyResult) *PolicyResult {
+	ret := *policyResult
+	ret.AuthorityMatches = make(map[string]AuthorityMatch, len(policyResult.AuthorityMatches))
+	for authorityName, authorityMatch := range policyResult.AuthorityMatches {
+		if authorityMatch.Attestations != nil {
+			withPredicates := make(map[string][]PolicyAttestation, len(authorityMatch.Attestations))
+			for attestationName, attestations := range authorityMatch.Attestations {
+				atts := slices.Clone(attestations)
+				for i := range atts {
+					var statement struct {
+						Predicate json.RawMessage `json:"predicate"`
+					}
+					if err := json.Unmarshal(atts[i].Payload, &statement); err != nil {
+						logging.FromContext(ctx).Warnf("Failed to decode the payload of attestation %s of authority %s: %v", attestationName, authorityName, err)
+						continue
+					}
+					atts[i].Predicate = statement.Predicate
+				}
+				withPredicates[attestationName] = atts
+			}
+			authorityMatch.Attestations = withPredicates
+		}
+		ret.AuthorityMatches[authorityName] = authorityMatch
+	}
+	return &ret
+}
+
+// evaluatePolicy evaluates the policy of the given type against the JSON
+// document. Like cosign's policy.EvaluatePolicyAgainstJSON, which evaluates
+// the cue and rego policies, it returns a non nil warn if the policy only
+// produced warnings.
+func evaluatePolicy(ctx context.Context, name, policyType, policyBody string, jsonBytes []byte) (warn error, err error) {
+	if policyType == policycel.PolicyType {
+		return nil, policycel.Evaluate(ctx, name, policyBody, jsonBytes)
+	}
+	return policy.EvaluatePolicyAgainstJSON(ctx, name, policyType, policyBody, jsonBytes)
+}
+
 func ociSignatureToPolicySignature(ctx context.Context, sigs []oci.Signature) []PolicySignature {
 	ret := make([]PolicySignature, 0, len(sigs))
 	for _, ociSig := range sigs {
@@ -706,6 +908,10 @@ func ociSignatureToPolicySignature(ctx context.Context, sigs []oci.Signature) []
 			if sans := cryptoutils.GetSubjectAlternateNames(cert); len(sans) > 0 {
 				sub = sans[0]
 			}
+			fulcioExts, err := fulcioExtensions(cert)
+			if err != nil {
+				logging.FromContext(ctx).Debugf("Error parsing certificate extensions %+v", err)
+			}
 			ret = append(ret, PolicySignature{
 				ID:      sigID,
 				Subject: sub,
@@ -717,6 +923,7 @@ func ociSignatureToPolicySignature(ctx context.Context, sigs []oci.Signature) []
 					WorkflowRepo:    ce.GetCertExtensionGithubWorkflowRepository(),
 					WorkflowRef:     ce.GetCertExtensionGithubWorkflowRef(),
 				},
+				FulcioExtensions: fulcioExts,
 			})
 		} else {
 			ret = append(ret, PolicySignature{
@@ -787,6 +994,10 @@ func attestationToPolicyAttestations(ctx context.Context, atts []attestation) []
 			if sans := cryptoutils.GetSubjectAlternateNames(cert); len(sans) > 0 {
 				sub = sans[0]
 			}
+			fulcioExts, err := fulcioExtensions(cert)
+			if err != nil {
+				logging.FromContext(ctx).Debugf("Error parsing certificate extensions %+v", err)
+			}
 			ret = append(ret, PolicyAttestation{
 				PolicySignature: PolicySignature{
 					ID:      sigID,
@@ -799,6 +1010,7 @@ func attestationToPolicyAttestations(ctx context.Context, atts []attestation) []
 						WorkflowRepo:    ce.GetCertExtensionGithubWorkflowRepository(),
 						WorkflowRef:     ce.GetCertExtensionGithubWorkflowRef(),
 					},
+					FulcioExtensions: fulcioExts,
 				},
 				Digest:        att.Digest,
 				PredicateType: att.PredicateType,
@@ -821,9 +1033,23 @@ func attestationToPolicyAttestations(ctx context.Context, atts []attestation) []
 
 // ValidatePolicySignaturesForAuthority takes the Authority and tries to
 // verify a signature against it.
-func ValidatePolicySignaturesForAuthority(ctx context.Context, ref name.Reference, authority webhookcip.Authority, remoteOpts ...ociremote.Option) ([]PolicySignature, error) {
+func ValidatePolicySignaturesForAuthority(ctx context.Context, ref name.Reference, authority webhookcip.Authority, remoteOpts ...ociremote.Option) (_ []PolicySignature, err error) {
+	ctx, span := tracing.Start(ctx, "ValidatePolicySignaturesForAuthority", tracing.ImageKey.String(ref.Name()), tracing.AuthorityKey.String(authority.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
 	name := authority.Name
 
+	// Notary Project signatures are not verified by cosign.
+	if authority.Notation != nil {
+		sps, err := validNotationSignatures(ctx, ref, authority.Notation, remoteOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("signature notation validation failed for authority %s for %s: %w", name, ref.Name(), err)
+		}
+		logging.FromContext(ctx).Debugf("validated notation signature for %s, got %d signatures", ref.Name(), len(sps))
+		return sps, nil
+	}
+
 	checkOpts, err := checkOptsFromAuthority(ctx, authority, remoteOpts...)
 	if err != nil {
 		logging.FromContext(ctx).Errorf("failed constructing checkOpts for %s: +v", name, err)
@@ -842,6 +1068,10 @@ func ValidatePolicySignaturesForAuthority(ctx context.Context, ref name.Referenc
 		if err != nil {
 			return nil, fmt.Errorf("signature key validation failed for authority %s for %s: %w", name, ref.Name(), err)
 		}
+		sps, err = filterByKeyValidity(sps, authority.Key, checkOpts)
+		if err != nil {
+			return nil, fmt.Errorf("signature key validation failed for authority %s for %s: %w", name, ref.Name(), err)
+		}
 		logging.FromContext(ctx).Debugf("validated signature for %s for authority %s got %d signatures", ref.Name(), authority.Name, len(sps))
 		return ociSignatureToPolicySignature(ctx, sps), nil
 
@@ -852,16 +1082,44 @@ func ValidatePolicySignaturesForAuthority(ctx context.Context, ref name.Referenc
 				logging.FromContext(ctx).Errorf("failed validSignatures for authority %s with fulcio for %s: %v", name, ref.Name(), err)
 				return nil, fmt.Errorf("signature keyless validation failed for authority %s for %s: %w", name, ref.Name(), err)
 			}
+			sps, err = filterByExtensions(sps, authority.Keyless.Identities)
+			if err != nil {
+				return nil, fmt.Errorf("signature keyless validation failed for authority %s for %s: %w", name, ref.Name(), err)
+			}
+			sps, err = filterRevoked(ctx, sps, checkOpts)
+			if err != nil {
+				return nil, fmt.Errorf("signature keyless validation failed for authority %s for %s: %w", name, ref.Name(), err)
+			}
 			logging.FromContext(ctx).Debugf("validated signature for %s, got %d signatures", ref.Name(), len(sps))
 			return ociSignatureToPolicySignature(ctx, sps), nil
 		}
 		return nil, fmt.Errorf("no Keyless URL specified")
+	case authority.Certificate != nil:
+		sps, err := validSignatures(ctx, ref, checkOpts)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("failed validSignatures for authority %s with certificate for %s: %v", name, ref.Name(), err)
+			return nil, fmt.Errorf("signature certificate validation failed for authority %s for %s: %w", name, ref.Name(), err)
+		}
+		sps, err = filterByCertificateAuthority(ctx, sps, authority.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("signature certificate validation failed for authority %s for %s: %w", name, ref.Name(), err)
+		}
+		sps, err = filterRevoked(ctx, sps, checkOpts)
+		if err != nil {
+			return nil, fmt.Errorf("signature certificate validation failed for authority %s for %s: %w", name, ref.Name(), err)
+		}
+		logging.FromContext(ctx).Debugf("validated signature for %s, got %d signatures", ref.Name(), len(sps))
+		return ociSignatureToPolicySignature(ctx, sps), nil
 	case authority.RFC3161Timestamp != nil:
 		sps, err := validSignatures(ctx, ref, checkOpts)
 		if err != nil {
 			logging.FromContext(ctx).Errorf("failed validSignatures for authority %s with fulcio for %s: %v", name, ref.Name(), err)
 			return nil, fmt.Errorf("signature TSA validation failed for authority %s for %s: %w", name, ref.Name(), err)
 		}
+		sps, err = filterRevoked(ctx, sps, checkOpts)
+		if err != nil {
+			return nil, fmt.Errorf("signature TSA validation failed for authority %s for %s: %w", name, ref.Name(), err)
+		}
 		logging.FromContext(ctx).Debugf("validated TSA signature for %s, got %d signatures", ref.Name(), len(sps))
 		return ociSignatureToPolicySignature(ctx, sps), nil
 	}
@@ -873,7 +1131,11 @@ func ValidatePolicySignaturesForAuthority(ctx context.Context, ref name.Referenc
 
 // ValidatePolicyAttestationsForAuthority takes the Authority and tries to
 // verify attestations against it.
-func ValidatePolicyAttestationsForAuthority(ctx context.Context, ref name.Reference, authority webhookcip.Authority, remoteOpts ...ociremote.Option) (map[string][]PolicyAttestation, error) {
+func ValidatePolicyAttestationsForAuthority(ctx context.Context, ref name.Reference, authority webhookcip.Authority, remoteOpts ...ociremote.Option) (_ map[string][]PolicyAttestation, err error) {
+	ctx, span := tracing.Start(ctx, "ValidatePolicyAttestationsForAuthority", tracing.ImageKey.String(ref.Name()), tracing.AuthorityKey.String(authority.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
 	name := authority.Name
 	checkOpts, err := checkOptsFromAuthority(ctx, authority, remoteOpts...)
 	if err != nil {
@@ -896,6 +1158,14 @@ func ValidatePolicyAttestationsForAuthority(ctx context.Context, ref name.Refere
 				logging.FromContext(ctx).Errorf("error validating attestations: %v", err)
 				return nil, fmt.Errorf("attestation key validation failed for authority %s for %s: %w", name, ref.Name(), err)
 			}
+			va, err = filterByKeyValidity(va, authority.Key, checkOpts)
+			if err != nil {
+				return nil, fmt.Errorf("attestation key validation failed for authority %s for %s: %w", name, ref.Name(), err)
+			}
+			va, err = filterRevoked(ctx, va, checkOpts)
+			if err != nil {
+				return nil, fmt.Errorf("attestation key validation failed for authority %s for %s: %w", name, ref.Name(), err)
+			}
 			verifiedAttestations = append(verifiedAttestations, va...)
 		}
 
@@ -906,14 +1176,41 @@ func ValidatePolicyAttestationsForAuthority(ctx context.Context, ref name.Refere
 				logging.FromContext(ctx).Errorf("failed validAttestationsWithFulcio for authority %s with fulcio for %s: %v", name, ref.Name(), err)
 				return nil, fmt.Errorf("attestation keyless validation failed for authority %s for %s: %w", name, ref.Name(), err)
 			}
+			va, err = filterByExtensions(va, authority.Keyless.Identities)
+			if err != nil {
+				return nil, fmt.Errorf("attestation keyless validation failed for authority %s for %s: %w", name, ref.Name(), err)
+			}
+			va, err = filterRevoked(ctx, va, checkOpts)
+			if err != nil {
+				return nil, fmt.Errorf("attestation keyless validation failed for authority %s for %s: %w", name, ref.Name(), err)
+			}
 			verifiedAttestations = append(verifiedAttestations, va...)
 		}
+	case authority.Certificate != nil:
+		va, err := validAttestations(ctx, ref, checkOpts)
+		if err != nil {
+			logging.FromContext(ctx).Errorf("failed validAttestations for authority %s with certificate for %s: %v", name, ref.Name(), err)
+			return nil, fmt.Errorf("attestation certificate validation failed for authority %s for %s: %w", name, ref.Name(), err)
+		}
+		va, err = filterByCertificateAuthority(ctx, va, authority.Certificate)
+		if err != nil {
+			return nil, fmt.Errorf("attestation certificate validation failed for authority %s for %s: %w", name, ref.Name(), err)
+		}
+		va, err = filterRevoked(ctx, va, checkOpts)
+		if err != nil {
+			return nil, fmt.Errorf("attestation certificate validation failed for authority %s for %s: %w", name, ref.Name(), err)
+		}
+		verifiedAttestations = append(verifiedAttestations, va...)
 	case authority.RFC3161Timestamp != nil:
 		va, err := validAttestations(ctx, ref, checkOpts)
 		if err != nil {
 			logging.FromContext(ctx).Errorf("failed validAttestations for authority %s with fulcio for %s: %v", name, ref.Name(), err)
 			return nil, fmt.Errorf("signature TSA validAttestations failed for authority %s for %s: %w", name, ref.Name(), err)
 		}
+		va, err = filterRevoked(ctx, va, checkOpts)
+		if err != nil {
+			return nil, fmt.Errorf("signature TSA validAttestations failed for authority %s for %s: %w", name, ref.Name(), err)
+		}
 		logging.FromContext(ctx).Debugf("validated TSA signature for %s, got %d signatures", ref.Name(), len(va))
 		verifiedAttestations = append(verifiedAttestations, va...)
 	}
@@ -939,12 +1236,28 @@ func ValidatePolicyAttestationsForAuthority(ctx context.Context, ref name.Refere
 	// We keep these in the map since there can be duplicates, so just use
 	// map as uniquifier.
 	checkedPredicateTypes := map[string]struct{}{}
+
+	// Only look for VEX statements if there is a vulnerability scan to
+	// apply them to.
+	var notAffected []string
+	for _, wantedAttestation := range authority.Attestations {
+		if wantedAttestation.Vulnerability != nil {
+			notAffected = notAffectedVulnerabilities(ctx, ref, verifiedAttestations)
+			break
+		}
+	}
 	for _, wantedAttestation := range authority.Attestations {
 		// Since there can be multiple verified attestations that matched, for
 		// example multiple 'custom' attestations. We keep the first error that
 		// we encounter here but do not exit on it, in case another attestation
 		// satisfies the policy.
 		var reterror error
+		// Only the most recent vulnerability scan is checked, otherwise an
+		// older scan could satisfy the policy when a newer one does not.
+		var latestScan string
+		if wantedAttestation.Vulnerability != nil {
+			latestScan = latestVulnerabilityScan(ctx, wantedAttestation.PredicateType, verifiedAttestations)
+		}
 		// There's a particular type, so we need to go through all the verified
 		// attestations and make sure that our particular one is satisfied.
 		checkedAttestations := make([]attestation, 0, len(verifiedAttestations))
@@ -954,7 +1267,7 @@ func ValidatePolicyAttestationsForAuthority(ctx context.Context, ref name.Refere
 				logging.FromContext(ctx).Errorf("failed to get the attestation digest for %s: %v", wantedAttestation.Name, err)
 				continue
 			}
-			attBytes, gotPredicateType, err := policy.AttestationToPayloadJSON(ctx, wantedAttestation.PredicateType, va)
+			attBytes, gotPredicateType, err := attestationPayloadJSON(ctx, wantedAttestation, va)
 			if gotPredicateType != "" {
 				checkedPredicateTypes[gotPredicateType] = struct{}{}
 			}
@@ -971,8 +1284,32 @@ func ValidatePolicyAttestationsForAuthority(ctx context.Context, ref name.Refere
 				// attestation is not for. It's not an error, so we skip it.
 				continue
 			}
+			if wantedAttestation.SLSA != nil {
+				if err := slsa.Verify(wantedAttestation.SLSA, attBytes); err != nil {
+					if reterror == nil {
+						// Only stash the first error
+						reterror = fmt.Errorf("failed SLSA validation for %s: %w", wantedAttestation.Name, err)
+					}
+					logging.FromContext(ctx).Warnf("failed SLSA validation for %s: %v", wantedAttestation.Name, err)
+					continue
+				}
+			}
+			if wantedAttestation.Vulnerability != nil {
+				if attDigest.String() != latestScan {
+					logging.FromContext(ctx).Debugf("skipping vulnerability scan %s for %s, it is not the most recent one", attDigest.String(), wantedAttestation.Name)
+					continue
+				}
+				if err := vulnerability.Verify(wantedAttestation.Vulnerability, attBytes, notAffected, time.Now()); err != nil {
+					if reterror == nil {
+						// Only stash the first error
+						reterror = fmt.Errorf("failed vulnerability validation for %s: %w", wantedAttestation.Name, err)
+					}
+					logging.FromContext(ctx).Warnf("failed vulnerability validation for %s: %v", wantedAttestation.Name, err)
+					continue
+				}
+			}
 			if wantedAttestation.Type != "" {
-				if warn, err := policy.EvaluatePolicyAgainstJSON(ctx, wantedAttestation.Name, wantedAttestation.Type, wantedAttestation.Data, attBytes); err != nil || warn != nil {
+				if warn, err := evaluatePolicy(ctx, wantedAttestation.Name, wantedAttestation.Type, wantedAttestation.Data, attBytes); err != nil || warn != nil {
 					if reterror == nil {
 						// Only stash the first error
 						reterror = err
@@ -988,9 +1325,13 @@ func ValidatePolicyAttestationsForAuthority(ctx context.Context, ref name.Refere
 			logging.FromContext(ctx).Debugf("found verified attestation with digest: %s\n", attDigest.String())
 			// Ok, so this passed aok, jot it down to our result set as
 			// verified attestation with the predicate type match
+			predicateType := wantedAttestation.PredicateType
+			if wantedAttestation.SLSA != nil {
+				predicateType = gotPredicateType
+			}
 			checkedAttestations = append(checkedAttestations, attestation{
 				Signature:     va,
-				PredicateType: wantedAttestation.PredicateType,
+				PredicateType: predicateType,
 				Payload:       attBytes,
 				Digest:        attDigest.String(),
 			})
@@ -1006,13 +1347,76 @@ func ValidatePolicyAttestationsForAuthority(ctx context.Context, ref name.Refere
 			for pt := range checkedPredicateTypes {
 				cpt = append(cpt, pt)
 			}
-			return nil, fmt.Errorf("%s with type %s, checked the following predicateTypes: %q", "no matching attestations", wantedAttestation.PredicateType, strings.Join(cpt, ","))
+			wantedPredicateType := wantedAttestation.PredicateType
+			if wantedAttestation.SLSA != nil {
+				wantedPredicateType = strings.Join([]string{slsa.PredicateTypeV02, slsa.PredicateTypeV1}, " or ")
+			}
+			return nil, fmt.Errorf("%s with type %s, checked the following predicateTypes: %q", "no matching attestations", wantedPredicateType, strings.Join(cpt, ","))
 		}
 		ret[wantedAttestation.Name] = attestationToPolicyAttestations(ctx, checkedAttestations)
 	}
 	return ret, nil
 }
 
+// latestVulnerabilityScan returns the digest of the most recent of the
+// verified attestations of the given predicate type, by when their scan was
+// run. Scans that do not record it are only picked if none does.
+func latestVulnerabilityScan(ctx context.Context, predicateType string, verifiedAttestations []oci.Signature) string {
+	var latest string
+	var latestScannedOn time.Time
+	for _, va := range verifiedAttestations {
+		attBytes, _, err := policy.AttestationToPayloadJSON(ctx, predicateType, va)
+		if err != nil || attBytes == nil {
+			continue
+		}
+		scannedOn, err := vulnerability.ScannedOn(attBytes)
+		if err != nil {
+			continue
+		}
+		attDigest, err := va.Digest()
+		if err != nil {
+			continue
+		}
+		if latest == "" || scannedOn.After(latestScannedOn) {
+			latest = attDigest.String()
+			latestScannedOn = scannedOn
+		}
+	}
+	return latest
+}
+
+// notAffectedVulnerabilities returns the vulnerabilities that the verified
+// OpenVEX attestations declare the image ref is not affected by. Only images
+// referenced by digest can be matched against the OpenVEX products.
+func notAffectedVulnerabilities(ctx context.Context, ref name.Reference, verifiedAttestations []oci.Signature) []string {
+	digest, ok := ref.(name.Digest)
+	if !ok {
+		return nil
+	}
+	var ret []string
+	for _, va := range verifiedAttestations {
+		// The OpenVEX predicate type is versioned, so find out which version
+		// the attestation has before asking for its payload.
+		attBytes, gotPredicateType, err := policy.AttestationToPayloadJSON(ctx, vulnerability.OpenVEXPredicateType, va)
+		if err != nil || !vulnerability.IsOpenVEX(gotPredicateType) {
+			continue
+		}
+		if attBytes == nil {
+			if attBytes, _, err = policy.AttestationToPayloadJSON(ctx, gotPredicateType, va); err != nil {
+				logging.FromContext(ctx).Warnf("failed to convert OpenVEX attestation payload to json: %v", err)
+				continue
+			}
+		}
+		ids, err := vulnerability.NotAffected(attBytes, digest.DigestStr())
+		if err != nil {
+			logging.FromContext(ctx).Warnf("failed to read OpenVEX attestation: %v", err)
+			continue
+		}
+		ret = append(ret, ids...)
+	}
+	return ret
+}
+
 // ResolvePodScalable implements policyduckv1beta1.PodScalableValidator
 func (v *Validator) ResolvePodScalable(ctx context.Context, ps *policyduckv1beta1.PodScalable) {
 	// Don't mess with things that are being deleted or already deleted or
@@ -1230,7 +1634,7 @@ func getNamespace(ctx context.Context, namespace string) string {
 // All the matched policies were validated, or
 // no matching policies were found, but the PolicyControllerConfig has been
 // configured to allow images not matching any policies.
-func (v *Validator) validateContainerImage(ctx context.Context, containerImage string, namespace, field string, index int, kind, apiVersion string, labels map[string]string, kc authn.Keychain, ociRemoteOpts ...ociremote.Option) *apis.FieldError {
+func (v *Validator) validateContainerImage(ctx context.Context, containerImage string, namespace, field string, index int, kind, apiVersion string, labels map[string]string, userInfo *authenticationv1.UserInfo, kc authn.Keychain, ociRemoteOpts ...ociremote.Option) *apis.FieldError {
 	ref, err := name.ParseReference(containerImage)
 	if err != nil {
 		return apis.ErrGeneric(err.Error(), "image").ViaFieldIndex(field, index)
@@ -1238,31 +1642,131 @@ func (v *Validator) validateContainerImage(ctx context.Context, containerImage s
 	config := config.FromContext(ctx)
 
 	if config != nil {
-		policies, err := config.ImagePolicyConfig.GetMatchingPolicies(ref.Name(), kind, apiVersion, labels)
+		// The labels of the namespace are only looked up when a policy
+		// needs them, so that the other policies do not depend on the
+		// namespace informer.
+		var namespaceLabels map[string]string
+		if config.ImagePolicyConfig.HasNamespaceSelector(namespace, ref.Name()) {
+			namespaceLabels, err = getNamespaceLabels(ctx, namespace)
+			if err != nil {
+				errorField := apis.ErrGeneric(err.Error(), "image").ViaFieldIndex(field, index)
+				errorField.Details = containerImage
+				return errorField
+			}
+		}
+		policies, err := config.ImagePolicyConfig.GetMatchingPolicies(ref.Name(), kind, apiVersion, labels, namespaceLabels, userInfo)
+		if err != nil {
+			errorField := apis.ErrGeneric(err.Error(), "image").ViaFieldIndex(field, index)
+			errorField.Details = containerImage
+			return errorField
+		}
+		// ImagePolicies from the namespace of the resource are evaluated in
+		// addition to the ClusterImagePolicies, and all of them have to be
+		// satisfied.
+		nsPolicies, err := config.ImagePolicyConfig.GetMatchingNamespacedPolicies(namespace, ref.Name(), kind, apiVersion, labels, namespaceLabels, userInfo)
 		if err != nil {
 			errorField := apis.ErrGeneric(err.Error(), "image").ViaFieldIndex(field, index)
 			errorField.Details = containerImage
 			return errorField
 		}
 
+		var errs *apis.FieldError
+		// Without a request, like when auditing running Pods, the policies
+		// that only apply to some subjects never match. Surface them, so
+		// that it is clear that they were not evaluated.
+		if userInfo == nil {
+			errs = errs.Also(subjectPolicyWarnings(ctx, config.ImagePolicyConfig, namespace, ref.Name(), containerImage, field, index, kind, apiVersion, labels, namespaceLabels))
+		}
+		// Only ClusterImagePolicies count towards the NoMatchPolicy, so that
+		// namespace tenants can not use an ImagePolicy to admit images that
+		// the cluster administrators have not covered with a policy.
+		if len(policies) == 0 {
+			errs = errs.Also(setNoMatchingPoliciesError(ctx, containerImage, field, index))
+			if errs != nil && errs.Filter(apis.ErrorLevel) != nil {
+				return errs
+			}
+		}
+		// PolicyExceptions can exempt the image from ClusterImagePolicies
+		// (but not from ImagePolicies). An image that is exempted from all the
+		// matching policies is admitted.
+		exempted, exemptionErrs := applyPolicyExceptions(ctx, config.PolicyExceptionsConfig, namespace, ref.Name(), containerImage, field, index, policies)
+		errs = errs.Also(exemptionErrs)
+		for k, v := range nsPolicies {
+			policies[k] = v
+		}
+
 		// If there is at least one policy that matches, that means it
 		// has to be satisfied.
 		if len(policies) > 0 {
 			signatures, fieldErrors := validatePolicies(ctx, namespace, ref, policies, kc, ociRemoteOpts...)
+			reportEvaluation(ctx, namespace, kind, apiVersion, containerImage, policies, exempted, signatures, fieldErrors)
 			if len(signatures) != len(policies) {
 				logging.FromContext(ctx).Warnf("Failed to validate at least one policy for %s wanted %d policies, only validated %d", ref.Name(), len(policies), len(signatures))
 			} else {
 				logging.FromContext(ctx).Infof("Validated %d policies for image %s", len(signatures), containerImage)
 			}
-			return errorsToFieldErrors(containerImage, field, index, fieldErrors)
+			errs = errs.Also(errorsToFieldErrors(containerImage, field, index, fieldErrors))
+		} else if len(exempted) > 0 {
+			reportEvaluation(ctx, namespace, kind, apiVersion, containerImage, policies, exempted, nil, nil)
 		}
-		// Container matched no policies, so return based on the configured
-		// NoMatchPolicy.
-		return setNoMatchingPoliciesError(ctx, containerImage, field, index)
+		return errs
 	}
 	return nil
 }
 
+// applyPolicyExceptions removes the policies that the image is exempted from
+// by a PolicyException from policies, and returns them. Every exemption is
+// surfaced as a warning, so that it is visible to whoever created the
+// resource.
+func applyPolicyExceptions(ctx context.Context, pe *config.PolicyExceptionsConfig, namespace, image, containerImage, field string, index int, policies map[string]webhookcip.ClusterImagePolicy) (map[string]PolicyEvaluation, *apis.FieldError) {
+	if pe == nil || len(policies) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(policies))
+	for name := range policies {
+		names = append(names, name)
+	}
+	exemptions, err := pe.GetExemptions(namespace, image, names, time.Now())
+	if err != nil {
+		// A bad PolicyException can only make us evaluate more policies, so
+		// carry on with the ones that we could match.
+		logging.FromContext(ctx).Warnf("Failed to match PolicyExceptions for %s: %v", image, err)
+	}
+
+	var errs *apis.FieldError
+	exempted := make(map[string]PolicyEvaluation, len(exemptions))
+	for policy, exception := range exemptions {
+		e := pe.Exceptions[exception]
+		logging.FromContext(ctx).Infof("Image %s in namespace %s is exempted from policy %s by PolicyException %s", containerImage, namespace, policy, exception)
+		exempted[policy] = PolicyEvaluation{
+			Mode:      policies[policy].Mode,
+			Exception: exception,
+		}
+		delete(policies, policy)
+
+		warnField := apis.ErrGeneric(fmt.Sprintf("policy %s skipped by PolicyException %s", policy, exception), "image").ViaFieldIndex(field, index)
+		warnField.Details = fmt.Sprintf("%s exempted until %s: %s", containerImage, e.Expires.UTC().Format(time.RFC3339), e.Justification)
+		errs = errs.Also(warnField.At(apis.WarningLevel))
+	}
+	return exempted, errs
+}
+
+// subjectPolicyWarnings returns a warning for each of the policies that
+// would match the image if it was not for their subjects.
+func subjectPolicyWarnings(ctx context.Context, ipc *config.ImagePolicyConfig, namespace, image, containerImage, field string, index int, kind, apiVersion string, labels, namespaceLabels map[string]string) *apis.FieldError {
+	skipped, err := ipc.GetSubjectPolicies(namespace, image, kind, apiVersion, labels, namespaceLabels)
+	if err != nil {
+		logging.FromContext(ctx).Warnf("Failed to match the subjects of policies for %s: %v", image, err)
+	}
+	var errs *apis.FieldError
+	for _, policy := range skipped {
+		warnField := apis.ErrGeneric(fmt.Sprintf("policy %s skipped, its subjects can only be matched on admission", policy), "image").ViaFieldIndex(field, index)
+		warnField.Details = containerImage
+		errs = errs.Also(warnField.At(apis.WarningLevel))
+	}
+	return errs
+}
+
 func errorsToFieldErrors(image, field string, index int, fieldErrors map[string][]error) (errs *apis.FieldError) {
 	// Do we really want to add all the error details here?
 	// Seems like we can just say which policy failed, so
@@ -1320,6 +1824,16 @@ func refOrFieldError(image, field string, index int) *apis.FieldError {
 	return nil
 }
 
+// registryRemoteOptions returns the ggcr remote options for fetching the
+// image itself, rather than its signatures, from the registry.
+func registryRemoteOptions(ctx context.Context, kc authn.Keychain) []remote.Option {
+	return []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(kc),
+		remote.WithTransport(tracing.Transport(remote.DefaultTransport)),
+	}
+}
+
 // configFileResult is used to communicate results from gofuncs that fetch
 // ConfigFiles for a given image.
 // Because this can be recursive (say, multi-arch image), returns a map where
@@ -1422,6 +1936,89 @@ func normalizeArchitecture(cf *v1.ConfigFile) string {
 	}.String()
 }
 
+// platformManifest is a platform manifest of an image index.
+type platformManifest struct {
+	platform string
+	ref      name.Digest
+}
+
+// getPlatformManifests returns the platform manifests of the image index ref
+// that satisfy one of the platforms, or all of them if no platform is
+// specified. Nested indexes are walked recursively. If ref is not an index,
+// there are no platform manifests.
+func getPlatformManifests(ref name.Reference, platforms []string, options ...remote.Option) ([]platformManifest, error) {
+	specs := make([]v1.Platform, 0, len(platforms))
+	for _, platform := range platforms {
+		spec, err := common.ParsePlatform(platform)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, *spec)
+	}
+	descriptor, err := remote.Get(ref, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ref %s : %w", ref.String(), err)
+	}
+	switch descriptor.MediaType {
+	case types.OCIImageIndex, types.DockerManifestList:
+		ii, err := descriptor.ImageIndex()
+		if err != nil {
+			return nil, fmt.Errorf("getting ImageIndex for %s : %w", ref.String(), err)
+		}
+		return indexPlatformManifests(ref.Context(), ii, specs)
+	default:
+		return nil, nil
+	}
+}
+
+func indexPlatformManifests(repo name.Repository, ii v1.ImageIndex, specs []v1.Platform) ([]platformManifest, error) {
+	im, err := ii.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("getting IndexManifest for %s : %w", repo.String(), err)
+	}
+	var ret []platformManifest
+	for _, manifest := range im.Manifests {
+		if manifest.MediaType.IsIndex() {
+			child, err := ii.ImageIndex(manifest.Digest)
+			if err != nil {
+				return nil, fmt.Errorf("getting ImageIndex for %s : %w", repo.Digest(manifest.Digest.String()).String(), err)
+			}
+			childManifests, err := indexPlatformManifests(repo, child, specs)
+			if err != nil {
+				return nil, err
+			}
+			ret = append(ret, childManifests...)
+			continue
+		}
+		platform := v1.Platform{}
+		if manifest.Platform != nil {
+			platform = *manifest.Platform
+		}
+		// Attestation manifests, as pushed by BuildKit, are not pulled by
+		// the nodes.
+		if platform.OS == "unknown" && platform.Architecture == "unknown" {
+			continue
+		}
+		if len(specs) > 0 && !satisfiesAny(platform, specs) {
+			continue
+		}
+		ret = append(ret, platformManifest{
+			platform: platform.String(),
+			ref:      repo.Digest(manifest.Digest.String()),
+		})
+	}
+	return ret, nil
+}
+
+func satisfiesAny(platform v1.Platform, specs []v1.Platform) bool {
+	for _, spec := range specs {
+		if platform.Satisfies(spec) {
+			return true
+		}
+	}
+	return false
+}
+
 // checkOptsFromAuthority creates the necessary options for calling Cosign
 // verify functions (signatures and attestations).
 func checkOptsFromAuthority(ctx context.Context, authority webhookcip.Authority, remoteOpts ...ociremote.Option) (*cosign.CheckOpts, error) {
@@ -1447,66 +2044,67 @@ func checkOptsFromAuthority(ctx context.Context, authority webhookcip.Authority,
 	}
 
 	if ret.NewBundleFormat {
-		// The new bundle format is only supported for keyless authorities
-		// and the trustRootRef must be set.
-		if authority.Keyless == nil {
-			// TODO: Support the new bundle format for non-keyless authorities
-			return nil, fmt.Errorf("when using the new bundle format, the authority must be keyless")
-		}
-		trustRootRef := authority.Keyless.TrustRootRef
-		if trustRootRef != "" {
-			// Set up TrustedMaterial
-			sigstoreKeys, err := sigstoreKeysFromContext(ctx, trustRootRef)
-			if err != nil {
-				return nil, fmt.Errorf("getting SigstoreKeys: %w", err)
+		// The new bundle format is only supported for key and keyless
+		// authorities. The trusted material is taken from the trustRootRef
+		// of the keyless authority, or of the TLog and TSA for key
+		// authorities, and from the TUF root if it is not set.
+		var trustRootRef string
+		switch {
+		case authority.Keyless != nil:
+			trustRootRef = authority.Keyless.TrustRootRef
+			if authority.Keyless.InsecureIgnoreSCT != nil && *authority.Keyless.InsecureIgnoreSCT {
+				ret.IgnoreSCT = *authority.Keyless.InsecureIgnoreSCT
 			}
-			sk, ok := sigstoreKeys.SigstoreKeys[trustRootRef]
-			if !ok {
-				return nil, fmt.Errorf("trustRootRef %s not found", trustRootRef)
+			if tsa := authority.RFC3161Timestamp; tsa != nil && tsa.TrustRootRef != trustRootRef {
+				return nil, fmt.Errorf("when using the new bundle format, the trustRootRef for the TSA must be the same as the trustRootRef for the Keyless authority")
 			}
-			ret.TrustedMaterial, err = root.NewTrustedRootFromProtobuf(sk)
-			if err != nil {
-				return nil, fmt.Errorf("failed to create trusted root from protobuf: %w", err)
+			if tlog := authority.CTLog; tlog != nil && tlog.TrustRootRef != trustRootRef {
+				return nil, fmt.Errorf("when using the new bundle format, the trustRootRef for the TLog must be the same as the trustRootRef for the Keyless authority")
 			}
-		} else {
-			var err error
-			ret.TrustedMaterial, err = pctuf.GetTrustedRoot(ctx)
-			if err != nil {
-				return nil, fmt.Errorf("failed to fetch trusted root: %w", err)
+		case authority.Key != nil:
+			// Signatures made with a key do not have a certificate, so
+			// there is no SCT to verify.
+			ret.IgnoreSCT = true
+			if authority.CTLog == nil && authority.RFC3161Timestamp == nil {
+				// Without a TLog or TSA, the bundle is only verified
+				// against the key.
+				ret.IgnoreTlog = true
+				ret.TrustedMaterial = &root.BaseTrustedMaterial{}
+				return ret, nil
 			}
+			if authority.CTLog != nil {
+				trustRootRef = authority.CTLog.TrustRootRef
+			}
+			if tsa := authority.RFC3161Timestamp; tsa != nil {
+				if authority.CTLog != nil && tsa.TrustRootRef != trustRootRef {
+					return nil, fmt.Errorf("when using the new bundle format, the trustRootRef for the TSA must be the same as the trustRootRef for the TLog")
+				}
+				trustRootRef = tsa.TrustRootRef
+			}
+		default:
+			return nil, fmt.Errorf("when using the new bundle format, the authority must be key or keyless")
 		}
-		if authority.Keyless.InsecureIgnoreSCT != nil && *authority.Keyless.InsecureIgnoreSCT {
-			ret.IgnoreSCT = *authority.Keyless.InsecureIgnoreSCT
+
+		var err error
+		ret.TrustedMaterial, err = trustedMaterialFromTrustRootRef(ctx, trustRootRef)
+		if err != nil {
+			return nil, err
 		}
 
-		// Check for custom TSA
-		tsa := authority.RFC3161Timestamp
-		if tsa != nil {
-			if tsa.TrustRootRef != authority.Keyless.TrustRootRef {
-				return nil, fmt.Errorf("when using the new bundle format, the trustRootRef for the TSA must be the same as the trustRootRef for the Keyless authority")
-			}
+		// Only require the TLog if we're not using signed timestamps
+		if authority.RFC3161Timestamp != nil {
 			ret.UseSignedTimestamps = true
 			ret.IgnoreTlog = true
 		}
-
-		// Check for custom Rekor
-		tlog := authority.CTLog
-		if tlog != nil {
-			if tlog.TrustRootRef != authority.Keyless.TrustRootRef {
-				return nil, fmt.Errorf("when using the new bundle format, the trustRootRef for the TLog must be the same as the trustRootRef for the Keyless authority")
-			}
-			// Only require the TLog if we're not using signed timestamps
-			if ret.UseSignedTimestamps {
-				ret.IgnoreTlog = true
-			}
-		}
 		return ret, nil
 	}
 
 	// If we're not using the new bundle verifier (TrustedMaterial), we need to assemble the other CheckOpts (Fulcio, Rekor, TSA, etc.)
 
 	if authority.Keyless != nil {
-		fulcioRoots, fulcioIntermediates, ctlogKeys, err := fulcioCertsFromAuthority(ctx, authority.Keyless)
+		fulcioCtx, span := tracing.Start(ctx, "fulcioCertsFromAuthority", tracing.TrustRootKey.String(authority.Keyless.TrustRootRef))
+		fulcioRoots, fulcioIntermediates, ctlogKeys, err := fulcioCertsFromAuthority(fulcioCtx, authority.Keyless)
+		tracing.End(span, err)
 		if err != nil {
 			return nil, fmt.Errorf("getting Fulcio certs: %s: %w", authority.Name, err)
 		}
@@ -1517,7 +2115,19 @@ func checkOptsFromAuthority(ctx context.Context, authority webhookcip.Authority,
 			ret.IgnoreSCT = *authority.Keyless.InsecureIgnoreSCT
 		}
 	}
-	rekorClient, rekorPubKeys, err := rekorClientAndKeysFromAuthority(ctx, authority)
+	if authority.Certificate != nil {
+		cas, err := certificateAuthorities(ctx, authority.Certificate.CABundle, authority.Certificate.TrustRootRef)
+		if err != nil {
+			return nil, fmt.Errorf("getting certificate authorities: %s: %w", authority.Name, err)
+		}
+		ret.RootCerts, ret.IntermediateCerts = certificate.Pools(cas)
+		// Certificates that are not issued by Fulcio are not logged to a
+		// certificate transparency log.
+		ret.IgnoreSCT = true
+	}
+	rekorCtx, span := tracing.Start(ctx, "rekorClientAndKeysFromAuthority")
+	rekorClient, rekorPubKeys, err := rekorClientAndKeysFromAuthority(rekorCtx, authority)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("getting Rekor public keys: %s: %w", authority.Name, err)
 	}
@@ -1569,6 +2179,33 @@ func checkOptsFromAuthority(ctx context.Context, authority webhookcip.Authority,
 	return ret, nil
 }
 
+// trustedMaterialFromTrustRootRef returns the trusted material of the
+// TrustRoot trustRootRef, or of the TUF root if trustRootRef is not set.
+func trustedMaterialFromTrustRootRef(ctx context.Context, trustRootRef string) (root.TrustedMaterial, error) {
+	if trustRootRef == "" {
+		tufCtx, span := tracing.Start(ctx, "tuf.GetTrustedRoot")
+		trustedRoot, err := pctuf.GetTrustedRoot(tufCtx)
+		tracing.End(span, err)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch trusted root: %w", err)
+		}
+		return trustedRoot, nil
+	}
+	sigstoreKeys, err := sigstoreKeysFromContext(ctx, trustRootRef)
+	if err != nil {
+		return nil, fmt.Errorf("getting SigstoreKeys: %w", err)
+	}
+	sk, ok := sigstoreKeys.SigstoreKeys[trustRootRef]
+	if !ok {
+		return nil, fmt.Errorf("trustRootRef %s not found", trustRootRef)
+	}
+	trustedRoot, err := root.NewTrustedRootFromProtobuf(sk)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trusted root from protobuf: %w", err)
+	}
+	return trustedRoot, nil
+}
+
 func sigstoreKeysFromContext(ctx context.Context, trustRootRef string) (*config.SigstoreKeysMap, error) {
 	config := config.FromContext(ctx)
 	if config == nil {
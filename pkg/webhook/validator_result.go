@@ -16,6 +16,8 @@
 package webhook
 
 import (
+	"encoding/json"
+
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 )
 
@@ -103,6 +105,10 @@ type PolicySignature struct {
 	// GithubExtensions holds the Github-related OID extensions.
 	// See also: https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
 	GithubExtensions `json:",inline"`
+
+	// FulcioExtensions holds the provider independent OID extensions.
+	// See also: https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+	FulcioExtensions `json:",inline"`
 }
 
 // PolicyAttestation contains a normalized result of a validated attestation,
@@ -117,9 +123,17 @@ type PolicyAttestation struct {
 	// Payload is the bytes of the in-toto statement's predicate payload.
 	// This is included for the benefit of the caller of ValidatePolicy, and is
 	// not intended for consumption in the ClusterImagePolicy's outer policy
-	// block.
+	// block (see Predicate).
 	Payload []byte `json:"-"`
 
+	// Predicate is the in-toto statement's predicate.
+	//
+	// This field is only filled in the document the CIP level policy is
+	// evaluated against, and only if
+	// CIP.Spec.Policy.IncludeAttestationPayloads is set to true. It is never
+	// set in the PolicyResult returned by ValidatePolicy.
+	Predicate json.RawMessage `json:"predicate,omitempty"`
+
 	// Digest of the attestation
 	Digest string `json:"digest,omitempty"`
 }
@@ -140,3 +154,60 @@ type GithubExtensions struct {
 	// OID: 1.3.6.1.4.1.57264.1.6
 	WorkflowRef string `json:"githubWorkflowRef,omitempty"`
 }
+
+// FulcioExtensions holds the OID extensions that Fulcio sets for any CI
+// provider, which supersede the GithubExtensions.
+// See also: https://github.com/sigstore/fulcio/blob/main/docs/oid-info.md
+// NOTE: these field names correlate with the names that keyless identities
+// match the extensions by.
+type FulcioExtensions struct {
+	// OID: 1.3.6.1.4.1.57264.1.9
+	BuildSignerURI string `json:"buildSignerURI,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.10
+	BuildSignerDigest string `json:"buildSignerDigest,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.11
+	RunnerEnvironment string `json:"runnerEnvironment,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.12
+	SourceRepositoryURI string `json:"sourceRepositoryURI,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.13
+	SourceRepositoryDigest string `json:"sourceRepositoryDigest,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.14
+	SourceRepositoryRef string `json:"sourceRepositoryRef,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.15
+	SourceRepositoryIdentifier string `json:"sourceRepositoryIdentifier,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.16
+	SourceRepositoryOwnerURI string `json:"sourceRepositoryOwnerURI,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.17
+	SourceRepositoryOwnerIdentifier string `json:"sourceRepositoryOwnerIdentifier,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.18
+	BuildConfigURI string `json:"buildConfigURI,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.19
+	BuildConfigDigest string `json:"buildConfigDigest,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.20
+	BuildTrigger string `json:"buildTrigger,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.21
+	RunInvocationURI string `json:"runInvocationURI,omitempty"`
+	// OID: 1.3.6.1.4.1.57264.1.22
+	SourceRepositoryVisibilityAtSigning string `json:"sourceRepositoryVisibilityAtSigning,omitempty"`
+}
+
+// byName returns the extensions keyed by the names that keyless identities
+// match them by.
+func (e FulcioExtensions) byName() map[string]string {
+	return map[string]string{
+		"buildSignerURI":                      e.BuildSignerURI,
+		"buildSignerDigest":                   e.BuildSignerDigest,
+		"runnerEnvironment":                   e.RunnerEnvironment,
+		"sourceRepositoryURI":                 e.SourceRepositoryURI,
+		"sourceRepositoryDigest":              e.SourceRepositoryDigest,
+		"sourceRepositoryRef":                 e.SourceRepositoryRef,
+		"sourceRepositoryIdentifier":          e.SourceRepositoryIdentifier,
+		"sourceRepositoryOwnerURI":            e.SourceRepositoryOwnerURI,
+		"sourceRepositoryOwnerIdentifier":     e.SourceRepositoryOwnerIdentifier,
+		"buildConfigURI":                      e.BuildConfigURI,
+		"buildConfigDigest":                   e.BuildConfigDigest,
+		"buildTrigger":                        e.BuildTrigger,
+		"runInvocationURI":                    e.RunInvocationURI,
+		"sourceRepositoryVisibilityAtSigning": e.SourceRepositoryVisibilityAtSigning,
+	}
+}
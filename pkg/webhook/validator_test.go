@@ -37,7 +37,12 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	v1types "github.com/google/go-containerregistry/pkg/v1/types"
 	"github.com/sigstore/cosign/v3/pkg/cosign"
 	"github.com/sigstore/cosign/v3/pkg/cosign/bundle"
@@ -49,6 +54,7 @@ import (
 	"github.com/sigstore/policy-controller/pkg/apis/policy/v1alpha1"
 	"github.com/sigstore/policy-controller/pkg/apis/signaturealgo"
 	policycontrollerconfig "github.com/sigstore/policy-controller/pkg/config"
+	"github.com/sigstore/policy-controller/pkg/slsa"
 	webhookcip "github.com/sigstore/policy-controller/pkg/webhook/clusterimagepolicy"
 	pbcommon "github.com/sigstore/protobuf-specs/gen/pb-go/common/v1"
 	"github.com/sigstore/sigstore-go/pkg/root"
@@ -56,6 +62,7 @@ import (
 	"github.com/sigstore/sigstore/pkg/fulcioroots"
 	"github.com/sigstore/sigstore/pkg/tuf"
 	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -3352,6 +3359,126 @@ func TestPolicyControllerConfigNoMatchPolicy(t *testing.T) {
 	}
 }
 
+func TestPolicyExceptions(t *testing.T) {
+	digest := "gcr.io/distroless/static:nonroot@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	policies := &config.ImagePolicyConfig{
+		Policies: map[string]webhookcip.ClusterImagePolicy{
+			"signed-images": {
+				Images: []v1alpha1.ImagePattern{{Glob: "gcr.io/*/*"}},
+				Authorities: []webhookcip.Authority{{
+					Name:   "authority-0",
+					Static: &webhookcip.StaticRef{Action: "fail"},
+				}},
+			},
+		},
+	}
+	exceptions := &config.PolicyExceptionsConfig{
+		Exceptions: map[string]config.PolicyException{
+			"distroless-exception": {
+				Images:        []v1alpha1.ImagePattern{{Glob: "gcr.io/distroless/*"}},
+				Namespaces:    []string{"vendor"},
+				Policies:      []string{"signed-images"},
+				Expires:       metav1.NewTime(time.Now().Add(time.Hour)),
+				Justification: "TICKET-123",
+			},
+			"expired-exception": {
+				Images:        []v1alpha1.ImagePattern{{Glob: "gcr.io/distroless/*"}},
+				Namespaces:    []string{"expired"},
+				Policies:      []string{"signed-images"},
+				Expires:       metav1.NewTime(time.Now().Add(-time.Hour)),
+				Justification: "TICKET-456",
+			},
+		},
+	}
+	ctx = config.ToContext(ctx, &config.Config{ImagePolicyConfig: policies, PolicyExceptionsConfig: exceptions})
+	v := NewValidator(ctx)
+
+	tests := []struct {
+		name      string
+		namespace string
+		wantErr   string
+		wantWarn  string
+	}{{
+		name:      "exempted",
+		namespace: "vendor",
+		wantWarn:  "policy signed-images skipped by PolicyException distroless-exception",
+	}, {
+		name:      "not exempted in namespace",
+		namespace: "default",
+		wantErr:   "failed policy: signed-images",
+	}, {
+		name:      "exception expired",
+		namespace: "expired",
+		wantErr:   "failed policy: signed-images",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := v.validateContainerImage(ctx, digest, tc.namespace, "containers", 0, "Pod", "v1", map[string]string{}, nil, nil)
+			if errs := got.Filter(apis.ErrorLevel); (errs != nil) != (tc.wantErr != "") {
+				t.Errorf("validateContainerImage() errors = %v, wanted %q", errs, tc.wantErr)
+			} else if errs != nil && !strings.Contains(errs.Error(), tc.wantErr) {
+				t.Errorf("validateContainerImage() errors = %v, wanted %q", errs, tc.wantErr)
+			}
+			if warns := got.Filter(apis.WarningLevel); (warns != nil) != (tc.wantWarn != "") {
+				t.Errorf("validateContainerImage() warnings = %v, wanted %q", warns, tc.wantWarn)
+			} else if warns != nil && !strings.Contains(warns.Error(), tc.wantWarn) {
+				t.Errorf("validateContainerImage() warnings = %v, wanted %q", warns, tc.wantWarn)
+			}
+		})
+	}
+}
+
+func TestSubjectPolicyWarnings(t *testing.T) {
+	digest := "gcr.io/distroless/static:nonroot@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"
+
+	ctx, _ := rtesting.SetupFakeContext(t)
+	policies := &config.ImagePolicyConfig{
+		Policies: map[string]webhookcip.ClusterImagePolicy{
+			"everyone": {
+				Images: []v1alpha1.ImagePattern{{Glob: "gcr.io/*/*"}},
+				Authorities: []webhookcip.Authority{{
+					Name:   "authority-0",
+					Static: &webhookcip.StaticRef{Action: "pass"},
+				}},
+			},
+			"ci-only": {
+				Images: []v1alpha1.ImagePattern{{Glob: "gcr.io/*/*"}},
+				Match: []v1alpha1.MatchResource{{Subjects: []v1alpha1.Subject{
+					{Kind: "ServiceAccount", Namespace: "ci", Name: "deployer"},
+				}}},
+				Authorities: []webhookcip.Authority{{
+					Name:   "authority-0",
+					Static: &webhookcip.StaticRef{Action: "fail"},
+				}},
+			},
+		},
+	}
+	ctx = config.ToContext(ctx, &config.Config{ImagePolicyConfig: policies})
+	v := NewValidator(ctx)
+
+	// Without a request the policy for the subjects is not evaluated, and
+	// that is surfaced as a warning.
+	got := v.validateContainerImage(ctx, digest, "default", "containers", 0, "Pod", "v1", map[string]string{}, nil, nil)
+	if errs := got.Filter(apis.ErrorLevel); errs != nil {
+		t.Errorf("validateContainerImage() errors = %v, wanted none", errs)
+	}
+	if warns := got.Filter(apis.WarningLevel); warns == nil || !strings.Contains(warns.Error(), "policy ci-only skipped, its subjects can only be matched on admission") {
+		t.Errorf("validateContainerImage() warnings = %v, wanted ci-only skipped", warns)
+	}
+
+	// On admission by the subject, it is evaluated.
+	userInfo := &authenticationv1.UserInfo{Username: "system:serviceaccount:ci:deployer"}
+	got = v.validateContainerImage(ctx, digest, "default", "containers", 0, "Pod", "v1", map[string]string{}, userInfo, nil)
+	if errs := got.Filter(apis.ErrorLevel); errs == nil || !strings.Contains(errs.Error(), "failed policy: ci-only") {
+		t.Errorf("validateContainerImage() errors = %v, wanted ci-only failed", errs)
+	}
+	if warns := got.Filter(apis.WarningLevel); warns != nil {
+		t.Errorf("validateContainerImage() warnings = %v, wanted none", warns)
+	}
+}
+
 func TestFulcioCertsFromAuthority(t *testing.T) {
 	certs, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(certChain))
 	if err != nil {
@@ -3859,7 +3986,77 @@ func TestCheckOptsFromAuthority(t *testing.T) {
 			SignatureFormat: "bundle",
 		},
 		ctx:     testCtx,
-		wantErr: "when using the new bundle format, the authority must be keyless",
+		wantErr: "when using the new bundle format, the authority must be key or keyless",
+	}, {
+		name: "bundle format, key",
+		authority: webhookcip.Authority{
+			SignatureFormat: "bundle",
+			Key:             &webhookcip.KeyRef{},
+		},
+		ctx: testCtx,
+		wantCheckOpts: &cosign.CheckOpts{
+			NewBundleFormat: true,
+			IgnoreSCT:       true,
+			IgnoreTlog:      true,
+			TrustedMaterial: &root.BaseTrustedMaterial{},
+		},
+	}, {
+		name: "bundle format, key with Rekor",
+		authority: webhookcip.Authority{
+			SignatureFormat: "bundle",
+			Key:             &webhookcip.KeyRef{},
+			CTLog: &v1alpha1.TLog{
+				TrustRootRef: "test-trust-combined",
+			},
+		},
+		ctx: testCtx,
+		wantCheckOpts: &cosign.CheckOpts{
+			NewBundleFormat: true,
+			IgnoreSCT:       true,
+			TrustedMaterial: &root.TrustedRoot{},
+		},
+	}, {
+		name: "bundle format, key with TSA",
+		authority: webhookcip.Authority{
+			SignatureFormat: "bundle",
+			Key:             &webhookcip.KeyRef{},
+			RFC3161Timestamp: &webhookcip.RFC3161Timestamp{
+				TrustRootRef: "test-trust-combined",
+			},
+		},
+		ctx: testCtx,
+		wantCheckOpts: &cosign.CheckOpts{
+			NewBundleFormat:     true,
+			IgnoreSCT:           true,
+			IgnoreTlog:          true,
+			UseSignedTimestamps: true,
+			TrustedMaterial:     &root.TrustedRoot{},
+		},
+	}, {
+		name: "bundle format, key with bad TrustRootRef",
+		authority: webhookcip.Authority{
+			SignatureFormat: "bundle",
+			Key:             &webhookcip.KeyRef{},
+			CTLog: &v1alpha1.TLog{
+				TrustRootRef: "not-there",
+			},
+		},
+		ctx:     testCtx,
+		wantErr: "trustRootRef not-there not found",
+	}, {
+		name: "bundle format, key with different trustroots",
+		authority: webhookcip.Authority{
+			SignatureFormat: "bundle",
+			Key:             &webhookcip.KeyRef{},
+			CTLog: &v1alpha1.TLog{
+				TrustRootRef: "test-trust-rekor",
+			},
+			RFC3161Timestamp: &webhookcip.RFC3161Timestamp{
+				TrustRootRef: "test-trust-combined",
+			},
+		},
+		ctx:     testCtx,
+		wantErr: "when using the new bundle format, the trustRootRef for the TSA must be the same as the trustRootRef for the TLog",
 	}}
 
 	for _, tc := range tests {
@@ -4631,3 +4828,396 @@ func TestDiscoverAttestationsOCI11PartialProcessingFailure(t *testing.T) {
 		t.Errorf("Expected 2 signatures (second failed), got %d", len(sigs))
 	}
 }
+
+func TestValidatePolicyCEL(t *testing.T) {
+	digest := name.MustParseReference("gcr.io/distroless/static:nonroot@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4")
+	ctx := context.Background()
+	kc, err := k8schain.NewNoClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to construct no client k8schain for testing")
+	}
+
+	tests := []struct {
+		name       string
+		expression string
+		mode       string
+		wantErr    string
+		wantWarn   bool
+	}{{
+		name:       "pass",
+		expression: `input.authorityMatches["authority-0"].static`,
+	}, {
+		name:       "fail",
+		expression: `"authority-1" in input.authorityMatches`,
+		wantErr:    "failed evaluating cel policy for ClusterImagePolicy: expression evaluated to false",
+	}, {
+		name:       "warn",
+		expression: `"authority-1" in input.authorityMatches`,
+		mode:       "warn",
+		wantErr:    "failed evaluating cel policy for ClusterImagePolicy: expression evaluated to false",
+		wantWarn:   true,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cip := webhookcip.ClusterImagePolicy{
+				Authorities: []webhookcip.Authority{{
+					Name:   "authority-0",
+					Static: &webhookcip.StaticRef{Action: "pass"},
+				}},
+				Policy: &webhookcip.AttestationPolicy{
+					Type: "cel",
+					Data: tc.expression,
+				},
+				Mode: tc.mode,
+			}
+			got, gotErrs := ValidatePolicy(ctx, system.Namespace(), digest, cip, kc)
+			if tc.wantErr == "" {
+				if got == nil || len(gotErrs) != 0 {
+					t.Fatalf("ValidatePolicy() = %v, %v, wanted a result", got, gotErrs)
+				}
+				return
+			}
+			if got != nil || len(gotErrs) != 1 {
+				t.Fatalf("ValidatePolicy() = %v, %v, wanted a single error", got, gotErrs)
+			}
+			var fe *apis.FieldError
+			if !errors.As(gotErrs[0], &fe) {
+				t.Fatalf("ValidatePolicy() error = %T, wanted a FieldError", gotErrs[0])
+			}
+			if !strings.Contains(fe.Error(), tc.wantErr) {
+				t.Errorf("ValidatePolicy() error = %v, wanted %q", fe, tc.wantErr)
+			}
+			if isWarn := fe.Filter(apis.WarningLevel) != nil; isWarn != tc.wantWarn {
+				t.Errorf("ValidatePolicy() warning = %t, wanted %t", isWarn, tc.wantWarn)
+			}
+		})
+	}
+}
+
+func TestWithAttestationPayloads(t *testing.T) {
+	policyResult := &PolicyResult{
+		AuthorityMatches: map[string]AuthorityMatch{
+			"authority-0": {
+				Attestations: map[string][]PolicyAttestation{
+					"vuln": {{
+						PredicateType: "https://cosign.sigstore.dev/attestation/vuln/v1",
+						Payload:       []byte(`{"_type":"https://in-toto.io/Statement/v0.1","predicate":{"scanner":{"result":"ok"}}}`),
+					}, {
+						PredicateType: "https://cosign.sigstore.dev/attestation/vuln/v1",
+						Payload:       []byte(`not json`),
+					}},
+				},
+			},
+		},
+	}
+	got := withAttestationPayloads(context.Background(), policyResult)
+
+	atts := got.AuthorityMatches["authority-0"].Attestations["vuln"]
+	if got, want := string(atts[0].Predicate), `{"scanner":{"result":"ok"}}`; got != want {
+		t.Errorf("Predicate = %s, wanted %s", got, want)
+	}
+	if atts[1].Predicate != nil {
+		t.Errorf("Predicate for undecodable payload = %s, wanted nil", atts[1].Predicate)
+	}
+	if original := policyResult.AuthorityMatches["authority-0"].Attestations["vuln"][0].Predicate; original != nil {
+		t.Errorf("withAttestationPayloads() modified the PolicyResult, Predicate = %s", original)
+	}
+
+	jsonBytes, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("json.Marshal() = %v", err)
+	}
+	if !strings.Contains(string(jsonBytes), `"predicate":{"scanner":{"result":"ok"}}`) {
+		t.Errorf("marshaled PolicyResult %s does not contain the predicate", jsonBytes)
+	}
+}
+
+func TestNotAffectedVulnerabilities(t *testing.T) {
+	attestation := func(statement string) oci.Signature {
+		t.Helper()
+		envelope, err := json.Marshal(map[string]string{
+			"payloadType": "application/vnd.in-toto+json",
+			"payload":     base64.StdEncoding.EncodeToString([]byte(statement)),
+		})
+		if err != nil {
+			t.Fatalf("json.Marshal() = %v", err)
+		}
+		sig, err := static.NewSignature(envelope, "")
+		if err != nil {
+			t.Fatalf("static.NewSignature() = %v", err)
+		}
+		return sig
+	}
+	verifiedAttestations := []oci.Signature{
+		attestation(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://spdx.dev/Document","predicate":{}}`),
+		attestation(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://openvex.dev/ns/v0.2.0","subject":[{"digest":{"sha256":"be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"}}],"predicate":{"statements":[{"vulnerability":{"name":"CVE-2023-0001"},"status":"not_affected"},{"vulnerability":{"name":"CVE-2023-0002"},"status":"affected"}]}}`),
+		attestation(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://openvex.dev/ns","predicate":{"statements":[{"vulnerability":"CVE-2023-0003","products":["pkg:oci/static@sha256%3Abe5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"],"status":"fixed"}]}}`),
+		attestation(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://openvex.dev/ns/v0.2.0","subject":[{"digest":{"sha256":"be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4"}}],"predicate":{"statements":[{"vulnerability":{"name":"CVE-2023-0004"},"products":[{"@id":"pkg:oci/other@sha256%3A0000000000000000000000000000000000000000000000000000000000000000"}],"status":"not_affected"}]}}`),
+		attestation(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://openvex.dev/ns/v0.2.0","subject":[{"digest":{"sha256":"0000000000000000000000000000000000000000000000000000000000000000"}}],"predicate":{"statements":[{"vulnerability":{"name":"CVE-2023-0005"},"status":"not_affected"}]}}`),
+	}
+	digest := name.MustParseReference("gcr.io/distroless/static@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4")
+	got := notAffectedVulnerabilities(context.Background(), digest, verifiedAttestations)
+	if diff := cmp.Diff([]string{"CVE-2023-0001", "CVE-2023-0003"}, got); diff != "" {
+		t.Errorf("notAffectedVulnerabilities() diff (-want +got): %s", diff)
+	}
+
+	tag := name.MustParseReference("gcr.io/distroless/static:nonroot")
+	if got := notAffectedVulnerabilities(context.Background(), tag, verifiedAttestations); got != nil {
+		t.Errorf("notAffectedVulnerabilities() = %v, wanted nil for a tag", got)
+	}
+}
+
+func TestLatestVulnerabilityScan(t *testing.T) {
+	attestation := func(scanFinishedOn string) oci.Signature {
+		t.Helper()
+		statement := `{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://cosign.sigstore.dev/attestation/vuln/v1","predicate":{"metadata":{"scanFinishedOn":"` + scanFinishedOn + `"}}}`
+		envelope, err := json.Marshal(map[string]string{
+			"payloadType": "application/vnd.in-toto+json",
+			"payload":     base64.StdEncoding.EncodeToString([]byte(statement)),
+		})
+		if err != nil {
+			t.Fatalf("json.Marshal() = %v", err)
+		}
+		sig, err := static.NewSignature(envelope, "")
+		if err != nil {
+			t.Fatalf("static.NewSignature() = %v", err)
+		}
+		return sig
+	}
+	older := attestation("2026-10-01T10:00:00Z")
+	latest := attestation("2026-10-02T10:00:00Z")
+	unknown := attestation("0001-01-01T00:00:00Z")
+	want, err := latest.Digest()
+	if err != nil {
+		t.Fatalf("Digest() = %v", err)
+	}
+	got := latestVulnerabilityScan(context.Background(), "https://cosign.sigstore.dev/attestation/vuln/v1", []oci.Signature{older, latest, unknown})
+	if got != want.String() {
+		t.Errorf("latestVulnerabilityScan() = %s, wanted %s", got, want)
+	}
+}
+
+func TestAttestationPayloadJSONSLSA(t *testing.T) {
+	attestation := func(statement string) oci.Signature {
+		t.Helper()
+		envelope, err := json.Marshal(map[string]string{
+			"payloadType": "application/vnd.in-toto+json",
+			"payload":     base64.StdEncoding.EncodeToString([]byte(statement)),
+		})
+		if err != nil {
+			t.Fatalf("json.Marshal() = %v", err)
+		}
+		sig, err := static.NewSignature(envelope, "")
+		if err != nil {
+			t.Fatalf("static.NewSignature() = %v", err)
+		}
+		return sig
+	}
+	// The predicate type names neither version, both are still checked.
+	wantedAttestation := webhookcip.AttestationPolicy{
+		Name: "provenance",
+		SLSA: &v1alpha1.SLSA{
+			Builders: []v1alpha1.SLSABuilder{{ID: "https://github.com/actions/runner/github-hosted"}, {ID: "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml"}},
+		},
+	}
+	for _, test := range []struct {
+		statement         string
+		wantPredicateType string
+	}{{
+		statement:         `{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://slsa.dev/provenance/v0.2","predicate":{"builder":{"id":"https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_container_slsa3.yml@refs/tags/v1.9.0"}}}`,
+		wantPredicateType: slsa.PredicateTypeV02,
+	}, {
+		statement:         `{"_type":"https://in-toto.io/Statement/v1","predicateType":"https://slsa.dev/provenance/v1","predicate":{"runDetails":{"builder":{"id":"https://github.com/actions/runner/github-hosted"}}}}`,
+		wantPredicateType: slsa.PredicateTypeV1,
+	}} {
+		attBytes, gotPredicateType, err := attestationPayloadJSON(context.Background(), wantedAttestation, attestation(test.statement))
+		if err != nil {
+			t.Fatalf("attestationPayloadJSON() = %v", err)
+		}
+		if attBytes == nil || gotPredicateType != test.wantPredicateType {
+			t.Fatalf("attestationPayloadJSON() = %s, %s, wanted the %s statement", attBytes, gotPredicateType, test.wantPredicateType)
+		}
+		if err := slsa.Verify(wantedAttestation.SLSA, attBytes); err != nil {
+			t.Errorf("slsa.Verify(%s) = %v", test.wantPredicateType, err)
+		}
+	}
+
+	attBytes, _, err := attestationPayloadJSON(context.Background(), wantedAttestation, attestation(`{"_type":"https://in-toto.io/Statement/v0.1","predicateType":"https://spdx.dev/Document","predicate":{}}`))
+	if err != nil || attBytes != nil {
+		t.Errorf("attestationPayloadJSON() = %s, %v, wanted no payload for an SBOM", attBytes, err)
+	}
+}
+
+func TestValidatePolicyAuthorityThreshold(t *testing.T) {
+	digest := name.MustParseReference("gcr.io/distroless/static:nonroot@sha256:be5d77c62dbe7fedfb0a4e5ec2f91078080800ab1f18358e5f31fcc8faa023c4")
+	ctx := context.Background()
+	kc, err := k8schain.NewNoClient(ctx)
+	if err != nil {
+		t.Fatalf("Failed to construct no client k8schain for testing")
+	}
+	pass := &webhookcip.StaticRef{Action: "pass"}
+	fail := &webhookcip.StaticRef{Action: "fail", Message: "not signed by the security team"}
+
+	tests := []struct {
+		name      string
+		threshold int32
+		statics   []*webhookcip.StaticRef
+		wantErr   string
+	}{{
+		name:    "unset, one of two",
+		statics: []*webhookcip.StaticRef{pass, fail},
+	}, {
+		name:      "two of two",
+		threshold: 2,
+		statics:   []*webhookcip.StaticRef{pass, pass},
+	}, {
+		name:      "two of three",
+		threshold: 2,
+		statics:   []*webhookcip.StaticRef{pass, fail, pass},
+	}, {
+		name:      "one of two, two required",
+		threshold: 2,
+		statics:   []*webhookcip.StaticRef{pass, fail},
+		wantErr:   "1 of the required 2 authorities matched",
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cip := webhookcip.ClusterImagePolicy{AuthorityThreshold: tc.threshold}
+			for i, static := range tc.statics {
+				cip.Authorities = append(cip.Authorities, webhookcip.Authority{
+					Name:   fmt.Sprintf("authority-%d", i),
+					Static: static,
+				})
+			}
+			got, gotErrs := ValidatePolicy(ctx, system.Namespace(), digest, cip, kc)
+			if tc.wantErr == "" {
+				if got == nil {
+					t.Fatalf("ValidatePolicy() = nil, %v, wanted a result", gotErrs)
+				}
+				return
+			}
+			if got != nil {
+				t.Fatalf("ValidatePolicy() = %v, wanted nil", got)
+			}
+			found := false
+			for _, err := range gotErrs {
+				if strings.Contains(err.Error(), tc.wantErr) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("ValidatePolicy() errors = %v, wanted %q", gotErrs, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetPlatformManifests(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	newImage := func() v1.Image {
+		img, err := random.Image(100, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return img
+	}
+	amd64, arm64, armv7, attestation := newImage(), newImage(), newImage(), newImage()
+	nested := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+		Add:        armv7,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm", Variant: "v7"}},
+	})
+	index := mutate.AppendManifests(empty.Index, mutate.IndexAddendum{
+		Add:        amd64,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "amd64"}},
+	}, mutate.IndexAddendum{
+		Add:        arm64,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"}},
+	}, mutate.IndexAddendum{
+		Add:        attestation,
+		Descriptor: v1.Descriptor{Platform: &v1.Platform{OS: "unknown", Architecture: "unknown"}},
+	}, mutate.IndexAddendum{
+		Add: nested,
+	})
+	indexRef, err := name.ParseReference(strings.TrimPrefix(server.URL, "http://") + "/test/image:index")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ggcrremote.WriteIndex(indexRef, index); err != nil {
+		t.Fatal(err)
+	}
+	imageRef := indexRef.Context().Tag("image")
+	if err := ggcrremote.Write(imageRef, amd64); err != nil {
+		t.Fatal(err)
+	}
+	digest := func(img v1.Image) string {
+		d, err := img.Digest()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d.String()
+	}
+
+	tests := []struct {
+		name      string
+		ref       name.Reference
+		platforms []string
+		want      map[string]string
+		wantErr   string
+	}{{
+		name: "all platforms",
+		ref:  indexRef,
+		want: map[string]string{
+			"linux/amd64":    digest(amd64),
+			"linux/arm64/v8": digest(arm64),
+			"linux/arm/v7":   digest(armv7),
+		},
+	}, {
+		name:      "some platforms",
+		ref:       indexRef,
+		platforms: []string{"linux/arm64", "linux/arm/v7"},
+		want: map[string]string{
+			"linux/arm64/v8": digest(arm64),
+			"linux/arm/v7":   digest(armv7),
+		},
+	}, {
+		name:      "no matching platform",
+		ref:       indexRef,
+		platforms: []string{"windows/amd64"},
+		want:      map[string]string{},
+	}, {
+		name: "not an index",
+		ref:  imageRef,
+		want: map[string]string{},
+	}, {
+		name:      "invalid platform",
+		ref:       indexRef,
+		platforms: []string{"linux"},
+		wantErr:   `platform "linux" is not in os/arch[/variant] form`,
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			manifests, err := getPlatformManifests(tc.ref, tc.platforms)
+			if tc.wantErr != "" {
+				if err == nil || err.Error() != tc.wantErr {
+					t.Fatalf("getPlatformManifests() = %v, wanted %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getPlatformManifests() = %v", err)
+			}
+			got := make(map[string]string, len(manifests))
+			for _, manifest := range manifests {
+				if manifest.ref.Context() != indexRef.Context() {
+					t.Errorf("platform %s is in %s, wanted %s", manifest.platform, manifest.ref.Context(), indexRef.Context())
+				}
+				got[manifest.platform] = manifest.ref.DigestStr()
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("getPlatformManifests() (-want, +got): %s", diff)
+			}
+		})
+	}
+}